@@ -41,3 +41,12 @@ func (fb *filterBackend) GetBorBlockLogs(ctx context.Context, hash common.Hash)
 func (fb *filterBackend) SubscribeStateSyncEvent(ch chan<- core.StateSyncEvent) event.Subscription {
 	return fb.bc.SubscribeStateSyncEvent(ch)
 }
+
+// SubscribeFinalityEvent subscribes to whitelisted checkpoints and milestones,
+// which the simulated backend doesn't have
+func (fb *filterBackend) SubscribeFinalityEvent(ch chan<- core.FinalityEvent) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+}
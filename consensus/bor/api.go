@@ -1,9 +1,9 @@
 package bor
 
 import (
+	"context"
 	"encoding/hex"
 	"math"
-	"math/big"
 	"sort"
 	"strconv"
 	"sync"
@@ -11,8 +11,11 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	lru "github.com/hashicorp/golang-lru"
@@ -271,6 +274,139 @@ func (api *API) GetCurrentValidators() ([]*valset.Validator, error) {
 	return snap.ValidatorSet.Validators, nil
 }
 
+// GetValidatorPerformance reports, for every validator, the blocks it was the
+// in-turn proposer of, the blocks it produced in turn or as backup and the
+// sprints it missed, over the given block range.
+func (api *API) GetValidatorPerformance(fromBlock rpc.BlockNumber, toBlock rpc.BlockNumber) (*PerformanceReport, error) {
+	from, err := api.resolveBlockNumber(fromBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := api.resolveBlockNumber(toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.bor.validatorPerformance(api.chain, from, to)
+}
+
+// ValidatorPerformance streams the validator performance of every sprint once
+// its last block is imported.
+func (api *API) ValidatorPerformance(ctx context.Context) (*rpc.Subscription, error) {
+	subscriber, ok := api.chain.(chainHeadSubscriber)
+	if !ok {
+		return nil, errChainEventsUnavailable
+	}
+
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		heads := make(chan core.ChainHeadEvent, 16)
+		headSub := subscriber.SubscribeChainHeadEvent(heads)
+
+		defer headSub.Unsubscribe()
+
+		// The sprint in progress is reported once it ends
+		next := api.bor.sprintStart(api.chain.CurrentHeader().Number.Uint64())
+
+		for {
+			select {
+			case head := <-heads:
+				number := head.Block.NumberU64()
+
+				var sprints [][2]uint64
+
+				sprints, next = api.bor.endedSprints(next, number)
+
+				for _, sprint := range sprints {
+					// The genesis block isn't produced by any validator
+					if sprint[0] == 0 {
+						continue
+					}
+
+					report, err := api.bor.validatorPerformance(api.chain, sprint[0], sprint[1])
+					if err != nil {
+						log.Debug("Failed to report sprint performance", "start", sprint[0], "end", sprint[1], "err", err)
+						continue
+					}
+
+					_ = notifier.Notify(rpcSub.ID, report)
+				}
+
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// resolveBlockNumber converts block tags like latest to the number of the
+// current header.
+func (api *API) resolveBlockNumber(number rpc.BlockNumber) (uint64, error) {
+	if number >= 0 {
+		return uint64(number.Int64()), nil
+	}
+
+	if number != rpc.LatestBlockNumber {
+		return 0, errUnknownBlock
+	}
+
+	header := api.chain.CurrentHeader()
+	if header == nil {
+		return 0, errUnknownBlock
+	}
+
+	return header.Number.Uint64(), nil
+}
+
+// SimulateStateSync commits the state-sync events pending in heimdall on top
+// of the latest block, reporting the gas used, logs and failures of each event.
+// No block is produced and the chain state is left untouched.
+func (api *API) SimulateStateSync(ctx context.Context) (*StateSyncSimulation, error) {
+	header := api.chain.CurrentHeader()
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+
+	return api.bor.SimulateStateSyncs(ctx, api.chain, header)
+}
+
+// ConfigAt is the effective bor configuration at a block, along with the
+// parameter transitions scheduled after it.
+type ConfigAt struct {
+	*params.BorParams
+	Upcoming []params.BorTransition `json:"upcoming"`
+}
+
+// GetConfigAt resolves the effective bor configuration at the given block (or
+// the current one if none requested) from the fork schedule of the chain.
+func (api *API) GetConfigAt(number *rpc.BlockNumber) (*ConfigAt, error) {
+	latest := rpc.LatestBlockNumber
+	if number == nil {
+		number = &latest
+	}
+
+	n, err := api.resolveBlockNumber(*number)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConfigAt{
+		BorParams: api.bor.config.ParamsAt(n),
+		Upcoming:  api.bor.config.Transitions(n),
+	}, nil
+}
+
 // GetRootHash returns the merkle root of the start to end block headers
 func (api *API) GetRootHash(start uint64, end uint64) (string, error) {
 	if err := api.initializeRootHashCache(); err != nil {
@@ -283,16 +419,81 @@ func (api *API) GetRootHash(start uint64, end uint64) (string, error) {
 		return root.(string), nil
 	}
 
+	blockHeaders, err := api.checkpointHeaders(start, end)
+	if err != nil {
+		return "", err
+	}
+
+	rootHash, err := ComputeRootHash(blockHeaders)
+	if err != nil {
+		return "", err
+	}
+
+	root := hex.EncodeToString(rootHash)
+	api.rootHashCache.Add(key, root)
+
+	return root, nil
+}
+
+// GetCheckpointProof returns the merkle branch proving the inclusion of the
+// header of a block in the root hash of the start to end block headers. The
+// trees of ranges covered by the whitelisted checkpoint are cached.
+func (api *API) GetCheckpointProof(start uint64, end uint64, number uint64) (*CheckpointProof, error) {
+	if number < start || number > end {
+		return nil, errCheckpointProofRange
+	}
+
+	key := getRootHashKey(start, end)
+
+	// Whitelisted blocks can't be reorged, but the tree of a range is only
+	// served from the cache if its end block is still the same
+	whitelisted := false
+
+	if api.bor.db != nil {
+		if whitelistedEnd, _, err := rawdb.ReadFinality[*rawdb.Checkpoint](api.bor.db); err == nil && end <= whitelistedEnd {
+			whitelisted = true
+		}
+	}
+
+	if whitelisted && api.bor.checkpointTrees != nil {
+		if cached, ok := api.bor.checkpointTrees.Get(key); ok {
+			tree := cached.(*checkpointTree)
+
+			if header := api.chain.GetHeaderByNumber(end); header != nil && header.Hash() == tree.endHash {
+				return tree.proof(end, number), nil
+			}
+		}
+	}
+
+	blockHeaders, err := api.checkpointHeaders(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := newCheckpointTree(start, blockHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	if whitelisted && api.bor.checkpointTrees != nil {
+		api.bor.checkpointTrees.Add(key, tree)
+	}
+
+	return tree.proof(end, number), nil
+}
+
+// checkpointHeaders returns the start to end block headers of a checkpoint
+func (api *API) checkpointHeaders(start uint64, end uint64) ([]*types.Header, error) {
 	length := end - start + 1
 
 	if length > MaxCheckpointLength {
-		return "", &MaxCheckpointLengthExceededError{start, end}
+		return nil, &MaxCheckpointLengthExceededError{start, end}
 	}
 
 	currentHeaderNumber := api.chain.CurrentHeader().Number.Uint64()
 
 	if start > end || end > currentHeaderNumber {
-		return "", &valset.InvalidStartEndBlockError{Start: start, End: end, CurrentHeader: currentHeaderNumber}
+		return nil, &valset.InvalidStartEndBlockError{Start: start, End: end, CurrentHeader: currentHeaderNumber}
 	}
 
 	blockHeaders := make([]*types.Header, end-start+1)
@@ -313,32 +514,24 @@ func (api *API) GetRootHash(start uint64, end uint64) (string, error) {
 	wg.Wait()
 	close(concurrent)
 
-	headers := make([][32]byte, nextPowerOfTwo(length))
-
-	for i := 0; i < len(blockHeaders); i++ {
-		blockHeader := blockHeaders[i]
-		header := crypto.Keccak256(appendBytes32(
-			blockHeader.Number.Bytes(),
-			new(big.Int).SetUint64(blockHeader.Time).Bytes(),
-			blockHeader.TxHash.Bytes(),
-			blockHeader.ReceiptHash.Bytes(),
-		))
+	return blockHeaders, nil
+}
 
-		var arr [32]byte
+// ComputeRootHash returns the merkle root of the given consecutive block headers,
+// as it is committed to the root chain in checkpoints.
+func ComputeRootHash(blockHeaders []*types.Header) ([]byte, error) {
+	headers := make([][32]byte, nextPowerOfTwo(uint64(len(blockHeaders))))
 
-		copy(arr[:], header)
-		headers[i] = arr
+	for i := 0; i < len(blockHeaders); i++ {
+		headers[i] = CheckpointLeaf(blockHeaders[i])
 	}
 
 	tree := merkle.NewTreeWithOpts(merkle.TreeOptions{EnableHashSorting: false, DisableHashLeaves: true})
 	if err := tree.Generate(convert(headers), sha3.NewLegacyKeccak256()); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	root := hex.EncodeToString(tree.Root().Hash)
-	api.rootHashCache.Add(key, root)
-
-	return root, nil
+	return tree.Root().Hash, nil
 }
 
 func (api *API) initializeRootHashCache() error {
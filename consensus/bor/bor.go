@@ -214,11 +214,16 @@ type Bor struct {
 	config      *params.BorConfig   // Consensus engine configuration parameters for bor consensus
 	db          ethdb.Database      // Database to store and retrieve snapshot checkpoints
 
-	recents    *lru.ARCCache // Snapshots for recent block to speed up reorgs
-	signatures *lru.ARCCache // Signatures of recent blocks to speed up mining
+	recents         *lru.ARCCache // Snapshots for recent block to speed up reorgs
+	signatures      *lru.ARCCache // Signatures of recent blocks to speed up mining
+	checkpointTrees *lru.ARCCache // Merkle trees of whitelisted checkpoints to serve proofs
 
 	authorizedSigner atomic.Pointer[signer] // Ethereum address and sign function of the signing key
 
+	snapshotConfig   SnapshotConfig // On-disk snapshot interval and pruning policy
+	snapshotsPruned  atomic.Uint64  // Block below which snapshots were last pruned
+	snapshotsPruning atomic.Bool    // Whether snapshots are being pruned
+
 	ethAPI                 api.Caller
 	spanner                Spanner
 	GenesisContractsClient GenesisContract
@@ -256,6 +261,7 @@ func New(
 	// Allocate the snapshot caches and create the engine
 	recents, _ := lru.NewARC(inmemorySnapshots)
 	signatures, _ := lru.NewARC(inmemorySignatures)
+	checkpointTrees, _ := lru.NewARC(inmemoryCheckpointTrees)
 
 	c := &Bor{
 		chainConfig:            chainConfig,
@@ -264,10 +270,12 @@ func New(
 		ethAPI:                 ethAPI,
 		recents:                recents,
 		signatures:             signatures,
+		checkpointTrees:        checkpointTrees,
 		spanner:                spanner,
 		GenesisContractsClient: genesisContracts,
 		HeimdallClient:         heimdallClient,
 		devFakeAuthor:          devFakeAuthor,
+		snapshotConfig:         DefaultSnapshotConfig,
 	}
 
 	c.authorizedSigner.Store(&signer{
@@ -539,9 +547,11 @@ func (c *Bor) snapshot(chain consensus.ChainHeaderReader, number uint64, hash co
 			break
 		}
 
-		// If an on-disk checkpoint snapshot can be found, use that
-		if number%checkpointInterval == 0 {
-			if s, err := loadSnapshot(c.config, c.signatures, c.db, hash); err == nil {
+		// If an on-disk snapshot can be found, use that. Besides the configured
+		// interval, snapshots are looked up at the default one they were stored
+		// with before it became configurable.
+		if legacy := number%checkpointInterval == 0; legacy || number%c.snapshotConfig.Interval == 0 {
+			if s, err := loadSnapshot(c.config, c.signatures, c.db, number, hash, legacy); err == nil {
 				log.Trace("Loaded snapshot from disk", "number", number, "hash", hash)
 
 				snap = s
@@ -621,12 +631,14 @@ func (c *Bor) snapshot(chain consensus.ChainHeaderReader, number uint64, hash co
 	c.recents.Add(snap.Hash, snap)
 
 	// If we've generated a new checkpoint snapshot, save to disk
-	if snap.Number%checkpointInterval == 0 && len(headers) > 0 {
+	if snap.Number%c.snapshotConfig.Interval == 0 && len(headers) > 0 {
 		if err = snap.store(c.db); err != nil {
 			return nil, err
 		}
 
 		log.Trace("Stored snapshot to disk", "number", snap.Number, "hash", snap.Hash)
+
+		c.pruneSnapshots()
 	}
 
 	return snap, err
@@ -855,6 +867,7 @@ func (c *Bor) Finalize(chain consensus.ChainHeaderReader, header *types.Header,
 	// Set state sync data to blockchain
 	bc := chain.(*core.BlockChain)
 	bc.SetStateSync(stateSyncData)
+	state.SetStateSyncs(stateSyncData)
 }
 
 func decodeGenesisAlloc(i interface{}) (core.GenesisAlloc, error) {
@@ -955,6 +968,7 @@ func (c *Bor) FinalizeAndAssemble(ctx context.Context, chain consensus.ChainHead
 	// set state sync
 	bc := chain.(core.BorStateSyncer)
 	bc.SetStateSync(stateSyncData)
+	state.SetStateSyncs(stateSyncData)
 
 	tracing.SetAttributes(
 		finalizeSpan,
@@ -1235,17 +1249,15 @@ func (c *Bor) CommitStates(
 			return nil, err
 		}
 
-		stateSyncDelay := c.config.CalculateStateSyncDelay(number)
-		to = time.Unix(int64(header.Time-stateSyncDelay), 0)
 	} else {
 		lastStateIDBig, err = c.GenesisContractsClient.LastStateId(nil, number-1, header.ParentHash)
 		if err != nil {
 			return nil, err
 		}
-
-		to = time.Unix(int64(chain.Chain.GetHeaderByNumber(number-c.config.CalculateSprint(number)).Time), 0)
 	}
 
+	to = c.stateSyncTo(chain.Chain, header)
+
 	lastStateID := lastStateIDBig.Uint64()
 	from = lastStateID + 1
 
@@ -1300,6 +1312,7 @@ func (c *Bor) CommitStates(
 			return nil, err
 		}
 
+		stateData.GasUsed = gasUsed
 		totalGas += int(gasUsed)
 
 		lastStateID++
@@ -1312,6 +1325,18 @@ func (c *Bor) CommitStates(
 	return stateSyncs, nil
 }
 
+// stateSyncTo returns the time the state-sync events committed in the block with
+// the given header have to be recorded before.
+func (c *Bor) stateSyncTo(chain consensus.ChainHeaderReader, header *types.Header) time.Time {
+	number := header.Number.Uint64()
+
+	if c.config.IsIndore(header.Number) {
+		return time.Unix(int64(header.Time-c.config.CalculateStateSyncDelay(number)), 0)
+	}
+
+	return time.Unix(int64(chain.GetHeaderByNumber(number-c.config.CalculateSprint(number)).Time), 0)
+}
+
 func validateEventRecord(eventRecord *clerk.EventRecordWithTime, number uint64, to time.Time, lastStateID uint64, chainID string) error {
 	// event id should be sequential and event.Time should lie in the range [from, to)
 	if lastStateID+1 != eventRecord.ID || eventRecord.ChainID != chainID || !eventRecord.Time.Before(to) {
@@ -1325,6 +1350,16 @@ func (c *Bor) SetHeimdallClient(h IHeimdallClient) {
 	c.HeimdallClient = h
 }
 
+// SetSnapshotConfig sets the on-disk snapshot interval and pruning policy. A zero
+// interval keeps the default one.
+func (c *Bor) SetSnapshotConfig(config SnapshotConfig) {
+	if config.Interval == 0 {
+		config.Interval = DefaultSnapshotConfig.Interval
+	}
+
+	c.snapshotConfig = config
+}
+
 func (c *Bor) GetCurrentValidators(ctx context.Context, headerHash common.Hash, blockNumber uint64) ([]*valset.Validator, error) {
 	return c.spanner.GetCurrentValidatorsByHash(ctx, headerHash, blockNumber)
 }
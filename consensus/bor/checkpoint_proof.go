@@ -0,0 +1,154 @@
+package bor
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// inmemoryCheckpointTrees is the number of merkle trees of whitelisted
+// checkpoints kept in memory to serve proofs
+const inmemoryCheckpointTrees = 8
+
+var (
+	errCheckpointProofRange  = errors.New("block outside of the checkpoint range")
+	errCheckpointProofLength = errors.New("invalid checkpoint proof length")
+	errCheckpointProofLeaf   = errors.New("block header doesn't match the proof leaf")
+	errCheckpointProofRoot   = errors.New("checkpoint proof doesn't lead to the root hash")
+)
+
+// CheckpointProof is the merkle branch proving the inclusion of a block header
+// in the root hash of a checkpoint.
+type CheckpointProof struct {
+	Start    uint64        `json:"start"`
+	End      uint64        `json:"end"`
+	Number   uint64        `json:"number"`
+	RootHash common.Hash   `json:"rootHash"`
+	Leaf     common.Hash   `json:"leaf"`
+	Branch   []common.Hash `json:"branch"` // Sibling hashes, from the leaf up to the root
+}
+
+// CheckpointLeaf returns the leaf of a block header in the merkle tree of a
+// checkpoint.
+func CheckpointLeaf(header *types.Header) common.Hash {
+	return common.BytesToHash(crypto.Keccak256(appendBytes32(
+		header.Number.Bytes(),
+		new(big.Int).SetUint64(header.Time).Bytes(),
+		header.TxHash.Bytes(),
+		header.ReceiptHash.Bytes(),
+	)))
+}
+
+// VerifyCheckpointProof verifies that the proof includes the block header in
+// the given checkpoint root hash.
+func VerifyCheckpointProof(rootHash common.Hash, header *types.Header, proof *CheckpointProof) error {
+	number := header.Number.Uint64()
+
+	if proof.Start > proof.End || number < proof.Start || number > proof.End || number != proof.Number {
+		return errCheckpointProofRange
+	}
+
+	width := nextPowerOfTwo(proof.End - proof.Start + 1)
+	if uint64(1)<<len(proof.Branch) != width {
+		return errCheckpointProofLength
+	}
+
+	leaf := CheckpointLeaf(header)
+	if leaf != proof.Leaf {
+		return errCheckpointProofLeaf
+	}
+
+	if computed := branchRoot(leaf, number-proof.Start, proof.Branch); computed != rootHash || computed != proof.RootHash {
+		return errCheckpointProofRoot
+	}
+
+	return nil
+}
+
+// branchRoot hashes the leaf at the given index with its merkle branch
+func branchRoot(leaf common.Hash, index uint64, branch []common.Hash) common.Hash {
+	node := leaf
+
+	for _, sibling := range branch {
+		if index%2 == 0 {
+			node = crypto.Keccak256Hash(node[:], sibling[:])
+		} else {
+			node = crypto.Keccak256Hash(sibling[:], node[:])
+		}
+
+		index /= 2
+	}
+
+	return node
+}
+
+// checkpointTree is the merkle tree of the headers of a checkpoint, padded
+// with empty leaves to a power of two like the tree of ComputeRootHash.
+type checkpointTree struct {
+	start   uint64
+	endHash common.Hash
+	levels  [][]common.Hash // Leaves first, root last
+}
+
+func newCheckpointTree(start uint64, headers []*types.Header) (*checkpointTree, error) {
+	if len(headers) == 0 {
+		return nil, errors.New("empty checkpoint")
+	}
+
+	leaves := make([]common.Hash, nextPowerOfTwo(uint64(len(headers))))
+
+	for i, header := range headers {
+		if header == nil {
+			return nil, fmt.Errorf("header %d not found", start+uint64(i))
+		}
+
+		leaves[i] = CheckpointLeaf(header)
+	}
+
+	tree := &checkpointTree{
+		start:   start,
+		endHash: headers[len(headers)-1].Hash(),
+		levels:  [][]common.Hash{leaves},
+	}
+
+	for level := leaves; len(level) > 1; {
+		next := make([]common.Hash, len(level)/2)
+		for i := range next {
+			next[i] = crypto.Keccak256Hash(level[2*i][:], level[2*i+1][:])
+		}
+
+		tree.levels = append(tree.levels, next)
+		level = next
+	}
+
+	return tree, nil
+}
+
+func (t *checkpointTree) root() common.Hash {
+	return t.levels[len(t.levels)-1][0]
+}
+
+// proof returns the merkle branch of the given block of the checkpoint
+func (t *checkpointTree) proof(end uint64, number uint64) *CheckpointProof {
+	index := number - t.start
+
+	proof := &CheckpointProof{
+		Start:    t.start,
+		End:      end,
+		Number:   number,
+		RootHash: t.root(),
+		Leaf:     t.levels[0][index],
+		Branch:   make([]common.Hash, 0, len(t.levels)-1),
+	}
+
+	for _, level := range t.levels[:len(t.levels)-1] {
+		proof.Branch = append(proof.Branch, level[index^1])
+		index /= 2
+	}
+
+	return proof
+}
@@ -0,0 +1,69 @@
+package bor
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Tests that the checkpoint tree has the root hash committed in checkpoints,
+// and that its proofs verify for every block of the range.
+func TestCheckpointProof(t *testing.T) {
+	t.Parallel()
+
+	for _, length := range []int{1, 2, 3, 16, 17} {
+		start := uint64(100)
+
+		headers := make([]*types.Header, length)
+		for i := range headers {
+			headers[i] = &types.Header{
+				Number:      new(big.Int).SetUint64(start + uint64(i)),
+				Time:        uint64(1000 + 2*i),
+				TxHash:      common.BigToHash(big.NewInt(int64(i + 1))),
+				ReceiptHash: common.BigToHash(big.NewInt(int64(i + 100))),
+			}
+		}
+
+		end := start + uint64(length) - 1
+
+		tree, err := newCheckpointTree(start, headers)
+		if err != nil {
+			t.Fatalf("length %d: failed to build tree: %v", length, err)
+		}
+
+		rootHash, err := ComputeRootHash(headers)
+		if err != nil {
+			t.Fatalf("length %d: failed to compute root hash: %v", length, err)
+		}
+
+		root := common.BytesToHash(rootHash)
+		if tree.root() != root {
+			t.Fatalf("length %d: root mismatch: have %x, want %x", length, tree.root(), root)
+		}
+
+		for i, header := range headers {
+			proof := tree.proof(end, header.Number.Uint64())
+
+			if err := VerifyCheckpointProof(root, header, proof); err != nil {
+				t.Errorf("length %d: proof of block %d failed: %v", length, i, err)
+			}
+
+			if len(proof.Branch) > 0 {
+				proof.Branch[0][0] ^= 0xff
+
+				if err := VerifyCheckpointProof(root, header, proof); err != errCheckpointProofRoot {
+					t.Errorf("length %d: tampered proof of block %d: have %v, want %v", length, i, err, errCheckpointProofRoot)
+				}
+			}
+		}
+
+		// A single block range has no other block to prove
+		if length > 1 {
+			if err := VerifyCheckpointProof(root, headers[0], tree.proof(end, end)); err != errCheckpointProofRange {
+				t.Errorf("length %d: proof of another block: have %v, want %v", length, err, errCheckpointProofRange)
+			}
+		}
+	}
+}
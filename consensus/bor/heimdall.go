@@ -7,6 +7,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/event"
 )
 
 //go:generate mockgen -destination=../../tests/bor/mocks/IHeimdallClient.go -package=mocks . IHeimdallClient
@@ -22,3 +23,11 @@ type IHeimdallClient interface {
 	FetchMilestoneID(ctx context.Context, milestoneID string) error    //Fetch the bool value whether milestone corresponding to the given id is in process in Heimdall
 	Close()
 }
+
+// IHeimdallSubscriber is optionally implemented by heimdall clients which can
+// push new finality data, instead of the caller polling for it.
+type IHeimdallSubscriber interface {
+	SubscribeMilestones(ch chan<- *milestone.Milestone) event.Subscription         // Sends every new latest milestone
+	SubscribeCheckpoints(ch chan<- *checkpoint.Checkpoint) event.Subscription      // Sends every new latest checkpoint
+	SubscribeSpans(fromID uint64, ch chan<- *span.HeimdallSpan) event.Subscription // Sends the spans starting at fromID as they are proposed
+}
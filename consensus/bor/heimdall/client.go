@@ -27,6 +27,10 @@ var (
 	ErrNotInRejectedList     = errors.New("milestoneID doesn't exist in rejected list")
 	ErrNotInMilestoneList    = errors.New("milestoneID doesn't exist in Heimdall")
 	ErrServiceUnavailable    = errors.New("service unavailable")
+
+	// errRequestRejected is returned for 4xx responses, which healthy
+	// endpoints give too, e.g. for data heimdall doesn't have yet
+	errRequestRejected = errors.New("request rejected")
 )
 
 const (
@@ -46,7 +50,7 @@ type SpanResponse struct {
 }
 
 type HeimdallClient struct {
-	urlString string
+	endpoints *Endpoints
 	client    http.Client
 	closeCh   chan struct{}
 }
@@ -57,9 +61,11 @@ type Request struct {
 	start  time.Time
 }
 
-func NewHeimdallClient(urlString string) *HeimdallClient {
+// NewHeimdallClient creates a heimdall REST client. Requests are sent to the
+// healthiest of urlString and failoverURLs, failing over to the others on error.
+func NewHeimdallClient(urlString string, failoverURLs ...string) *HeimdallClient {
 	return &HeimdallClient{
-		urlString: urlString,
+		endpoints: NewEndpoints(append([]string{urlString}, failoverURLs...)),
 		client: http.Client{
 			Timeout: apiHeimdallTimeout,
 		},
@@ -88,16 +94,15 @@ func (h *HeimdallClient) StateSyncEvents(ctx context.Context, fromID uint64, to
 	eventRecords := make([]*clerk.EventRecordWithTime, 0)
 
 	for {
-		url, err := stateSyncURL(h.urlString, fromID, to)
-		if err != nil {
-			return nil, err
+		urlFn := func(urlString string) (*url.URL, error) {
+			return stateSyncURL(urlString, fromID, to)
 		}
 
-		log.Info("Fetching state sync events", "queryParams", url.RawQuery)
+		log.Info("Fetching state sync events", "queryParams", fmt.Sprintf(fetchStateSyncEventsFormat, fromID, to, stateFetchLimit))
 
 		ctx = withRequestType(ctx, stateSyncRequest)
 
-		response, err := FetchWithRetry[StateSyncEventsResponse](ctx, h.client, url, h.closeCh)
+		response, err := FetchWithRetry[StateSyncEventsResponse](ctx, h.client, h.endpoints, urlFn, h.closeCh)
 		if err != nil {
 			return nil, err
 		}
@@ -124,14 +129,13 @@ func (h *HeimdallClient) StateSyncEvents(ctx context.Context, fromID uint64, to
 }
 
 func (h *HeimdallClient) Span(ctx context.Context, spanID uint64) (*span.HeimdallSpan, error) {
-	url, err := spanURL(h.urlString, spanID)
-	if err != nil {
-		return nil, err
+	urlFn := func(urlString string) (*url.URL, error) {
+		return spanURL(urlString, spanID)
 	}
 
 	ctx = withRequestType(ctx, spanRequest)
 
-	response, err := FetchWithRetry[SpanResponse](ctx, h.client, url, h.closeCh)
+	response, err := FetchWithRetry[SpanResponse](ctx, h.client, h.endpoints, urlFn, h.closeCh)
 	if err != nil {
 		return nil, err
 	}
@@ -141,14 +145,13 @@ func (h *HeimdallClient) Span(ctx context.Context, spanID uint64) (*span.Heimdal
 
 // FetchCheckpoint fetches the checkpoint from heimdall
 func (h *HeimdallClient) FetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
-	url, err := checkpointURL(h.urlString, number)
-	if err != nil {
-		return nil, err
+	urlFn := func(urlString string) (*url.URL, error) {
+		return checkpointURL(urlString, number)
 	}
 
 	ctx = withRequestType(ctx, checkpointRequest)
 
-	response, err := FetchWithRetry[checkpoint.CheckpointResponse](ctx, h.client, url, h.closeCh)
+	response, err := FetchWithRetry[checkpoint.CheckpointResponse](ctx, h.client, h.endpoints, urlFn, h.closeCh)
 	if err != nil {
 		return nil, err
 	}
@@ -158,14 +161,13 @@ func (h *HeimdallClient) FetchCheckpoint(ctx context.Context, number int64) (*ch
 
 // FetchMilestone fetches the checkpoint from heimdall
 func (h *HeimdallClient) FetchMilestone(ctx context.Context) (*milestone.Milestone, error) {
-	url, err := milestoneURL(h.urlString)
-	if err != nil {
-		return nil, err
+	urlFn := func(urlString string) (*url.URL, error) {
+		return milestoneURL(urlString)
 	}
 
 	ctx = withRequestType(ctx, milestoneRequest)
 
-	response, err := FetchWithRetry[milestone.MilestoneResponse](ctx, h.client, url, h.closeCh)
+	response, err := FetchWithRetry[milestone.MilestoneResponse](ctx, h.client, h.endpoints, urlFn, h.closeCh)
 	if err != nil {
 		return nil, err
 	}
@@ -175,14 +177,13 @@ func (h *HeimdallClient) FetchMilestone(ctx context.Context) (*milestone.Milesto
 
 // FetchCheckpointCount fetches the checkpoint count from heimdall
 func (h *HeimdallClient) FetchCheckpointCount(ctx context.Context) (int64, error) {
-	url, err := checkpointCountURL(h.urlString)
-	if err != nil {
-		return 0, err
+	urlFn := func(urlString string) (*url.URL, error) {
+		return checkpointCountURL(urlString)
 	}
 
 	ctx = withRequestType(ctx, checkpointCountRequest)
 
-	response, err := FetchWithRetry[checkpoint.CheckpointCountResponse](ctx, h.client, url, h.closeCh)
+	response, err := FetchWithRetry[checkpoint.CheckpointCountResponse](ctx, h.client, h.endpoints, urlFn, h.closeCh)
 	if err != nil {
 		return 0, err
 	}
@@ -192,14 +193,13 @@ func (h *HeimdallClient) FetchCheckpointCount(ctx context.Context) (int64, error
 
 // FetchMilestoneCount fetches the milestone count from heimdall
 func (h *HeimdallClient) FetchMilestoneCount(ctx context.Context) (int64, error) {
-	url, err := milestoneCountURL(h.urlString)
-	if err != nil {
-		return 0, err
+	urlFn := func(urlString string) (*url.URL, error) {
+		return milestoneCountURL(urlString)
 	}
 
 	ctx = withRequestType(ctx, milestoneCountRequest)
 
-	response, err := FetchWithRetry[milestone.MilestoneCountResponse](ctx, h.client, url, h.closeCh)
+	response, err := FetchWithRetry[milestone.MilestoneCountResponse](ctx, h.client, h.endpoints, urlFn, h.closeCh)
 	if err != nil {
 		return 0, err
 	}
@@ -209,14 +209,13 @@ func (h *HeimdallClient) FetchMilestoneCount(ctx context.Context) (int64, error)
 
 // FetchLastNoAckMilestone fetches the last no-ack-milestone from heimdall
 func (h *HeimdallClient) FetchLastNoAckMilestone(ctx context.Context) (string, error) {
-	url, err := lastNoAckMilestoneURL(h.urlString)
-	if err != nil {
-		return "", err
+	urlFn := func(urlString string) (*url.URL, error) {
+		return lastNoAckMilestoneURL(urlString)
 	}
 
 	ctx = withRequestType(ctx, milestoneLastNoAckRequest)
 
-	response, err := FetchWithRetry[milestone.MilestoneLastNoAckResponse](ctx, h.client, url, h.closeCh)
+	response, err := FetchWithRetry[milestone.MilestoneLastNoAckResponse](ctx, h.client, h.endpoints, urlFn, h.closeCh)
 	if err != nil {
 		return "", err
 	}
@@ -226,14 +225,13 @@ func (h *HeimdallClient) FetchLastNoAckMilestone(ctx context.Context) (string, e
 
 // FetchNoAckMilestone fetches the last no-ack-milestone from heimdall
 func (h *HeimdallClient) FetchNoAckMilestone(ctx context.Context, milestoneID string) error {
-	url, err := noAckMilestoneURL(h.urlString, milestoneID)
-	if err != nil {
-		return err
+	urlFn := func(urlString string) (*url.URL, error) {
+		return noAckMilestoneURL(urlString, milestoneID)
 	}
 
 	ctx = withRequestType(ctx, milestoneNoAckRequest)
 
-	response, err := FetchWithRetry[milestone.MilestoneNoAckResponse](ctx, h.client, url, h.closeCh)
+	response, err := FetchWithRetry[milestone.MilestoneNoAckResponse](ctx, h.client, h.endpoints, urlFn, h.closeCh)
 	if err != nil {
 		return err
 	}
@@ -248,14 +246,13 @@ func (h *HeimdallClient) FetchNoAckMilestone(ctx context.Context, milestoneID st
 // FetchMilestoneID fetches the bool result from Heimdal whether the ID corresponding
 // to the given milestone is in process in Heimdall
 func (h *HeimdallClient) FetchMilestoneID(ctx context.Context, milestoneID string) error {
-	url, err := milestoneIDURL(h.urlString, milestoneID)
-	if err != nil {
-		return err
+	urlFn := func(urlString string) (*url.URL, error) {
+		return milestoneIDURL(urlString, milestoneID)
 	}
 
 	ctx = withRequestType(ctx, milestoneIDRequest)
 
-	response, err := FetchWithRetry[milestone.MilestoneIDResponse](ctx, h.client, url, h.closeCh)
+	response, err := FetchWithRetry[milestone.MilestoneIDResponse](ctx, h.client, h.endpoints, urlFn, h.closeCh)
 
 	if err != nil {
 		return err
@@ -268,16 +265,35 @@ func (h *HeimdallClient) FetchMilestoneID(ctx context.Context, milestoneID strin
 	return nil
 }
 
-// FetchWithRetry returns data from heimdall with retry
-func FetchWithRetry[T any](ctx context.Context, client http.Client, url *url.URL, closeCh chan struct{}) (*T, error) {
+type singleAttemptKey struct{}
+
+// WithSingleAttempt returns a context making the requests done with it give up
+// after the first failed attempt instead of retrying, leaving the retries to the
+// caller.
+func WithSingleAttempt(ctx context.Context) context.Context {
+	return context.WithValue(ctx, singleAttemptKey{}, true)
+}
+
+func isSingleAttempt(ctx context.Context) bool {
+	single, _ := ctx.Value(singleAttemptKey{}).(bool)
+	return single
+}
+
+// FetchWithRetry returns data from heimdall with retry. Every attempt walks the
+// endpoints from the healthiest to the least healthy one, using urlFn to build
+// the request URL for each of them, and returns the first successful response.
+func FetchWithRetry[T any](ctx context.Context, client http.Client, endpoints *Endpoints, urlFn func(string) (*url.URL, error), closeCh chan struct{}) (*T, error) {
 	// request data once
-	request := &Request{client: client, url: url, start: time.Now()}
-	result, err := Fetch[T](ctx, request)
+	result, url, err := fetchFromEndpoints[T](ctx, client, endpoints, urlFn)
 
 	if err == nil {
 		return result, nil
 	}
 
+	if url == nil || isSingleAttempt(ctx) {
+		return nil, err
+	}
+
 	// 503 (Service Unavailable) is thrown when an endpoint isn't activated
 	// yet in heimdall. E.g. when the hardfork hasn't hit yet but heimdall
 	// is upgraded.
@@ -313,8 +329,7 @@ retryLoop:
 
 			return nil, ErrShutdownDetected
 		case <-ticker.C:
-			request = &Request{client: client, url: url, start: time.Now()}
-			result, err = Fetch[T](ctx, request)
+			result, _, err = fetchFromEndpoints[T](ctx, client, endpoints, urlFn)
 
 			if errors.Is(err, ErrServiceUnavailable) {
 				log.Debug("Heimdall service unavailable at the moment", "path", url.Path, "error", err)
@@ -334,6 +349,74 @@ retryLoop:
 	}
 }
 
+// fetchFromEndpoints requests data from the endpoints in the order of their health
+// until one of them succeeds. It returns the URL of the last request made. The
+// error is ErrServiceUnavailable only if every endpoint answered with a 503.
+func fetchFromEndpoints[T any](ctx context.Context, client http.Client, endpoints *Endpoints, urlFn func(string) (*url.URL, error)) (*T, *url.URL, error) {
+	var (
+		result  *T
+		lastURL *url.URL
+		err     error
+
+		lastErr     error
+		unavailable error
+	)
+
+	for i, endpoint := range endpoints.Ordered() {
+		u, urlErr := urlFn(endpoint.Address)
+		if urlErr != nil {
+			return nil, lastURL, urlErr
+		}
+
+		lastURL = u
+
+		request := &Request{client: client, url: u, start: time.Now()}
+		result, err = Fetch[T](ctx, request)
+
+		// Don't blame the endpoint for the caller giving up on the request
+		if ctx.Err() != nil {
+			return nil, lastURL, err
+		}
+
+		// An answer such as a 404 comes from a healthy endpoint
+		if err == nil || isEndpointFailure(err) {
+			endpoint.Report(request.start, err)
+		}
+
+		if err == nil {
+			if i > 0 {
+				failoverMeter.Mark(1)
+			}
+
+			return result, lastURL, nil
+		}
+
+		// A 503 from one endpoint doesn't mean the others can't serve the request,
+		// e.g. during a rolling heimdall upgrade, so keep failing over.
+		if errors.Is(err, ErrServiceUnavailable) {
+			unavailable = err
+		} else {
+			lastErr = err
+		}
+
+		if endpoints.Len() > 1 {
+			log.Debug("Failed to fetch data from Heimdall endpoint, trying the next one", "endpoint", endpoint.Address, "path", u.Path, "error", err)
+		}
+	}
+
+	if lastErr == nil {
+		lastErr = unavailable
+	}
+
+	return nil, lastURL, lastErr
+}
+
+// isEndpointFailure reports whether err is down to the endpoint rather than
+// being its answer to the request.
+func isEndpointFailure(err error) bool {
+	return !errors.Is(err, errRequestRejected) && !errors.Is(err, ErrNoResponse)
+}
+
 // Fetch returns data from heimdall
 func Fetch[T any](ctx context.Context, request *Request) (*T, error) {
 	isSuccessful := false
@@ -445,6 +528,10 @@ func internalFetch(ctx context.Context, client http.Client, u *url.URL) ([]byte,
 	}
 
 	// check status code
+	if res.StatusCode >= 400 && res.StatusCode < 500 {
+		return nil, fmt.Errorf("%w: %w: response code %d", ErrNotSuccessfulResponse, errRequestRejected, res.StatusCode)
+	}
+
 	if res.StatusCode != 200 && res.StatusCode != 204 {
 		return nil, fmt.Errorf("%w: response code %d", ErrNotSuccessfulResponse, res.StatusCode)
 	}
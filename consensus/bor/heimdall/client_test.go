@@ -8,7 +8,9 @@ import (
 	"math/big"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -417,3 +419,144 @@ func TestStateSyncURL(t *testing.T) {
 		t.Fatalf("expected URL %q, got %q", url.String(), expected)
 	}
 }
+
+// TestFetchFailover tests that the client fails over to the next endpoint without
+// waiting for a retry if the preferred one fails, and prefers the healthy endpoint
+// for the following requests.
+func TestFetchFailover(t *testing.T) {
+	t.Parallel()
+
+	var primaryCalls, secondaryCalls atomic.Int32
+
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		primaryCalls.Add(1)
+
+		w.WriteHeader(500) // Return 500 Internal Server Error.
+	}))
+	defer primary.Close()
+
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		secondaryCalls.Add(1)
+
+		err := json.NewEncoder(w).Encode(milestone.MilestoneResponse{
+			Height: "0",
+			Result: milestone.Milestone{
+				Proposer:   common.Address{},
+				StartBlock: big.NewInt(0),
+				EndBlock:   big.NewInt(512),
+				Hash:       common.Hash{},
+				BorChainID: "15001",
+				Timestamp:  0,
+			},
+		})
+
+		if err != nil {
+			w.WriteHeader(500) // Return 500 Internal Server Error.
+		}
+	}))
+	defer secondary.Close()
+
+	client := NewHeimdallClient(primary.URL, secondary.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), retryCall/2)
+	defer cancel()
+
+	// The first request hits the primary endpoint, then fails over to the secondary one
+	res, err := client.FetchMilestone(ctx)
+	require.NoError(t, err, "expect no error when failing over to the healthy endpoint")
+	require.Equal(t, int64(512), res.EndBlock.Int64())
+	require.Equal(t, int32(1), primaryCalls.Load())
+	require.Equal(t, int32(1), secondaryCalls.Load())
+
+	// The primary endpoint is backing off now, so the secondary one is asked first
+	ordered := client.endpoints.Ordered()
+	require.Equal(t, secondary.URL, ordered[0].Address)
+	require.Equal(t, primary.URL, ordered[1].Address)
+
+	_, err = client.FetchMilestone(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), primaryCalls.Load())
+	require.Equal(t, int32(2), secondaryCalls.Load())
+}
+
+// TestFetchFailoverServiceUnavailable tests that a 503 from one endpoint fails
+// over to the next one, and that the request is given up without retrying only
+// if every endpoint is unavailable.
+func TestFetchFailoverServiceUnavailable(t *testing.T) {
+	t.Parallel()
+
+	var unavailableCalls atomic.Int32
+
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		unavailableCalls.Add(1)
+
+		w.WriteHeader(503) // Return 503 Service Unavailable.
+	}))
+	defer unavailable.Close()
+
+	available := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		err := json.NewEncoder(w).Encode(milestone.MilestoneResponse{
+			Height: "0",
+			Result: milestone.Milestone{
+				StartBlock: big.NewInt(0),
+				EndBlock:   big.NewInt(512),
+				BorChainID: "15001",
+			},
+		})
+
+		if err != nil {
+			w.WriteHeader(500) // Return 500 Internal Server Error.
+		}
+	}))
+	defer available.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), retryCall/2)
+	defer cancel()
+
+	client := NewHeimdallClient(unavailable.URL, available.URL)
+
+	res, err := client.FetchMilestone(ctx)
+	require.NoError(t, err, "expect no error when failing over from an unavailable endpoint")
+	require.Equal(t, int64(512), res.EndBlock.Int64())
+	require.Equal(t, int32(1), unavailableCalls.Load())
+
+	client = NewHeimdallClient(unavailable.URL, unavailable.URL)
+
+	_, err = client.FetchMilestone(ctx)
+	require.ErrorIs(t, err, ErrServiceUnavailable)
+	require.Equal(t, int32(3), unavailableCalls.Load())
+}
+
+// TestFetchNotFoundKeepsHealth tests that an endpoint answering with a 404 isn't
+// demoted behind the failing ones.
+func TestFetchNotFoundKeepsHealth(t *testing.T) {
+	t.Parallel()
+
+	var notFoundCalls, failingCalls atomic.Int32
+
+	notFound := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		notFoundCalls.Add(1)
+
+		w.WriteHeader(404) // Return 404 Not Found.
+	}))
+	defer notFound.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		failingCalls.Add(1)
+
+		w.WriteHeader(500) // Return 500 Internal Server Error.
+	}))
+	defer failing.Close()
+
+	client := NewHeimdallClient(notFound.URL, failing.URL)
+
+	_, err := client.FetchMilestone(WithSingleAttempt(context.Background()))
+	require.ErrorIs(t, err, ErrNotSuccessfulResponse)
+	require.Equal(t, int32(1), notFoundCalls.Load())
+	require.Equal(t, int32(1), failingCalls.Load())
+
+	ordered := client.endpoints.Ordered()
+	require.Equal(t, notFound.URL, ordered[0].Address)
+	require.Equal(t, 0, ordered[0].failures)
+	require.Equal(t, 1, ordered[1].failures)
+}
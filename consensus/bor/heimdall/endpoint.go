@@ -0,0 +1,162 @@
+package heimdall
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// endpointLatencyWeight is the weight of the most recent sample in the
+	// exponential moving average of an endpoint's latency.
+	endpointLatencyWeight = 0.3
+
+	// endpointBackoff is the time a failing endpoint is demoted for after its
+	// first failure. It doubles with every consecutive failure.
+	endpointBackoff    = 5 * time.Second
+	endpointMaxBackoff = 5 * time.Minute
+)
+
+// Endpoint is a single heimdall endpoint together with its health statistics.
+type Endpoint struct {
+	Address string // URL or gRPC address of the endpoint
+
+	index int            // Position of the endpoint in the configuration
+	stats endpointMeters // Per-endpoint metrics
+
+	lock        sync.Mutex
+	latency     time.Duration // Moving average of the successful request latency
+	failures    int           // Number of consecutive failed requests
+	lastFailure time.Time     // Time of the last failed request
+}
+
+// healthy reports whether the endpoint is not backing off after failures.
+func (e *Endpoint) healthy(now time.Time) bool {
+	if e.failures == 0 {
+		return true
+	}
+
+	backoff := endpointBackoff << uint(e.failures-1)
+	if backoff <= 0 || backoff > endpointMaxBackoff {
+		backoff = endpointMaxBackoff
+	}
+
+	return now.Sub(e.lastFailure) > backoff
+}
+
+// Index returns the position of the endpoint in the configured address list.
+func (e *Endpoint) Index() int {
+	return e.index
+}
+
+// Report records the outcome of a request sent to the endpoint at start.
+func (e *Endpoint) Report(start time.Time, err error) {
+	elapsed := time.Since(start)
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if err != nil {
+		e.failures++
+		e.lastFailure = time.Now()
+
+		if e.failures == 1 {
+			log.Warn("Heimdall endpoint became unhealthy", "endpoint", e.Address, "err", err)
+		}
+	} else {
+		if e.failures > 0 {
+			log.Info("Heimdall endpoint recovered", "endpoint", e.Address, "failures", e.failures)
+		}
+
+		e.failures = 0
+
+		if e.latency == 0 {
+			e.latency = elapsed
+		} else {
+			e.latency = time.Duration(endpointLatencyWeight*float64(elapsed) + (1-endpointLatencyWeight)*float64(e.latency))
+		}
+	}
+
+	e.stats.update(elapsed, err == nil, e.latency, e.failures)
+}
+
+// Endpoints tracks the health of a set of heimdall endpoints and orders them by
+// preference for the next request.
+type Endpoints struct {
+	endpoints []*Endpoint
+}
+
+// NewEndpoints creates the health tracker for the given endpoint addresses. The
+// first address is the primary endpoint, the rest are used for failover.
+func NewEndpoints(addresses []string) *Endpoints {
+	endpoints := make([]*Endpoint, 0, len(addresses))
+
+	for i, address := range addresses {
+		endpoints = append(endpoints, &Endpoint{
+			Address: address,
+			index:   i,
+			stats:   newEndpointMeters(address),
+		})
+	}
+
+	return &Endpoints{endpoints: endpoints}
+}
+
+// Len returns the number of tracked endpoints.
+func (s *Endpoints) Len() int {
+	return len(s.endpoints)
+}
+
+// Ordered returns the endpoints from the healthiest to the least healthy one.
+// Healthy endpoints are sorted by their average latency, with the ones that
+// weren't used yet coming after the measured ones in configuration order.
+// Endpoints which are backing off after failures come last, the one that
+// failed the longest time ago first.
+func (s *Endpoints) Ordered() []*Endpoint {
+	type entry struct {
+		endpoint    *Endpoint
+		healthy     bool
+		latency     time.Duration
+		lastFailure time.Time
+	}
+
+	now := time.Now()
+	entries := make([]entry, 0, len(s.endpoints))
+
+	for _, e := range s.endpoints {
+		e.lock.Lock()
+		entries = append(entries, entry{e, e.healthy(now), e.latency, e.lastFailure})
+		e.lock.Unlock()
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+
+		if a.healthy != b.healthy {
+			return a.healthy
+		}
+
+		if !a.healthy {
+			return a.lastFailure.Before(b.lastFailure)
+		}
+
+		if (a.latency == 0) != (b.latency == 0) {
+			return a.latency != 0
+		}
+
+		if a.latency != b.latency {
+			return a.latency < b.latency
+		}
+
+		return a.endpoint.index < b.endpoint.index
+	})
+
+	ordered := make([]*Endpoint, len(entries))
+	for i, entry := range entries {
+		ordered[i] = entry.endpoint
+	}
+
+	return ordered
+}
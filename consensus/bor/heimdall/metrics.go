@@ -2,6 +2,7 @@ package heimdall
 
 import (
 	"context"
+	"regexp"
 	"time"
 
 	"github.com/ethereum/go-ethereum/metrics"
@@ -15,6 +16,13 @@ type (
 		request map[bool]metrics.Meter // map[isSuccessful]metrics.Meter
 		timer   metrics.Timer
 	}
+
+	endpointMeters struct {
+		request  map[bool]metrics.Meter // map[isSuccessful]metrics.Meter
+		timer    metrics.Timer
+		latency  metrics.Gauge // moving average of the latency in milliseconds
+		failures metrics.Gauge // number of consecutive failures
+	}
 )
 
 const (
@@ -120,3 +128,36 @@ func sendMetrics(ctx context.Context, start time.Time, isSuccessful bool) {
 	meters.request[isSuccessful].Mark(1)
 	meters.timer.Update(time.Since(start))
 }
+
+var (
+	// failoverMeter counts the requests which were served by an endpoint other
+	// than the preferred one.
+	failoverMeter = metrics.NewRegisteredMeter("client/requests/failover", nil)
+
+	endpointSchemeRegexp = regexp.MustCompile(`^[a-zA-Z]+://`)
+	endpointNameRegexp   = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+)
+
+// newEndpointMeters registers the per-endpoint metrics, named after the endpoint
+// address without the scheme (e.g. client/endpoints/localhost_1317/valid).
+func newEndpointMeters(address string) endpointMeters {
+	name := endpointSchemeRegexp.ReplaceAllString(address, "")
+	name = "client/endpoints/" + endpointNameRegexp.ReplaceAllString(name, "_")
+
+	return endpointMeters{
+		request: map[bool]metrics.Meter{
+			true:  metrics.GetOrRegisterMeter(name+"/valid", nil),
+			false: metrics.GetOrRegisterMeter(name+"/invalid", nil),
+		},
+		timer:    metrics.GetOrRegisterTimer(name+"/duration", nil),
+		latency:  metrics.GetOrRegisterGauge(name+"/latency", nil),
+		failures: metrics.GetOrRegisterGauge(name+"/failures", nil),
+	}
+}
+
+func (m endpointMeters) update(elapsed time.Duration, isSuccessful bool, latency time.Duration, failures int) {
+	m.request[isSuccessful].Mark(1)
+	m.timer.Update(elapsed)
+	m.latency.Update(latency.Milliseconds())
+	m.failures.Update(int64(failures))
+}
@@ -0,0 +1,254 @@
+package heimdall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// LongPollWait is the time a server supporting long-polling may hold a
+	// subscription request for, waiting for newer data. It has to stay below
+	// the request timeout.
+	LongPollWait = 4 * time.Second
+
+	// The minimum time between two subscription requests, the same as the
+	// intervals milestones and checkpoints were polled in before. Servers which
+	// hold the request (long-polling) are asked again right away.
+	MilestoneSubscriptionInterval  = 12 * time.Second
+	CheckpointSubscriptionInterval = 100 * time.Second
+	SpanSubscriptionInterval       = 30 * time.Second
+)
+
+const (
+	fetchLatestSpan = "bor/latest-span"
+	longPollFormat  = "wait=%d&after=%d"
+)
+
+// NewPollingSubscription emulates a subscription on top of request/response
+// APIs. It keeps calling fetch with the last item sent (nil at first) and sends
+// every result with a higher key, e.g. the end block of a milestone, to ch. The
+// subscription ends with an error if the client was closed.
+//
+// The server is assumed to support long-polling, and is asked again right away,
+// until it answers a request with the last item without holding on to it. From
+// then on, requests are sent every interval.
+func NewPollingSubscription[T any](ch chan<- *T, interval time.Duration, key func(*T) uint64, fetch func(ctx context.Context, last *T) (*T, error)) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go func() {
+			select {
+			case <-quit:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		var (
+			last     *T
+			longPoll = true
+		)
+
+		for {
+			start := time.Now()
+
+			result, err := fetch(ctx, last)
+
+			switch {
+			case err == nil && result != nil && (last == nil || key(result) > key(last)):
+				select {
+				case ch <- result:
+					last = result
+				case <-quit:
+					return nil
+				}
+
+				// Ask for the next item right away, servers supporting long-polling
+				// hold the request until it exists
+				if longPoll {
+					continue
+				}
+
+			case err == nil && result != nil && longPoll:
+				// The server held on to the request until it timed out
+				if time.Since(start) >= LongPollWait/2 {
+					continue
+				}
+
+				log.Debug("Heimdall doesn't support long-polling, polling subscription", "interval", interval)
+
+				longPoll = false
+
+			case errors.Is(err, ErrShutdownDetected):
+				return err
+
+			case err != nil && ctx.Err() == nil && !errors.Is(err, ErrServiceUnavailable):
+				log.Debug("Failed to poll Heimdall subscription", "err", err)
+			}
+
+			// Space out the requests, unless the server held on to the last one
+			// until it timed out
+			wait := interval - time.Since(start)
+			if wait <= 0 {
+				wait = 0
+			}
+
+			timer := time.NewTimer(wait)
+
+			select {
+			case <-timer.C:
+			case <-quit:
+				timer.Stop()
+				return nil
+			}
+		}
+	})
+}
+
+// PollMilestones emulates a milestone subscription for clients which can only
+// fetch the latest milestone, calling fetch every MilestoneSubscriptionInterval.
+func PollMilestones(ch chan<- *milestone.Milestone, fetch func(ctx context.Context) (*milestone.Milestone, error)) event.Subscription {
+	return NewPollingSubscription(ch, MilestoneSubscriptionInterval, milestoneEnd, func(ctx context.Context, _ *milestone.Milestone) (*milestone.Milestone, error) {
+		return fetch(ctx)
+	})
+}
+
+// PollCheckpoints emulates a checkpoint subscription for clients which can only
+// fetch the latest checkpoint, calling fetch every CheckpointSubscriptionInterval.
+func PollCheckpoints(ch chan<- *checkpoint.Checkpoint, fetch func(ctx context.Context) (*checkpoint.Checkpoint, error)) event.Subscription {
+	return NewPollingSubscription(ch, CheckpointSubscriptionInterval, checkpointEnd, func(ctx context.Context, _ *checkpoint.Checkpoint) (*checkpoint.Checkpoint, error) {
+		return fetch(ctx)
+	})
+}
+
+// PollSpans emulates a span subscription for clients which can only fetch spans
+// by id, asking for the span following the last one sent every
+// SpanSubscriptionInterval until it exists.
+func PollSpans(fromID uint64, ch chan<- *span.HeimdallSpan, fetch func(ctx context.Context, spanID uint64) (*span.HeimdallSpan, error)) event.Subscription {
+	return NewPollingSubscription(ch, SpanSubscriptionInterval, spanID, func(ctx context.Context, last *span.HeimdallSpan) (*span.HeimdallSpan, error) {
+		next := fromID
+		if last != nil {
+			next = last.ID + 1
+		}
+
+		return fetch(ctx, next)
+	})
+}
+
+// SubscribeMilestones sends every new latest milestone to ch. The REST API has
+// no push mechanism, so the latest milestone is long-polled: servers supporting
+// it hold the request until a newer milestone exists, the others are polled
+// every MilestoneSubscriptionInterval.
+func (h *HeimdallClient) SubscribeMilestones(ch chan<- *milestone.Milestone) event.Subscription {
+	return NewPollingSubscription(ch, MilestoneSubscriptionInterval, milestoneEnd, func(ctx context.Context, last *milestone.Milestone) (*milestone.Milestone, error) {
+		var after uint64
+		if last != nil {
+			after = milestoneEnd(last)
+		}
+
+		response, err := longPoll[milestone.MilestoneResponse](withRequestType(ctx, milestoneRequest), h, fetchMilestone, after)
+		if err != nil {
+			return nil, err
+		}
+
+		return &response.Result, nil
+	})
+}
+
+// SubscribeCheckpoints sends every new latest checkpoint to ch, long-polling
+// the latest checkpoint like SubscribeMilestones.
+func (h *HeimdallClient) SubscribeCheckpoints(ch chan<- *checkpoint.Checkpoint) event.Subscription {
+	return NewPollingSubscription(ch, CheckpointSubscriptionInterval, checkpointEnd, func(ctx context.Context, last *checkpoint.Checkpoint) (*checkpoint.Checkpoint, error) {
+		var after uint64
+		if last != nil {
+			after = checkpointEnd(last)
+		}
+
+		response, err := longPoll[checkpoint.CheckpointResponse](withRequestType(ctx, checkpointRequest), h, fmt.Sprintf(fetchCheckpoint, "latest"), after)
+		if err != nil {
+			return nil, err
+		}
+
+		return &response.Result, nil
+	})
+}
+
+// SubscribeSpans sends the spans starting at fromID to ch as soon as they are
+// proposed, long-polling the latest span like SubscribeMilestones.
+func (h *HeimdallClient) SubscribeSpans(fromID uint64, ch chan<- *span.HeimdallSpan) event.Subscription {
+	return NewPollingSubscription(ch, SpanSubscriptionInterval, spanID, func(ctx context.Context, last *span.HeimdallSpan) (*span.HeimdallSpan, error) {
+		next := fromID
+		if last != nil {
+			next = last.ID + 1
+		}
+
+		// Wait for the next span to be proposed. Span 0 is part of the genesis.
+		if next > 0 {
+			response, err := longPoll[SpanResponse](withRequestType(ctx, spanRequest), h, fetchLatestSpan, next-1)
+			if err != nil {
+				return nil, err
+			}
+
+			if response.Result.ID < next {
+				return nil, nil
+			}
+
+			if response.Result.ID == next {
+				return &response.Result, nil
+			}
+		}
+
+		// More than one span was proposed since the last request, catch up one by one
+		urlFn := func(urlString string) (*url.URL, error) {
+			return spanURL(urlString, next)
+		}
+
+		response, _, err := fetchFromEndpoints[SpanResponse](withRequestType(ctx, spanRequest), h.client, h.endpoints, urlFn)
+		if err != nil {
+			return nil, err
+		}
+
+		return &response.Result, nil
+	})
+}
+
+// longPoll requests the latest item at path, asking the server to hold the
+// request until an item with a key above after exists. Unlike FetchWithRetry,
+// it tries every endpoint only once.
+func longPoll[T any](ctx context.Context, h *HeimdallClient, path string, after uint64) (*T, error) {
+	urlFn := func(urlString string) (*url.URL, error) {
+		return makeURL(urlString, path, fmt.Sprintf(longPollFormat, int(LongPollWait.Seconds()), after))
+	}
+
+	select {
+	case <-h.closeCh:
+		return nil, ErrShutdownDetected
+	default:
+	}
+
+	response, _, err := fetchFromEndpoints[T](ctx, h.client, h.endpoints, urlFn)
+
+	return response, err
+}
+
+func milestoneEnd(m *milestone.Milestone) uint64 {
+	return m.EndBlock.Uint64()
+}
+
+func checkpointEnd(c *checkpoint.Checkpoint) uint64 {
+	return c.EndBlock.Uint64()
+}
+
+func spanID(s *span.HeimdallSpan) uint64 {
+	return s.ID
+}
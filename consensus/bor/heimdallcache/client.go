@@ -0,0 +1,336 @@
+// Package heimdallcache implements a bor.IHeimdallClient decorator which keeps
+// immutable Heimdall responses in the node's database.
+package heimdallcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	// ErrNotCached is returned in replay mode if the requested data was never
+	// stored locally.
+	ErrNotCached = errors.New("heimdall data not available in local cache")
+
+	// errReplayMode is returned in replay mode for requests which can't be served
+	// from the cache (e.g. latest milestone). It wraps heimdall.ErrServiceUnavailable
+	// so that the periodic whitelisting services treat it as a soft failure.
+	errReplayMode = fmt.Errorf("%w: heimdall replay mode", heimdall.ErrServiceUnavailable)
+)
+
+var (
+	spanHitMeter        = metrics.NewRegisteredMeter("heimdall/cache/span/hit", nil)
+	spanMissMeter       = metrics.NewRegisteredMeter("heimdall/cache/span/miss", nil)
+	stateSyncHitMeter   = metrics.NewRegisteredMeter("heimdall/cache/statesync/hit", nil)
+	stateSyncMissMeter  = metrics.NewRegisteredMeter("heimdall/cache/statesync/miss", nil)
+	checkpointHitMeter  = metrics.NewRegisteredMeter("heimdall/cache/checkpoint/hit", nil)
+	checkpointMissMeter = metrics.NewRegisteredMeter("heimdall/cache/checkpoint/miss", nil)
+)
+
+var (
+	_ bor.IHeimdallClient     = (*Client)(nil)
+	_ bor.IHeimdallSubscriber = (*Client)(nil)
+)
+
+// Client wraps an IHeimdallClient and persists spans, state-sync event records
+// and checkpoints in the database. Requests for data already present locally are
+// answered without reaching out to Heimdall. In replay mode there is no underlying
+// client at all and only the locally stored data is served, which allows
+// re-executing historical blocks without a Heimdall endpoint.
+type Client struct {
+	client bor.IHeimdallClient // Underlying client, nil in replay mode
+	db     ethdb.Database      // Database to store the responses in
+
+	lock sync.Mutex // Protects the event coverage marker
+}
+
+// NewClient creates a caching decorator around the given heimdall client.
+func NewClient(client bor.IHeimdallClient, db ethdb.Database) *Client {
+	return &Client{
+		client: client,
+		db:     db,
+	}
+}
+
+// NewReplayClient creates a client which serves heimdall data from the local
+// database only.
+func NewReplayClient(db ethdb.Database) *Client {
+	log.Info("Serving Heimdall data from the local cache only (replay mode)")
+
+	return &Client{
+		db: db,
+	}
+}
+
+func (c *Client) replay() bool {
+	return c.client == nil
+}
+
+// Span returns the span with the given id, fetching and storing it if it's not
+// available locally yet.
+func (c *Client) Span(ctx context.Context, spanID uint64) (*span.HeimdallSpan, error) {
+	if s := readSpan(c.db, spanID); s != nil {
+		spanHitMeter.Mark(1)
+		return s, nil
+	}
+
+	spanMissMeter.Mark(1)
+
+	if c.replay() {
+		return nil, fmt.Errorf("%w: span %d", ErrNotCached, spanID)
+	}
+
+	s, err := c.client.Span(ctx, spanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeSpan(c.db, s); err != nil {
+		log.Warn("Failed to cache heimdall span", "id", spanID, "err", err)
+	}
+
+	return s, nil
+}
+
+// StateSyncEvents returns the event records with an ID of at least fromID and a
+// record time before to. The query is served from the database if the stored
+// event history is known to be complete for it.
+func (c *Client) StateSyncEvents(ctx context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	c.lock.Lock()
+	coverage := readEventCoverage(c.db)
+	c.lock.Unlock()
+
+	if coverage.covers(fromID, to) {
+		if events, ok := c.storedEvents(fromID, coverage.LastID, to); ok {
+			stateSyncHitMeter.Mark(1)
+			return events, nil
+		}
+	}
+
+	stateSyncMissMeter.Mark(1)
+
+	if c.replay() {
+		return nil, fmt.Errorf("%w: state sync events from %d to %d", ErrNotCached, fromID, to)
+	}
+
+	events, err := c.client.StateSyncEvents(ctx, fromID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.storeEvents(fromID, to, events); err != nil {
+		log.Warn("Failed to cache heimdall state sync events", "fromID", fromID, "to", to, "err", err)
+	}
+
+	return events, nil
+}
+
+// storedEvents collects the stored event records in [fromID, lastID] which were
+// recorded before to. It returns false if any of them is missing.
+func (c *Client) storedEvents(fromID uint64, lastID uint64, to int64) ([]*clerk.EventRecordWithTime, bool) {
+	events := make([]*clerk.EventRecordWithTime, 0)
+
+	for id := fromID; id <= lastID; id++ {
+		event := readEvent(c.db, id)
+		if event == nil {
+			return nil, false
+		}
+
+		if event.Time.Unix() >= to {
+			break
+		}
+
+		events = append(events, event)
+	}
+
+	return events, true
+}
+
+// storeEvents persists the events returned by heimdall for the (fromID, to) query
+// and extends the coverage marker if the response is contiguous with it.
+func (c *Client) storeEvents(fromID uint64, to int64, events []*clerk.EventRecordWithTime) error {
+	sorted := make([]*clerk.EventRecordWithTime, len(events))
+	copy(sorted, events)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	// Only a gapless response starting at fromID says something about the
+	// completeness of the event history.
+	lastID := fromID - 1
+
+	for _, event := range sorted {
+		if event.ID != lastID+1 {
+			return writeEvents(c.db, sorted)
+		}
+
+		lastID = event.ID
+	}
+
+	if err := writeEvents(c.db, sorted); err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	coverage := readEventCoverage(c.db)
+
+	switch {
+	case coverage == nil || fromID > coverage.LastID+1 || fromID < coverage.FirstID:
+		// Disjoint with the known history, start tracking from this response
+		// unless it's older than what we already have.
+		if coverage != nil && to <= coverage.ToTime {
+			return nil
+		}
+
+		coverage = &eventCoverage{FirstID: fromID, LastID: lastID, ToTime: to}
+	default:
+		if lastID > coverage.LastID {
+			coverage.LastID = lastID
+		}
+
+		if to > coverage.ToTime {
+			coverage.ToTime = to
+		}
+	}
+
+	return writeEventCoverage(c.db, coverage)
+}
+
+// FetchCheckpoint returns the checkpoint with the given number. Numbered
+// checkpoints are immutable and therefore cached, the latest one (-1) is always
+// fetched from heimdall.
+func (c *Client) FetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	if number >= 0 {
+		if cp := readCheckpoint(c.db, uint64(number)); cp != nil {
+			checkpointHitMeter.Mark(1)
+			return cp, nil
+		}
+
+		checkpointMissMeter.Mark(1)
+	}
+
+	if c.replay() {
+		if number < 0 {
+			return nil, errReplayMode
+		}
+
+		return nil, fmt.Errorf("%w: checkpoint %d", ErrNotCached, number)
+	}
+
+	cp, err := c.client.FetchCheckpoint(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	if number >= 0 {
+		if err := writeCheckpoint(c.db, uint64(number), cp); err != nil {
+			log.Warn("Failed to cache heimdall checkpoint", "number", number, "err", err)
+		}
+	}
+
+	return cp, nil
+}
+
+func (c *Client) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	if c.replay() {
+		return 0, errReplayMode
+	}
+
+	return c.client.FetchCheckpointCount(ctx)
+}
+
+func (c *Client) FetchMilestone(ctx context.Context) (*milestone.Milestone, error) {
+	if c.replay() {
+		return nil, errReplayMode
+	}
+
+	return c.client.FetchMilestone(ctx)
+}
+
+func (c *Client) FetchMilestoneCount(ctx context.Context) (int64, error) {
+	if c.replay() {
+		return 0, errReplayMode
+	}
+
+	return c.client.FetchMilestoneCount(ctx)
+}
+
+func (c *Client) FetchNoAckMilestone(ctx context.Context, milestoneID string) error {
+	if c.replay() {
+		return errReplayMode
+	}
+
+	return c.client.FetchNoAckMilestone(ctx, milestoneID)
+}
+
+func (c *Client) FetchLastNoAckMilestone(ctx context.Context) (string, error) {
+	if c.replay() {
+		return "", errReplayMode
+	}
+
+	return c.client.FetchLastNoAckMilestone(ctx)
+}
+
+func (c *Client) FetchMilestoneID(ctx context.Context, milestoneID string) error {
+	if c.replay() {
+		return errReplayMode
+	}
+
+	return c.client.FetchMilestoneID(ctx, milestoneID)
+}
+
+// Close closes the underlying client, if any.
+func (c *Client) Close() {
+	if c.client != nil {
+		c.client.Close()
+	}
+}
+
+// SubscribeMilestones forwards the subscription to the underlying client, or
+// polls the latest milestone if it doesn't support subscriptions.
+func (c *Client) SubscribeMilestones(ch chan<- *milestone.Milestone) event.Subscription {
+	if subscriber, ok := c.client.(bor.IHeimdallSubscriber); ok {
+		return subscriber.SubscribeMilestones(ch)
+	}
+
+	return heimdall.PollMilestones(ch, c.FetchMilestone)
+}
+
+// SubscribeCheckpoints forwards the subscription to the underlying client, or
+// polls the latest checkpoint if it doesn't support subscriptions.
+func (c *Client) SubscribeCheckpoints(ch chan<- *checkpoint.Checkpoint) event.Subscription {
+	if subscriber, ok := c.client.(bor.IHeimdallSubscriber); ok {
+		return subscriber.SubscribeCheckpoints(ch)
+	}
+
+	return heimdall.PollCheckpoints(ch, func(ctx context.Context) (*checkpoint.Checkpoint, error) {
+		return c.FetchCheckpoint(ctx, -1)
+	})
+}
+
+// SubscribeSpans forwards the subscription to the underlying client, or polls
+// the next span if it doesn't support subscriptions.
+func (c *Client) SubscribeSpans(fromID uint64, ch chan<- *span.HeimdallSpan) event.Subscription {
+	if subscriber, ok := c.client.(bor.IHeimdallSubscriber); ok {
+		return subscriber.SubscribeSpans(fromID, ch)
+	}
+
+	return heimdall.PollSpans(fromID, ch, c.Span)
+}
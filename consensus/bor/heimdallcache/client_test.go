@@ -0,0 +1,193 @@
+package heimdallcache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+// fakeHeimdall serves a fixed set of spans and events and counts the requests
+// it receives.
+type fakeHeimdall struct {
+	spans  map[uint64]*span.HeimdallSpan
+	events []*clerk.EventRecordWithTime
+
+	spanCalls  int
+	eventCalls int
+}
+
+func (f *fakeHeimdall) StateSyncEvents(_ context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	f.eventCalls++
+
+	res := make([]*clerk.EventRecordWithTime, 0)
+
+	for _, event := range f.events {
+		if event.ID >= fromID && event.Time.Unix() < to {
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
+
+func (f *fakeHeimdall) Span(_ context.Context, spanID uint64) (*span.HeimdallSpan, error) {
+	f.spanCalls++
+
+	s, ok := f.spans[spanID]
+	if !ok {
+		return nil, errors.New("unknown span")
+	}
+
+	return s, nil
+}
+
+func (f *fakeHeimdall) FetchCheckpoint(context.Context, int64) (*checkpoint.Checkpoint, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeHeimdall) FetchCheckpointCount(context.Context) (int64, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (f *fakeHeimdall) FetchMilestone(context.Context) (*milestone.Milestone, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeHeimdall) FetchMilestoneCount(context.Context) (int64, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (f *fakeHeimdall) FetchNoAckMilestone(context.Context, string) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeHeimdall) FetchLastNoAckMilestone(context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (f *fakeHeimdall) FetchMilestoneID(context.Context, string) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeHeimdall) Close() {}
+
+func newEvent(id uint64, at int64) *clerk.EventRecordWithTime {
+	return &clerk.EventRecordWithTime{
+		EventRecord: clerk.EventRecord{
+			ID:       id,
+			Contract: common.HexToAddress("0x1"),
+			Data:     []byte{byte(id)},
+			ChainID:  "15001",
+		},
+		Time: time.Unix(at, 0).UTC(),
+	}
+}
+
+func TestSpanCache(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+	fake := &fakeHeimdall{
+		spans: map[uint64]*span.HeimdallSpan{
+			1: {Span: span.Span{ID: 1, StartBlock: 256, EndBlock: 6655}, ChainID: "15001"},
+		},
+	}
+	client := NewClient(fake, db)
+
+	for i := 0; i < 3; i++ {
+		s, err := client.Span(context.Background(), 1)
+		require.NoError(t, err)
+		require.Equal(t, uint64(6655), s.EndBlock)
+	}
+
+	require.Equal(t, 1, fake.spanCalls, "span should be fetched from heimdall only once")
+
+	// The replay client must serve the stored span and fail for unknown ones
+	replay := NewReplayClient(db)
+
+	s, err := replay.Span(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(256), s.StartBlock)
+
+	_, err = replay.Span(context.Background(), 2)
+	require.ErrorIs(t, err, ErrNotCached)
+}
+
+func TestStateSyncEventsCache(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+	fake := &fakeHeimdall{
+		events: []*clerk.EventRecordWithTime{
+			newEvent(1, 10), newEvent(2, 20), newEvent(3, 30), newEvent(4, 40),
+		},
+	}
+	client := NewClient(fake, db)
+	ctx := context.Background()
+
+	// First sprint, fetched from heimdall
+	events, err := client.StateSyncEvents(ctx, 1, 25)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, 1, fake.eventCalls)
+
+	// Same query again, served from the cache
+	events, err = client.StateSyncEvents(ctx, 1, 25)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, 1, fake.eventCalls)
+
+	// Older time bound inside the known history, served from the cache
+	events, err = client.StateSyncEvents(ctx, 1, 15)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, 1, fake.eventCalls)
+
+	// Next sprint extends the known history
+	events, err = client.StateSyncEvents(ctx, 3, 45)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, 2, fake.eventCalls)
+
+	// Re-importing both sprints doesn't reach heimdall anymore
+	replay := NewReplayClient(db)
+
+	events, err = replay.StateSyncEvents(ctx, 1, 25)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	events, err = replay.StateSyncEvents(ctx, 3, 45)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, uint64(4), events[1].ID)
+
+	// Beyond the known history
+	_, err = replay.StateSyncEvents(ctx, 5, 100)
+	require.ErrorIs(t, err, ErrNotCached)
+}
+
+func TestReplayModeSoftErrors(t *testing.T) {
+	t.Parallel()
+
+	replay := NewReplayClient(rawdb.NewMemoryDatabase())
+
+	_, err := replay.FetchMilestone(context.Background())
+	require.ErrorIs(t, err, heimdall.ErrServiceUnavailable)
+
+	_, err = replay.FetchCheckpoint(context.Background(), -1)
+	require.ErrorIs(t, err, heimdall.ErrServiceUnavailable)
+
+	_, err = replay.FetchCheckpoint(context.Background(), 7)
+	require.ErrorIs(t, err, ErrNotCached)
+}
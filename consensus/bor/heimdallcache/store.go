@@ -0,0 +1,106 @@
+package heimdallcache
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// eventCoverage describes which part of the state-sync event history is fully
+// available in the store. Every event with an ID in [FirstID, LastID] is stored
+// and no event with an ID above LastID has a record time before ToTime.
+type eventCoverage struct {
+	FirstID uint64 `json:"first_id"`
+	LastID  uint64 `json:"last_id"`
+	ToTime  int64  `json:"to_time"`
+}
+
+// covers reports whether a StateSyncEvents(fromID, to) query can be answered
+// from the store alone.
+func (c *eventCoverage) covers(fromID uint64, to int64) bool {
+	return c != nil && fromID >= c.FirstID && to <= c.ToTime
+}
+
+// readJSON loads and decodes the value stored under key. It returns false if the
+// key is missing or the stored value can't be decoded.
+func readJSON(db ethdb.KeyValueReader, key []byte, v interface{}) bool {
+	blob, err := db.Get(key)
+	if err != nil || len(blob) == 0 {
+		return false
+	}
+
+	return json.Unmarshal(blob, v) == nil
+}
+
+func writeJSON(db ethdb.KeyValueWriter, key []byte, v interface{}) error {
+	blob, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return db.Put(key, blob)
+}
+
+func readSpan(db ethdb.KeyValueReader, id uint64) *span.HeimdallSpan {
+	s := new(span.HeimdallSpan)
+	if !readJSON(db, rawdb.HeimdallSpanKey(id), s) {
+		return nil
+	}
+
+	return s
+}
+
+func writeSpan(db ethdb.KeyValueWriter, s *span.HeimdallSpan) error {
+	return writeJSON(db, rawdb.HeimdallSpanKey(s.ID), s)
+}
+
+func readEvent(db ethdb.KeyValueReader, id uint64) *clerk.EventRecordWithTime {
+	event := new(clerk.EventRecordWithTime)
+	if !readJSON(db, rawdb.HeimdallEventKey(id), event) {
+		return nil
+	}
+
+	return event
+}
+
+func writeEvents(db ethdb.Batcher, events []*clerk.EventRecordWithTime) error {
+	batch := db.NewBatch()
+
+	for _, event := range events {
+		if err := writeJSON(batch, rawdb.HeimdallEventKey(event.ID), event); err != nil {
+			return err
+		}
+	}
+
+	return batch.Write()
+}
+
+func readCheckpoint(db ethdb.KeyValueReader, number uint64) *checkpoint.Checkpoint {
+	cp := new(checkpoint.Checkpoint)
+	if !readJSON(db, rawdb.HeimdallCheckpointKey(number), cp) {
+		return nil
+	}
+
+	return cp
+}
+
+func writeCheckpoint(db ethdb.KeyValueWriter, number uint64, cp *checkpoint.Checkpoint) error {
+	return writeJSON(db, rawdb.HeimdallCheckpointKey(number), cp)
+}
+
+func readEventCoverage(db ethdb.KeyValueReader) *eventCoverage {
+	coverage := new(eventCoverage)
+	if !readJSON(db, rawdb.HeimdallEventCoverageKey, coverage) {
+		return nil
+	}
+
+	return coverage
+}
+
+func writeEventCoverage(db ethdb.KeyValueWriter, coverage *eventCoverage) error {
+	return writeJSON(db, rawdb.HeimdallEventCoverageKey, coverage)
+}
@@ -14,38 +14,67 @@ import (
 func (h *HeimdallGRPCClient) FetchCheckpointCount(ctx context.Context) (int64, error) {
 	log.Info("Fetching checkpoint count")
 
-	res, err := h.client.FetchCheckpointCount(ctx, nil)
+	var count int64
+
+	err := h.call(ctx, func(client proto.HeimdallClient) error {
+		res, err := client.FetchCheckpointCount(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		count = res.Result.Result
+
+		return nil
+	})
 	if err != nil {
 		return 0, err
 	}
 
 	log.Info("Fetched checkpoint count")
 
-	return res.Result.Result, nil
+	return count, nil
 }
 
 func (h *HeimdallGRPCClient) FetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
-	req := &proto.FetchCheckpointRequest{
-		ID: number,
-	}
-
 	log.Info("Fetching checkpoint", "number", number)
 
-	res, err := h.client.FetchCheckpoint(ctx, req)
+	result, err := h.fetchCheckpoint(ctx, number)
 	if err != nil {
 		return nil, err
 	}
 
 	log.Info("Fetched checkpoint", "number", number)
 
-	checkpoint := &checkpoint.Checkpoint{
-		StartBlock: new(big.Int).SetUint64(res.Result.StartBlock),
-		EndBlock:   new(big.Int).SetUint64(res.Result.EndBlock),
-		RootHash:   protoutils.ConvertH256ToHash(res.Result.RootHash),
-		Proposer:   protoutils.ConvertH160toAddress(res.Result.Proposer),
-		BorChainID: res.Result.BorChainID,
-		Timestamp:  uint64(res.Result.Timestamp.GetSeconds()),
+	return result, nil
+}
+
+func (h *HeimdallGRPCClient) fetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	req := &proto.FetchCheckpointRequest{
+		ID: number,
+	}
+
+	var result *checkpoint.Checkpoint
+
+	err := h.call(ctx, func(client proto.HeimdallClient) error {
+		res, err := client.FetchCheckpoint(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		result = &checkpoint.Checkpoint{
+			StartBlock: new(big.Int).SetUint64(res.Result.StartBlock),
+			EndBlock:   new(big.Int).SetUint64(res.Result.EndBlock),
+			RootHash:   protoutils.ConvertH256ToHash(res.Result.RootHash),
+			Proposer:   protoutils.ConvertH160toAddress(res.Result.Proposer),
+			BorChainID: res.Result.BorChainID,
+			Timestamp:  uint64(res.Result.Timestamp.GetSeconds()),
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return checkpoint, nil
+	return result, nil
 }
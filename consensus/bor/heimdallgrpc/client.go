@@ -1,8 +1,10 @@
 package heimdallgrpc
 
 import (
+	"context"
 	"time"
 
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
 	"github.com/ethereum/go-ethereum/log"
 
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
@@ -11,42 +13,150 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	stateFetchLimit = 50
+
+	// failoverRetries is the number of retries done against a single endpoint
+	// before failing over to the next one, if there are several of them.
+	failoverRetries = 3
+	retryCall       = 5 * time.Second
 )
 
+// failoverCodes are the error codes worth asking another endpoint, or the same
+// endpoints again, about. Any other code is an answer that won't change on retry.
+var failoverCodes = map[codes.Code]bool{
+	codes.Internal:          true,
+	codes.Unavailable:       true,
+	codes.Aborted:           true,
+	codes.DeadlineExceeded:  true,
+	codes.ResourceExhausted: true,
+}
+
 type HeimdallGRPCClient struct {
-	conn   *grpc.ClientConn
-	client proto.HeimdallClient
+	conns     []*grpc.ClientConn
+	clients   []proto.HeimdallClient
+	endpoints *heimdall.Endpoints
+	closeCh   chan struct{}
 }
 
-func NewHeimdallGRPCClient(address string) *HeimdallGRPCClient {
+// NewHeimdallGRPCClient creates a heimdall gRPC client. Requests are sent to the
+// healthiest of address and failoverAddresses, failing over to the others on error.
+func NewHeimdallGRPCClient(address string, failoverAddresses ...string) *HeimdallGRPCClient {
+	addresses := append([]string{address}, failoverAddresses...)
+
+	// A single endpoint keeps retrying (almost) forever, with failover endpoints
+	// configured give up early and move on to the next one instead.
+	maxRetries := uint(10000)
+	if len(addresses) > 1 {
+		maxRetries = failoverRetries
+	}
+
 	opts := []grpc_retry.CallOption{
-		grpc_retry.WithMax(10000),
+		grpc_retry.WithMax(maxRetries),
 		grpc_retry.WithBackoff(grpc_retry.BackoffLinear(5 * time.Second)),
-		grpc_retry.WithCodes(codes.Internal, codes.Unavailable, codes.Aborted, codes.NotFound),
+		grpc_retry.WithCodes(codes.Internal, codes.Unavailable, codes.Aborted),
+	}
+
+	h := &HeimdallGRPCClient{
+		endpoints: heimdall.NewEndpoints(addresses),
+		closeCh:   make(chan struct{}),
+	}
+
+	for _, address := range addresses {
+		conn, err := grpc.Dial(address,
+			grpc.WithStreamInterceptor(grpc_retry.StreamClientInterceptor(opts...)),
+			grpc.WithUnaryInterceptor(grpc_retry.UnaryClientInterceptor(opts...)),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		if err != nil {
+			log.Crit("Failed to connect to Heimdall gRPC", "address", address, "error", err)
+		}
+
+		log.Info("Connected to Heimdall gRPC server", "address", address)
+
+		h.conns = append(h.conns, conn)
+		h.clients = append(h.clients, proto.NewHeimdallClient(conn))
+	}
+
+	return h
+}
+
+// call runs fn against the healthiest endpoint, failing over to the other ones
+// on transient errors. If all of them fail, it retries until the context is done
+// or the client is closed. Errors with a code outside failoverCodes, such as
+// InvalidArgument or NotFound, are returned straight away. With a single
+// endpoint, the retries are left to the gRPC retry interceptor.
+func (h *HeimdallGRPCClient) call(ctx context.Context, fn func(client proto.HeimdallClient) error) error {
+	err := h.callEndpoints(ctx, fn)
+	if err == nil || len(h.clients) == 1 || !isFailoverError(err) {
+		return err
 	}
 
-	conn, err := grpc.Dial(address,
-		grpc.WithStreamInterceptor(grpc_retry.StreamClientInterceptor(opts...)),
-		grpc.WithUnaryInterceptor(grpc_retry.UnaryClientInterceptor(opts...)),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
-	if err != nil {
-		log.Crit("Failed to connect to Heimdall gRPC", "error", err)
+	ticker := time.NewTicker(retryCall)
+	defer ticker.Stop()
+
+	for {
+		log.Warn("Failed to fetch data from all Heimdall gRPC endpoints, retrying", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-h.closeCh:
+			return heimdall.ErrShutdownDetected
+		case <-ticker.C:
+			if err = h.callEndpoints(ctx, fn); err == nil || !isFailoverError(err) {
+				return err
+			}
+		}
 	}
+}
+
+func (h *HeimdallGRPCClient) callEndpoints(ctx context.Context, fn func(client proto.HeimdallClient) error) error {
+	var err error
+
+	for i, endpoint := range h.endpoints.Ordered() {
+		start := time.Now()
+		err = fn(h.clients[endpoint.Index()])
+
+		// Don't blame the endpoint for the caller giving up on the request
+		if ctx.Err() != nil {
+			return err
+		}
+
+		// An answer such as NotFound comes from a healthy endpoint
+		if err != nil && !isFailoverError(err) {
+			return err
+		}
 
-	log.Info("Connected to Heimdall gRPC server", "address", address)
+		endpoint.Report(start, err)
 
-	return &HeimdallGRPCClient{
-		conn:   conn,
-		client: proto.NewHeimdallClient(conn),
+		if err == nil {
+			if i > 0 {
+				log.Info("Fetched data from failover Heimdall gRPC endpoint", "endpoint", endpoint.Address)
+			}
+
+			return nil
+		}
 	}
+
+	return err
+}
+
+// isFailoverError reports whether err is a transient error another attempt may
+// not run into.
+func isFailoverError(err error) bool {
+	return failoverCodes[status.Code(err)]
 }
 
 func (h *HeimdallGRPCClient) Close() {
 	log.Debug("Shutdown detected, Closing Heimdall gRPC client")
-	h.conn.Close()
+
+	close(h.closeCh)
+
+	for _, conn := range h.conns {
+		conn.Close()
+	}
 }
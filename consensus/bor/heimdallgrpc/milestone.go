@@ -15,49 +15,89 @@ import (
 func (h *HeimdallGRPCClient) FetchMilestoneCount(ctx context.Context) (int64, error) {
 	log.Info("Fetching milestone count")
 
-	res, err := h.client.FetchMilestoneCount(ctx, nil)
+	var count int64
+
+	err := h.call(ctx, func(client proto.HeimdallClient) error {
+		res, err := client.FetchMilestoneCount(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		count = res.Result.Count
+
+		return nil
+	})
 	if err != nil {
 		return 0, err
 	}
 
 	log.Info("Fetched milestone count")
 
-	return res.Result.Count, nil
+	return count, nil
 }
 
 func (h *HeimdallGRPCClient) FetchMilestone(ctx context.Context) (*milestone.Milestone, error) {
 	log.Info("Fetching milestone")
 
-	res, err := h.client.FetchMilestone(ctx, nil)
+	result, err := h.fetchMilestone(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	log.Info("Fetched milestone")
 
-	milestone := &milestone.Milestone{
-		StartBlock: new(big.Int).SetUint64(res.Result.StartBlock),
-		EndBlock:   new(big.Int).SetUint64(res.Result.EndBlock),
-		Hash:       protoutils.ConvertH256ToHash(res.Result.RootHash),
-		Proposer:   protoutils.ConvertH160toAddress(res.Result.Proposer),
-		BorChainID: res.Result.BorChainID,
-		Timestamp:  uint64(res.Result.Timestamp.GetSeconds()),
+	return result, nil
+}
+
+func (h *HeimdallGRPCClient) fetchMilestone(ctx context.Context) (*milestone.Milestone, error) {
+	var result *milestone.Milestone
+
+	err := h.call(ctx, func(client proto.HeimdallClient) error {
+		res, err := client.FetchMilestone(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		result = &milestone.Milestone{
+			StartBlock: new(big.Int).SetUint64(res.Result.StartBlock),
+			EndBlock:   new(big.Int).SetUint64(res.Result.EndBlock),
+			Hash:       protoutils.ConvertH256ToHash(res.Result.RootHash),
+			Proposer:   protoutils.ConvertH160toAddress(res.Result.Proposer),
+			BorChainID: res.Result.BorChainID,
+			Timestamp:  uint64(res.Result.Timestamp.GetSeconds()),
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return milestone, nil
+	return result, nil
 }
 
 func (h *HeimdallGRPCClient) FetchLastNoAckMilestone(ctx context.Context) (string, error) {
 	log.Info("Fetching latest no ack milestone Id")
 
-	res, err := h.client.FetchLastNoAckMilestone(ctx, nil)
+	var milestoneID string
+
+	err := h.call(ctx, func(client proto.HeimdallClient) error {
+		res, err := client.FetchLastNoAckMilestone(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		milestoneID = res.Result.Result
+
+		return nil
+	})
 	if err != nil {
 		return "", err
 	}
 
 	log.Info("Fetched last no-ack milestone")
 
-	return res.Result.Result, nil
+	return milestoneID, nil
 }
 
 func (h *HeimdallGRPCClient) FetchNoAckMilestone(ctx context.Context, milestoneID string) error {
@@ -67,12 +107,23 @@ func (h *HeimdallGRPCClient) FetchNoAckMilestone(ctx context.Context, milestoneI
 
 	log.Info("Fetching no ack milestone", "milestoneaID", milestoneID)
 
-	res, err := h.client.FetchNoAckMilestone(ctx, req)
+	var result bool
+
+	err := h.call(ctx, func(client proto.HeimdallClient) error {
+		res, err := client.FetchNoAckMilestone(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		result = res.Result.Result
+
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if !res.Result.Result {
+	if !result {
 		return fmt.Errorf("Not in rejected list: milestoneID %q", milestoneID)
 	}
 
@@ -88,12 +139,23 @@ func (h *HeimdallGRPCClient) FetchMilestoneID(ctx context.Context, milestoneID s
 
 	log.Info("Fetching milestone id", "milestoneID", milestoneID)
 
-	res, err := h.client.FetchMilestoneID(ctx, req)
+	var result bool
+
+	err := h.call(ctx, func(client proto.HeimdallClient) error {
+		res, err := client.FetchMilestoneID(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		result = res.Result.Result
+
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if !res.Result.Result {
+	if !result {
 		return fmt.Errorf("This milestoneID %q does not exist", milestoneID)
 	}
 
@@ -12,20 +12,40 @@ import (
 )
 
 func (h *HeimdallGRPCClient) Span(ctx context.Context, spanID uint64) (*span.HeimdallSpan, error) {
+	log.Info("Fetching span", "spanID", spanID)
+
+	result, err := h.span(ctx, spanID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Fetched span", "spanID", spanID)
+
+	return result, nil
+}
+
+func (h *HeimdallGRPCClient) span(ctx context.Context, spanID uint64) (*span.HeimdallSpan, error) {
 	req := &proto.SpanRequest{
 		ID: spanID,
 	}
 
-	log.Info("Fetching span", "spanID", spanID)
+	var result *span.HeimdallSpan
+
+	err := h.call(ctx, func(client proto.HeimdallClient) error {
+		res, err := client.Span(ctx, req)
+		if err != nil {
+			return err
+		}
 
-	res, err := h.client.Span(ctx, req)
+		result = parseSpan(res.Result)
+
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	log.Info("Fetched span", "spanID", spanID)
-
-	return parseSpan(res.Result), nil
+	return result, nil
 }
 
 func parseSpan(protoSpan *proto.Span) *span.HeimdallSpan {
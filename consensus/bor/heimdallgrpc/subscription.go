@@ -0,0 +1,72 @@
+package heimdallgrpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// The heimdall gRPC service has no streaming endpoints for finality data, so
+// subscriptions poll the latest item over the open connection in the intervals
+// of the heimdall package. Every request is bounded by subscriptionTimeout, so
+// the retry interceptor doesn't block a subscription on data which doesn't
+// exist yet.
+const subscriptionTimeout = 5 * time.Second
+
+// SubscribeMilestones sends every new latest milestone to ch.
+func (h *HeimdallGRPCClient) SubscribeMilestones(ch chan<- *milestone.Milestone) event.Subscription {
+	return heimdall.PollMilestones(ch, func(ctx context.Context) (*milestone.Milestone, error) {
+		if err := h.closed(); err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, subscriptionTimeout)
+		defer cancel()
+
+		return h.fetchMilestone(ctx)
+	})
+}
+
+// SubscribeCheckpoints sends every new latest checkpoint to ch.
+func (h *HeimdallGRPCClient) SubscribeCheckpoints(ch chan<- *checkpoint.Checkpoint) event.Subscription {
+	return heimdall.PollCheckpoints(ch, func(ctx context.Context) (*checkpoint.Checkpoint, error) {
+		if err := h.closed(); err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, subscriptionTimeout)
+		defer cancel()
+
+		return h.fetchCheckpoint(ctx, -1)
+	})
+}
+
+// SubscribeSpans sends the spans starting at fromID to ch as soon as they are
+// proposed, requesting the next span until heimdall knows about it.
+func (h *HeimdallGRPCClient) SubscribeSpans(fromID uint64, ch chan<- *span.HeimdallSpan) event.Subscription {
+	return heimdall.PollSpans(fromID, ch, func(ctx context.Context, spanID uint64) (*span.HeimdallSpan, error) {
+		if err := h.closed(); err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, subscriptionTimeout)
+		defer cancel()
+
+		return h.span(ctx, spanID)
+	})
+}
+
+// closed returns heimdall.ErrShutdownDetected once the client was closed.
+func (h *HeimdallGRPCClient) closed() error {
+	select {
+	case <-h.closeCh:
+		return heimdall.ErrShutdownDetected
+	default:
+		return nil
+	}
+}
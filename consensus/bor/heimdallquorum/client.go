@@ -0,0 +1,382 @@
+// Package heimdallquorum implements a bor.IHeimdallClient which cross-checks the
+// responses of several Heimdall endpoints before accepting them.
+package heimdallquorum
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ErrNoQuorum is returned if not enough endpoints agreed on a response.
+var ErrNoQuorum = errors.New("heimdall endpoints did not reach quorum")
+
+var (
+	_ bor.IHeimdallClient     = (*Client)(nil)
+	_ bor.IHeimdallSubscriber = (*Client)(nil)
+)
+
+const (
+	// memberTimeout is the time a member is given to answer a single request,
+	// so that a dead member can't hold up the quorum.
+	memberTimeout = 10 * time.Second
+
+	// retryDelay is the time waited before asking all members again if the
+	// quorum wasn't reached because some of them failed.
+	retryDelay = 5 * time.Second
+)
+
+// Member is a single heimdall endpoint taking part in the quorum.
+type Member struct {
+	Name   string              // Endpoint address, used for reporting
+	Client bor.IHeimdallClient // Client talking to this endpoint only
+}
+
+// Client asks all members for spans, checkpoints, milestones and state-sync
+// events and only accepts a response once quorum members returned an identical
+// (decoded) result. Requests which don't carry consensus critical data are
+// forwarded to the fallback client.
+type Client struct {
+	members  []Member
+	quorum   int
+	fallback bor.IHeimdallClient
+
+	memberTimeout time.Duration
+	retryDelay    time.Duration
+	closeCh       chan struct{}
+}
+
+// NewClient creates a quorum client over the given members. The fallback client
+// serves the remaining requests, usually a failover client over the same endpoints.
+func NewClient(members []Member, quorum int, fallback bor.IHeimdallClient) (*Client, error) {
+	if quorum < 1 || quorum > len(members) {
+		return nil, fmt.Errorf("invalid heimdall quorum %d for %d endpoints", quorum, len(members))
+	}
+
+	log.Info("Heimdall quorum mode enabled", "quorum", quorum, "endpoints", len(members))
+
+	return &Client{
+		members:       members,
+		quorum:        quorum,
+		fallback:      fallback,
+		memberTimeout: memberTimeout,
+		retryDelay:    retryDelay,
+		closeCh:       make(chan struct{}),
+	}, nil
+}
+
+func (c *Client) StateSyncEvents(ctx context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	return query(ctx, c, stateSyncRequest, func(ctx context.Context, client bor.IHeimdallClient) ([]*clerk.EventRecordWithTime, error) {
+		return client.StateSyncEvents(ctx, fromID, to)
+	})
+}
+
+func (c *Client) Span(ctx context.Context, spanID uint64) (*span.HeimdallSpan, error) {
+	return query(ctx, c, spanRequest, func(ctx context.Context, client bor.IHeimdallClient) (*span.HeimdallSpan, error) {
+		return client.Span(ctx, spanID)
+	})
+}
+
+func (c *Client) FetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	return query(ctx, c, checkpointRequest, func(ctx context.Context, client bor.IHeimdallClient) (*checkpoint.Checkpoint, error) {
+		return client.FetchCheckpoint(ctx, number)
+	})
+}
+
+func (c *Client) FetchMilestone(ctx context.Context) (*milestone.Milestone, error) {
+	return query(ctx, c, milestoneRequest, func(ctx context.Context, client bor.IHeimdallClient) (*milestone.Milestone, error) {
+		return client.FetchMilestone(ctx)
+	})
+}
+
+func (c *Client) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	return c.fallback.FetchCheckpointCount(ctx)
+}
+
+func (c *Client) FetchMilestoneCount(ctx context.Context) (int64, error) {
+	return c.fallback.FetchMilestoneCount(ctx)
+}
+
+func (c *Client) FetchNoAckMilestone(ctx context.Context, milestoneID string) error {
+	return c.fallback.FetchNoAckMilestone(ctx, milestoneID)
+}
+
+func (c *Client) FetchLastNoAckMilestone(ctx context.Context) (string, error) {
+	return c.fallback.FetchLastNoAckMilestone(ctx)
+}
+
+func (c *Client) FetchMilestoneID(ctx context.Context, milestoneID string) error {
+	return c.fallback.FetchMilestoneID(ctx, milestoneID)
+}
+
+// SubscribeMilestones sends every new latest milestone the members agree on to
+// ch. New milestones are noticed through the fallback client's subscription, or
+// by polling if it doesn't support subscriptions.
+func (c *Client) SubscribeMilestones(ch chan<- *milestone.Milestone) event.Subscription {
+	subscriber, ok := c.fallback.(bor.IHeimdallSubscriber)
+	if !ok {
+		return heimdall.PollMilestones(ch, c.FetchMilestone)
+	}
+
+	return forward(ch, subscriber.SubscribeMilestones, func(m *milestone.Milestone) uint64 { return m.EndBlock.Uint64() },
+		func(ctx context.Context, _ *milestone.Milestone) (*milestone.Milestone, error) {
+			return c.FetchMilestone(ctx)
+		})
+}
+
+// SubscribeCheckpoints sends every new latest checkpoint the members agree on
+// to ch, like SubscribeMilestones.
+func (c *Client) SubscribeCheckpoints(ch chan<- *checkpoint.Checkpoint) event.Subscription {
+	fetch := func(ctx context.Context, _ *checkpoint.Checkpoint) (*checkpoint.Checkpoint, error) {
+		return c.FetchCheckpoint(ctx, -1)
+	}
+
+	subscriber, ok := c.fallback.(bor.IHeimdallSubscriber)
+	if !ok {
+		return heimdall.PollCheckpoints(ch, func(ctx context.Context) (*checkpoint.Checkpoint, error) {
+			return fetch(ctx, nil)
+		})
+	}
+
+	return forward(ch, subscriber.SubscribeCheckpoints, func(cp *checkpoint.Checkpoint) uint64 { return cp.EndBlock.Uint64() }, fetch)
+}
+
+// SubscribeSpans sends the spans starting at fromID the members agree on to ch
+// as soon as they are proposed, like SubscribeMilestones.
+func (c *Client) SubscribeSpans(fromID uint64, ch chan<- *span.HeimdallSpan) event.Subscription {
+	subscriber, ok := c.fallback.(bor.IHeimdallSubscriber)
+	if !ok {
+		return heimdall.PollSpans(fromID, ch, c.Span)
+	}
+
+	subscribe := func(ch chan<- *span.HeimdallSpan) event.Subscription {
+		return subscriber.SubscribeSpans(fromID, ch)
+	}
+
+	return forward(ch, subscribe, func(s *span.HeimdallSpan) uint64 { return s.ID },
+		func(ctx context.Context, s *span.HeimdallSpan) (*span.HeimdallSpan, error) {
+			return c.Span(ctx, s.ID)
+		})
+}
+
+// forward subscribes through subscribe and, for every item received, sends the
+// result of confirm to ch if its key is higher than the last one sent. Items of
+// a single endpoint can't be trusted, so confirm asks all members for it.
+func forward[T any](ch chan<- *T, subscribe func(chan<- *T) event.Subscription, key func(*T) uint64, confirm func(context.Context, *T) (*T, error)) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go func() {
+			select {
+			case <-quit:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		items := make(chan *T)
+
+		sub := subscribe(items)
+		defer sub.Unsubscribe()
+
+		var last *T
+
+		for {
+			select {
+			case item := <-items:
+				confirmed, err := confirm(ctx, item)
+				if err != nil {
+					if ctx.Err() == nil {
+						log.Warn("Failed to confirm heimdall subscription item", "err", err)
+					}
+
+					continue
+				}
+
+				if last != nil && key(confirmed) <= key(last) {
+					continue
+				}
+
+				select {
+				case ch <- confirmed:
+					last = confirmed
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	})
+}
+
+// Close aborts the pending requests and closes the member and fallback clients.
+func (c *Client) Close() {
+	close(c.closeCh)
+
+	for _, member := range c.members {
+		member.Client.Close()
+	}
+
+	c.fallback.Close()
+}
+
+type answer[T any] struct {
+	member int
+	value  T
+	key    string // JSON encoding of value, used for comparison
+	err    error
+}
+
+// query asks all members for the result until quorum members agree on it. If
+// the quorum is missed only because some members failed, all of them are asked
+// again after a delay, until the context is done or the client is closed.
+func query[T any](ctx context.Context, c *Client, reqType requestType, fn func(context.Context, bor.IHeimdallClient) (T, error)) (T, error) {
+	for attempt := 1; ; attempt++ {
+		result, retry, err := queryOnce(ctx, c, reqType, fn)
+		if !retry {
+			return result, err
+		}
+
+		log.Warn("Heimdall quorum not reached, retrying", "type", reqType, "attempt", attempt, "err", err)
+
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-c.closeCh:
+			return result, heimdall.ErrShutdownDetected
+		case <-time.After(c.retryDelay):
+		}
+	}
+}
+
+// queryOnce sends the request to all members concurrently and returns the first
+// result which quorum members agree on. Every member gets a single attempt within
+// memberTimeout. Outstanding requests are cancelled as soon as the quorum is
+// reached or can't be reached anymore. The returned flag reports whether the
+// quorum could still be reached by the failed members on another attempt.
+func queryOnce[T any](ctx context.Context, c *Client, reqType requestType, fn func(context.Context, bor.IHeimdallClient) (T, error)) (T, bool, error) {
+	var zero T
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	answers := make(chan answer[T], len(c.members))
+
+	for i, member := range c.members {
+		go func(i int, client bor.IHeimdallClient) {
+			a := answer[T]{member: i}
+
+			memberCtx, memberCancel := context.WithTimeout(heimdall.WithSingleAttempt(ctx), c.memberTimeout)
+			defer memberCancel()
+
+			a.value, a.err = fn(memberCtx, client)
+			if a.err == nil {
+				var blob []byte
+
+				blob, a.err = json.Marshal(a.value)
+				a.key = string(blob)
+			}
+
+			answers <- a
+		}(i, member.Client)
+	}
+
+	var (
+		votes    = make(map[string]int)
+		received = make([]answer[T], 0, len(c.members))
+		best     int
+		failed   int // failures worth retrying
+	)
+
+	for pending := len(c.members); pending > 0; pending-- {
+		var a answer[T]
+
+		select {
+		case a = <-answers:
+		case <-ctx.Done():
+			return zero, false, ctx.Err()
+		}
+
+		received = append(received, a)
+
+		if a.err != nil {
+			log.Debug("Heimdall quorum member failed", "type", reqType, "endpoint", c.members[a.member].Name, "err", a.err)
+
+			// A 503 means the endpoint doesn't serve the request yet, asking again won't help
+			if !errors.Is(a.err, heimdall.ErrServiceUnavailable) {
+				failed++
+			}
+		} else {
+			votes[a.key]++
+
+			if votes[a.key] > best {
+				best = votes[a.key]
+			}
+
+			if votes[a.key] >= c.quorum {
+				if len(votes) > 1 {
+					reportDisagreement(c, reqType, received)
+				}
+
+				quorumMeters[reqType].agreement.Mark(1)
+
+				return a.value, false, nil
+			}
+		}
+
+		// Stop waiting if the outstanding answers can't make up a quorum anymore
+		if best+pending-1 < c.quorum {
+			break
+		}
+	}
+
+	quorumMeters[reqType].failure.Mark(1)
+
+	// The members which failed or didn't answer yet could have made up the quorum
+	retry := best+failed+len(c.members)-len(received) >= c.quorum
+
+	// Nobody answered, surface the underlying error (e.g. service unavailable)
+	if len(votes) == 0 {
+		return zero, retry, received[len(received)-1].err
+	}
+
+	if len(votes) > 1 {
+		reportDisagreement(c, reqType, received)
+	}
+
+	log.Error("Heimdall endpoints did not reach quorum", "type", reqType, "quorum", c.quorum, "best", best, "answers", len(received))
+
+	return zero, retry, fmt.Errorf("%w: %s, %d of %d required endpoints agreed", ErrNoQuorum, reqType, best, c.quorum)
+}
+
+// reportDisagreement logs the diverging answers and marks the disagreement meter.
+func reportDisagreement[T any](c *Client, reqType requestType, received []answer[T]) {
+	quorumMeters[reqType].disagreement.Mark(1)
+
+	ctx := []interface{}{"type", reqType}
+
+	for _, a := range received {
+		if a.err != nil {
+			ctx = append(ctx, c.members[a.member].Name, a.err.Error())
+		} else {
+			ctx = append(ctx, c.members[a.member].Name, a.key)
+		}
+	}
+
+	log.Warn("Heimdall endpoints returned different responses", ctx...)
+}
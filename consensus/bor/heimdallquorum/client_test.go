@@ -0,0 +1,193 @@
+package heimdallquorum
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+)
+
+// fakeHeimdall answers span requests with a fixed span or error.
+type fakeHeimdall struct {
+	span *span.HeimdallSpan
+	err  error
+}
+
+func (f *fakeHeimdall) StateSyncEvents(context.Context, uint64, int64) ([]*clerk.EventRecordWithTime, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeHeimdall) Span(context.Context, uint64) (*span.HeimdallSpan, error) {
+	return f.span, f.err
+}
+
+func (f *fakeHeimdall) FetchCheckpoint(context.Context, int64) (*checkpoint.Checkpoint, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeHeimdall) FetchCheckpointCount(context.Context) (int64, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (f *fakeHeimdall) FetchMilestone(context.Context) (*milestone.Milestone, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeHeimdall) FetchMilestoneCount(context.Context) (int64, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (f *fakeHeimdall) FetchNoAckMilestone(context.Context, string) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeHeimdall) FetchLastNoAckMilestone(context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (f *fakeHeimdall) FetchMilestoneID(context.Context, string) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeHeimdall) Close() {}
+
+func newSpan(id uint64, endBlock uint64) *span.HeimdallSpan {
+	return &span.HeimdallSpan{
+		Span:    span.Span{ID: id, StartBlock: 256, EndBlock: endBlock},
+		ChainID: "15001",
+	}
+}
+
+func newQuorumClient(t *testing.T, quorum int, clients ...*fakeHeimdall) *Client {
+	t.Helper()
+
+	members := make([]Member, 0, len(clients))
+	for i, client := range clients {
+		members = append(members, Member{Name: string(rune('a' + i)), Client: client})
+	}
+
+	c, err := NewClient(members, quorum, &fakeHeimdall{})
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestQuorumAgreement(t *testing.T) {
+	t.Parallel()
+
+	c := newQuorumClient(t, 2,
+		&fakeHeimdall{span: newSpan(1, 6655)},
+		&fakeHeimdall{span: newSpan(1, 9999)},
+		&fakeHeimdall{span: newSpan(1, 6655)},
+	)
+
+	s, err := c.Span(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6655), s.EndBlock)
+}
+
+func TestQuorumDisagreement(t *testing.T) {
+	t.Parallel()
+
+	c := newQuorumClient(t, 2,
+		&fakeHeimdall{span: newSpan(1, 6655)},
+		&fakeHeimdall{span: newSpan(1, 9999)},
+		&fakeHeimdall{span: newSpan(1, 7777)},
+	)
+
+	// All members answered, so asking them again won't help
+	_, err := c.Span(context.Background(), 1)
+	require.ErrorIs(t, err, ErrNoQuorum)
+}
+
+// flakyHeimdall fails the first span requests, either right away or by hanging
+// until the request is cancelled, before answering them.
+type flakyHeimdall struct {
+	fakeHeimdall
+
+	failures atomic.Int32
+	hang     bool
+}
+
+func (f *flakyHeimdall) Span(ctx context.Context, spanID uint64) (*span.HeimdallSpan, error) {
+	if f.failures.Add(-1) >= 0 {
+		if f.hang {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+
+		return nil, errors.New("connection refused")
+	}
+
+	return f.fakeHeimdall.Span(ctx, spanID)
+}
+
+func TestQuorumRetry(t *testing.T) {
+	t.Parallel()
+
+	var (
+		failing = &flakyHeimdall{fakeHeimdall: fakeHeimdall{span: newSpan(1, 6655)}}
+		hanging = &flakyHeimdall{fakeHeimdall: fakeHeimdall{span: newSpan(1, 6655)}, hang: true}
+	)
+
+	failing.failures.Store(2)
+	hanging.failures.Store(1)
+
+	members := []Member{{Name: "a", Client: failing}, {Name: "b", Client: hanging}}
+
+	c, err := NewClient(members, 2, &fakeHeimdall{})
+	require.NoError(t, err)
+
+	c.memberTimeout = 50 * time.Millisecond
+	c.retryDelay = 10 * time.Millisecond
+
+	// Members are asked again until all of them agree
+	s, err := c.Span(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6655), s.EndBlock)
+	require.Equal(t, int32(-1), failing.failures.Load())
+	require.Equal(t, int32(-2), hanging.failures.Load())
+
+	// Retrying stops once the client is closed
+	failing.failures.Store(1 << 20)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		c.Close()
+	}()
+
+	_, err = c.Span(context.Background(), 1)
+	require.ErrorIs(t, err, heimdall.ErrShutdownDetected)
+}
+
+func TestQuorumUnavailable(t *testing.T) {
+	t.Parallel()
+
+	c := newQuorumClient(t, 1,
+		&fakeHeimdall{err: heimdall.ErrServiceUnavailable},
+		&fakeHeimdall{err: heimdall.ErrServiceUnavailable},
+	)
+
+	// If no endpoint answered, the underlying error is returned as is
+	_, err := c.Span(context.Background(), 1)
+	require.ErrorIs(t, err, heimdall.ErrServiceUnavailable)
+}
+
+func TestQuorumInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewClient([]Member{{Name: "a", Client: &fakeHeimdall{}}}, 2, &fakeHeimdall{})
+	require.Error(t, err)
+
+	_, err = NewClient([]Member{{Name: "a", Client: &fakeHeimdall{}}}, 0, &fakeHeimdall{})
+	require.Error(t, err)
+}
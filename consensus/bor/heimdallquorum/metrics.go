@@ -0,0 +1,47 @@
+package heimdallquorum
+
+import (
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+type (
+	requestType string
+
+	meter struct {
+		agreement    metrics.Meter // quorum reached
+		disagreement metrics.Meter // endpoints returned different responses
+		failure      metrics.Meter // quorum not reached
+	}
+)
+
+const (
+	stateSyncRequest  requestType = "state-sync"
+	spanRequest       requestType = "span"
+	checkpointRequest requestType = "checkpoint"
+	milestoneRequest  requestType = "milestone"
+)
+
+var (
+	quorumMeters = map[requestType]meter{
+		stateSyncRequest: {
+			agreement:    metrics.NewRegisteredMeter("client/quorum/statesync/agreement", nil),
+			disagreement: metrics.NewRegisteredMeter("client/quorum/statesync/disagreement", nil),
+			failure:      metrics.NewRegisteredMeter("client/quorum/statesync/failure", nil),
+		},
+		spanRequest: {
+			agreement:    metrics.NewRegisteredMeter("client/quorum/span/agreement", nil),
+			disagreement: metrics.NewRegisteredMeter("client/quorum/span/disagreement", nil),
+			failure:      metrics.NewRegisteredMeter("client/quorum/span/failure", nil),
+		},
+		checkpointRequest: {
+			agreement:    metrics.NewRegisteredMeter("client/quorum/checkpoint/agreement", nil),
+			disagreement: metrics.NewRegisteredMeter("client/quorum/checkpoint/disagreement", nil),
+			failure:      metrics.NewRegisteredMeter("client/quorum/checkpoint/failure", nil),
+		},
+		milestoneRequest: {
+			agreement:    metrics.NewRegisteredMeter("client/quorum/milestone/agreement", nil),
+			disagreement: metrics.NewRegisteredMeter("client/quorum/milestone/disagreement", nil),
+			failure:      metrics.NewRegisteredMeter("client/quorum/milestone/failure", nil),
+		},
+	}
+)
@@ -0,0 +1,340 @@
+package heimdallsim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// longPollInterval is the interval long-polling requests check for new data in.
+const longPollInterval = 250 * time.Millisecond
+
+// server serves the simulator over HTTP.
+type server struct {
+	listener net.Listener
+	http     *http.Server
+}
+
+// response is the envelope of all Heimdall REST responses.
+type response struct {
+	Height string      `json:"height"`
+	Result interface{} `json:"result"`
+}
+
+// eventRequest is the body of a request scripting a new state-sync event.
+type eventRequest struct {
+	Contract common.Address `json:"contract"`
+	Data     hexutil.Bytes  `json:"data"`
+}
+
+// Start serves the Heimdall REST API on the given listen address.
+func (s *Simulator) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	s.server = &server{
+		listener: listener,
+		http: &http.Server{
+			Handler:           s.Handler(),
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+	}
+
+	go func() {
+		if err := s.server.http.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("Heimdall simulator stopped", "err", err)
+		}
+	}()
+
+	log.Info("Heimdall simulator started", "url", s.URL(), "validators", len(s.config.Validators), "producers", s.config.Producers, "span", s.config.SpanLength)
+
+	return nil
+}
+
+// URL returns the base URL of the running simulator, to be used as heimdall URL.
+func (s *Simulator) URL() string {
+	if s.server == nil {
+		return ""
+	}
+
+	return "http://" + s.server.listener.Addr().String()
+}
+
+// Close stops serving the REST API.
+func (s *Simulator) Close() {
+	if s.server == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.server.http.Shutdown(ctx); err != nil {
+		log.Warn("Failed to stop heimdall simulator", "err", err)
+	}
+}
+
+// Handler returns the HTTP handler of the Heimdall REST API. Besides the read
+// endpoints used by bor, it serves
+//
+//	POST /simulator/event-record             {"contract": "0x..", "data": "0x.."}
+//	POST /simulator/milestone/noAck/<id>
+//
+// to queue state-sync events and to reject milestones.
+func (s *Simulator) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/bor/span/", s.handleSpan)
+	mux.HandleFunc("/bor/latest-span", s.handleLatestSpan)
+	mux.HandleFunc("/clerk/event-record/list", s.handleStateSyncEvents)
+	mux.HandleFunc("/checkpoints/", s.handleCheckpoint)
+	mux.HandleFunc("/milestone/", s.handleMilestone)
+	mux.HandleFunc("/simulator/event-record", s.handleAddEvent)
+	mux.HandleFunc("/simulator/milestone/noAck/", s.handleRejectMilestone)
+
+	return mux
+}
+
+func (s *Simulator) handleSpan(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/bor/span/"), 10, 64)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.writeResult(w, s.Span(id))
+}
+
+func (s *Simulator) handleLatestSpan(w http.ResponseWriter, r *http.Request) {
+	s.longPoll(r, s.LatestSpanID)
+
+	id, err := s.LatestSpanID()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.writeResult(w, s.Span(id))
+}
+
+func (s *Simulator) handleStateSyncEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	fromID, err := strconv.ParseUint(query.Get("from-id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid from-id", http.StatusBadRequest)
+		return
+	}
+
+	to, err := strconv.ParseInt(query.Get("to-time"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid to-time", http.StatusBadRequest)
+		return
+	}
+
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		http.Error(w, "invalid limit", http.StatusBadRequest)
+		return
+	}
+
+	s.writeResult(w, s.StateSyncEvents(fromID, to, limit))
+}
+
+func (s *Simulator) handleCheckpoint(w http.ResponseWriter, r *http.Request) {
+	param := strings.TrimPrefix(r.URL.Path, "/checkpoints/")
+
+	if param == "count" {
+		count, err := s.CheckpointCount()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		s.writeResult(w, checkpoint.CheckpointCount{Result: count})
+
+		return
+	}
+
+	number := int64(-1)
+
+	if param == "latest" {
+		s.longPoll(r, func() (uint64, error) {
+			return s.lastEnd(s.config.CheckpointLength)
+		})
+	} else {
+		n, err := strconv.ParseInt(param, 10, 64)
+		if err != nil || n < 1 {
+			http.Error(w, "invalid checkpoint number", http.StatusBadRequest)
+			return
+		}
+
+		number = n
+	}
+
+	result, err := s.Checkpoint(number)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.writeResult(w, result)
+}
+
+func (s *Simulator) handleMilestone(w http.ResponseWriter, r *http.Request) {
+	param := strings.TrimPrefix(r.URL.Path, "/milestone/")
+
+	switch {
+	case param == "latest":
+		s.longPoll(r, func() (uint64, error) {
+			return s.lastEnd(s.config.MilestoneLength)
+		})
+
+		result, err := s.Milestone()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		s.writeResult(w, result)
+
+	case param == "count":
+		count, err := s.MilestoneCount()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		s.writeResult(w, milestone.MilestoneCount{Count: count})
+
+	case param == "lastNoAck":
+		s.writeResult(w, milestone.MilestoneLastNoAck{Result: s.LastNoAckMilestone()})
+
+	case strings.HasPrefix(param, "noAck/"):
+		s.writeResult(w, milestone.MilestoneNoAck{Result: s.IsNoAckMilestone(strings.TrimPrefix(param, "noAck/"))})
+
+	case strings.HasPrefix(param, "ID/"):
+		s.writeResult(w, milestone.MilestoneID{Result: s.HasMilestoneID(strings.TrimPrefix(param, "ID/"))})
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Simulator) handleAddEvent(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req eventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	event := s.AddStateSyncEvent(req.Contract, req.Data)
+
+	log.Info("Queued simulated state-sync event", "id", event.ID, "contract", event.Contract)
+
+	s.writeResult(w, event)
+}
+
+func (s *Simulator) handleRejectMilestone(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/simulator/milestone/noAck/")
+	if id == "" {
+		http.Error(w, "missing milestone id", http.StatusBadRequest)
+		return
+	}
+
+	s.RejectMilestone(id)
+
+	log.Info("Rejected simulated milestone", "id", id)
+
+	s.writeResult(w, milestone.MilestoneNoAck{Result: true})
+}
+
+// longPoll holds requests carrying wait (in seconds) and after parameters until
+// latest returns a value above after, the wait time passed or the request got
+// cancelled.
+func (s *Simulator) longPoll(r *http.Request, latest func() (uint64, error)) {
+	query := r.URL.Query()
+
+	wait, err := strconv.ParseUint(query.Get("wait"), 10, 64)
+	if err != nil || wait == 0 {
+		return
+	}
+
+	after, err := strconv.ParseUint(query.Get("after"), 10, 64)
+	if err != nil {
+		return
+	}
+
+	timeout := time.NewTimer(time.Duration(wait) * time.Second)
+	defer timeout.Stop()
+
+	ticker := time.NewTicker(longPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if value, err := latest(); err == nil && value > after {
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-timeout.C:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// writeResult writes the result in the Heimdall response envelope.
+func (s *Simulator) writeResult(w http.ResponseWriter, result interface{}) {
+	height := "0"
+	if head, err := s.head(); err == nil {
+		height = strconv.FormatUint(head, 10)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(response{Height: height, Result: result}); err != nil {
+		log.Debug("Failed to write heimdall simulator response", "err", err)
+	}
+}
+
+// writeError maps the simulator errors to HTTP status codes. Requests which
+// can't be served before the local chain is available are reported as service
+// unavailable, and items which don't exist (yet) as not found.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, errNoChain):
+		status = http.StatusServiceUnavailable
+	case errors.Is(err, errNotFound):
+		status = http.StatusNotFound
+	}
+
+	http.Error(w, err.Error(), status)
+}
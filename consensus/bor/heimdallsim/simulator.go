@@ -0,0 +1,440 @@
+// Package heimdallsim implements an in-process stand-in for Heimdall, serving
+// the REST API the bor heimdall client expects. It is meant for local devnets
+// and integration tests, not for production networks.
+package heimdallsim
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// firstSpanLength is the length of span 0, which is part of the genesis.
+	firstSpanLength = 256
+
+	// confirmations is the number of blocks a block has to be buried under
+	// before it is included in a checkpoint or milestone.
+	confirmations = 16
+)
+
+var (
+	// errNoChain is returned if checkpoints or milestones are requested before
+	// the local chain was attached to the simulator.
+	errNoChain = errors.New("local chain not available yet")
+
+	// errNotFound is returned if the requested item doesn't exist (yet).
+	errNotFound = errors.New("not found")
+)
+
+// Config contains the settings of the simulated Heimdall.
+type Config struct {
+	ChainID          string              // Bor chain id reported in spans, events, checkpoints and milestones
+	Validators       []*valset.Validator // Validator set of all spans
+	Producers        int                 // Number of block producers selected per span (0 = all validators)
+	SpanLength       uint64              // Number of blocks in a span after the first one
+	CheckpointLength uint64              // Number of blocks in a checkpoint
+	MilestoneLength  uint64              // Number of blocks in a milestone
+}
+
+// DefaultConfig contains the default lengths of spans, checkpoints and milestones.
+var DefaultConfig = Config{
+	SpanLength:       6400,
+	CheckpointLength: 256,
+	MilestoneLength:  16,
+}
+
+// ChainReader is the subset of the blockchain the simulator builds checkpoints
+// and milestones from.
+type ChainReader interface {
+	CurrentHeader() *types.Header
+	GetHeaderByNumber(number uint64) *types.Header
+}
+
+// Simulator is a self-contained Heimdall stand-in. Spans rotate the block
+// producers over the configured validators, state-sync events are queued by the
+// caller, and checkpoints and milestones are derived from the local chain.
+type Simulator struct {
+	config Config
+
+	lock      sync.RWMutex
+	chain     ChainReader                  // Local chain, nil until attached
+	events    []*clerk.EventRecordWithTime // Queued state-sync events, event i has ID i+1
+	noAck     map[string]struct{}          // Milestone IDs which weren't acknowledged
+	lastNoAck string                       // Last milestone ID which wasn't acknowledged
+
+	server *server // HTTP server, nil if not started
+}
+
+// New creates a simulator with the given config. Missing lengths are taken from
+// DefaultConfig.
+func New(config Config) (*Simulator, error) {
+	if len(config.Validators) == 0 {
+		return nil, errors.New("heimdall simulator needs at least one validator")
+	}
+
+	if config.Producers <= 0 || config.Producers > len(config.Validators) {
+		config.Producers = len(config.Validators)
+	}
+
+	if config.SpanLength == 0 {
+		config.SpanLength = DefaultConfig.SpanLength
+	}
+
+	if config.CheckpointLength == 0 {
+		config.CheckpointLength = DefaultConfig.CheckpointLength
+	}
+
+	if config.MilestoneLength == 0 {
+		config.MilestoneLength = DefaultConfig.MilestoneLength
+	}
+
+	validators := make([]*valset.Validator, len(config.Validators))
+
+	for i, validator := range config.Validators {
+		validators[i] = validator.Copy()
+
+		if validators[i].ID == 0 {
+			validators[i].ID = uint64(i + 1)
+		}
+	}
+
+	config.Validators = validators
+
+	return &Simulator{
+		config: config,
+		noAck:  make(map[string]struct{}),
+	}, nil
+}
+
+// SetChain attaches the local chain checkpoints and milestones are built from.
+func (s *Simulator) SetChain(chain ChainReader) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.chain = chain
+}
+
+// Span returns the span with the given id. Span 0 covers the first 256 blocks,
+// all later ones SpanLength blocks. The selected producers rotate over the
+// validators by one position per span.
+func (s *Simulator) Span(id uint64) *span.HeimdallSpan {
+	start, end := uint64(0), uint64(firstSpanLength-1)
+	if id > 0 {
+		start = firstSpanLength + (id-1)*s.config.SpanLength
+		end = start + s.config.SpanLength - 1
+	}
+
+	count := len(s.config.Validators)
+	validators := make([]*valset.Validator, count)
+	selected := make([]valset.Validator, s.config.Producers)
+
+	for i, validator := range s.config.Validators {
+		validators[i] = validator.Copy()
+	}
+
+	for i := range selected {
+		selected[i] = *s.config.Validators[(int(id%uint64(count))+i)%count]
+	}
+
+	return &span.HeimdallSpan{
+		Span: span.Span{
+			ID:         id,
+			StartBlock: start,
+			EndBlock:   end,
+		},
+		ValidatorSet:      *valset.NewValidatorSet(validators),
+		SelectedProducers: selected,
+		ChainID:           s.config.ChainID,
+	}
+}
+
+// spanAt returns the id of the span containing the given block.
+func (s *Simulator) spanAt(number uint64) uint64 {
+	if number < firstSpanLength {
+		return 0
+	}
+
+	return (number-firstSpanLength)/s.config.SpanLength + 1
+}
+
+// LatestSpanID returns the id of the latest proposed span, which is the one
+// following the span of the local chain head.
+func (s *Simulator) LatestSpanID() (uint64, error) {
+	head, err := s.head()
+	if err != nil {
+		return 0, err
+	}
+
+	return s.spanAt(head) + 1, nil
+}
+
+// proposer returns the first producer of the span containing the given block.
+func (s *Simulator) proposer(number uint64) common.Address {
+	return s.Span(s.spanAt(number)).SelectedProducers[0].Address
+}
+
+// AddStateSyncEvent queues a state-sync event for the given state receiver
+// contract and returns it. Events are timestamped when they are added.
+func (s *Simulator) AddStateSyncEvent(contract common.Address, data []byte) *clerk.EventRecordWithTime {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	id := uint64(len(s.events) + 1)
+
+	event := &clerk.EventRecordWithTime{
+		EventRecord: clerk.EventRecord{
+			ID:       id,
+			Contract: contract,
+			Data:     common.CopyBytes(data),
+			LogIndex: id,
+			ChainID:  s.config.ChainID,
+		},
+		Time: time.Now().UTC(),
+	}
+	s.events = append(s.events, event)
+
+	return event
+}
+
+// StateSyncEvents returns at most limit events starting at fromID, which were
+// added before the to timestamp.
+func (s *Simulator) StateSyncEvents(fromID uint64, to int64, limit int) []*clerk.EventRecordWithTime {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	events := make([]*clerk.EventRecordWithTime, 0)
+
+	if fromID == 0 {
+		fromID = 1
+	}
+
+	for i := fromID - 1; i < uint64(len(s.events)) && len(events) < limit; i++ {
+		if s.events[i].Time.Unix() >= to {
+			break
+		}
+
+		events = append(events, s.events[i])
+	}
+
+	return events
+}
+
+// head returns the current head number of the local chain.
+func (s *Simulator) head() (uint64, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.chain == nil {
+		return 0, errNoChain
+	}
+
+	return s.chain.CurrentHeader().Number.Uint64(), nil
+}
+
+// header returns the local header with the given number.
+func (s *Simulator) header(number uint64) (*types.Header, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.chain == nil {
+		return nil, errNoChain
+	}
+
+	header := s.chain.GetHeaderByNumber(number)
+	if header == nil {
+		return nil, fmt.Errorf("%w: block %d", errNotFound, number)
+	}
+
+	return header, nil
+}
+
+// count returns the number of intervals of the given length which are completed
+// and confirmed on the local chain.
+func (s *Simulator) count(length uint64) (int64, error) {
+	head, err := s.head()
+	if err != nil {
+		return 0, err
+	}
+
+	if head < confirmations {
+		return 0, nil
+	}
+
+	return int64((head - confirmations + 1) / length), nil
+}
+
+// lastEnd returns the end block of the last completed interval of the given
+// length, or 0 if there is none.
+func (s *Simulator) lastEnd(length uint64) (uint64, error) {
+	count, err := s.count(length)
+	if err != nil || count == 0 {
+		return 0, err
+	}
+
+	return uint64(count)*length - 1, nil
+}
+
+// CheckpointCount returns the number of checkpoints covering the local chain.
+func (s *Simulator) CheckpointCount() (int64, error) {
+	return s.count(s.config.CheckpointLength)
+}
+
+// Checkpoint returns the checkpoint with the given number, starting at 1, or
+// the latest one if the number is -1.
+func (s *Simulator) Checkpoint(number int64) (*checkpoint.Checkpoint, error) {
+	count, err := s.CheckpointCount()
+	if err != nil {
+		return nil, err
+	}
+
+	if number == -1 {
+		number = count
+	}
+
+	if number < 1 || number > count {
+		return nil, fmt.Errorf("%w: checkpoint %d", errNotFound, number)
+	}
+
+	start := uint64(number-1) * s.config.CheckpointLength
+	end := start + s.config.CheckpointLength - 1
+
+	headers := make([]*types.Header, 0, s.config.CheckpointLength)
+
+	for i := start; i <= end; i++ {
+		header, err := s.header(i)
+		if err != nil {
+			return nil, err
+		}
+
+		headers = append(headers, header)
+	}
+
+	root, err := bor.ComputeRootHash(headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &checkpoint.Checkpoint{
+		Proposer:   s.proposer(end),
+		StartBlock: new(big.Int).SetUint64(start),
+		EndBlock:   new(big.Int).SetUint64(end),
+		RootHash:   common.BytesToHash(root),
+		BorChainID: s.config.ChainID,
+		Timestamp:  headers[len(headers)-1].Time,
+	}, nil
+}
+
+// MilestoneCount returns the number of milestones covering the local chain.
+func (s *Simulator) MilestoneCount() (int64, error) {
+	return s.count(s.config.MilestoneLength)
+}
+
+// Milestone returns the latest milestone.
+func (s *Simulator) Milestone() (*milestone.Milestone, error) {
+	count, err := s.MilestoneCount()
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		return nil, fmt.Errorf("%w: no milestone yet", errNotFound)
+	}
+
+	return s.milestone(count)
+}
+
+// milestone returns the milestone with the given number, starting at 1.
+func (s *Simulator) milestone(number int64) (*milestone.Milestone, error) {
+	start := uint64(number-1) * s.config.MilestoneLength
+	end := start + s.config.MilestoneLength - 1
+
+	header, err := s.header(end)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &milestone.Milestone{
+		Proposer:   s.proposer(end),
+		StartBlock: new(big.Int).SetUint64(start),
+		EndBlock:   new(big.Int).SetUint64(end),
+		Hash:       header.Hash(),
+		BorChainID: s.config.ChainID,
+		Timestamp:  header.Time,
+	}
+	m.MilestoneID = MilestoneID(m)
+
+	return m, nil
+}
+
+// MilestoneID returns the id Heimdall assigns to the given milestone.
+func MilestoneID(m *milestone.Milestone) string {
+	return fmt.Sprintf("%d - %s", m.EndBlock.Uint64(), m.Hash.Hex())
+}
+
+// HasMilestoneID reports whether the id belongs to a milestone of the local chain.
+func (s *Simulator) HasMilestoneID(id string) bool {
+	number, hash, found := strings.Cut(id, " - ")
+	if !found {
+		return false
+	}
+
+	end, err := strconv.ParseUint(number, 10, 64)
+	if err != nil || (end+1)%s.config.MilestoneLength != 0 {
+		return false
+	}
+
+	count, err := s.MilestoneCount()
+	if err != nil || int64((end+1)/s.config.MilestoneLength) > count {
+		return false
+	}
+
+	header, err := s.header(end)
+	if err != nil {
+		return false
+	}
+
+	return header.Hash().Hex() == hash
+}
+
+// RejectMilestone marks the milestone with the given id as not acknowledged,
+// making it the latest no-ack milestone.
+func (s *Simulator) RejectMilestone(id string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.noAck[id] = struct{}{}
+	s.lastNoAck = id
+}
+
+// LastNoAckMilestone returns the id of the latest milestone which wasn't
+// acknowledged, or an empty string if there is none.
+func (s *Simulator) LastNoAckMilestone() string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.lastNoAck
+}
+
+// IsNoAckMilestone reports whether the milestone with the given id wasn't acknowledged.
+func (s *Simulator) IsNoAckMilestone(id string) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	_, ok := s.noAck[id]
+
+	return ok
+}
@@ -0,0 +1,246 @@
+package heimdallsim
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// fakeChain is a linear chain of empty headers.
+type fakeChain struct {
+	lock    sync.Mutex
+	headers []*types.Header
+}
+
+func newFakeChain(length int) *fakeChain {
+	chain := &fakeChain{}
+	chain.extend(length)
+
+	return chain
+}
+
+func (c *fakeChain) extend(blocks int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for i := 0; i < blocks; i++ {
+		number := len(c.headers)
+
+		c.headers = append(c.headers, &types.Header{
+			Number: big.NewInt(int64(number)),
+			Time:   uint64(number * 2),
+		})
+	}
+}
+
+func (c *fakeChain) CurrentHeader() *types.Header {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.headers[len(c.headers)-1]
+}
+
+func (c *fakeChain) GetHeaderByNumber(number uint64) *types.Header {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if number >= uint64(len(c.headers)) {
+		return nil
+	}
+
+	return c.headers[number]
+}
+
+func newTestSimulator(t *testing.T) (*Simulator, *heimdall.HeimdallClient) {
+	t.Helper()
+
+	sim, err := New(Config{
+		ChainID: "15001",
+		Validators: []*valset.Validator{
+			valset.NewValidator(common.HexToAddress("0x1"), 10),
+			valset.NewValidator(common.HexToAddress("0x2"), 10),
+			valset.NewValidator(common.HexToAddress("0x3"), 10),
+		},
+		Producers:  2,
+		SpanLength: 64,
+	})
+	require.NoError(t, err)
+
+	server := httptest.NewServer(sim.Handler())
+	client := heimdall.NewHeimdallClient(server.URL)
+
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+
+	return sim, client
+}
+
+func TestSpanRotation(t *testing.T) {
+	t.Parallel()
+
+	_, client := newTestSimulator(t)
+	ctx := context.Background()
+
+	first, err := client.Span(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), first.StartBlock)
+	require.Equal(t, uint64(255), first.EndBlock)
+
+	second, err := client.Span(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(256), second.StartBlock)
+	require.Equal(t, uint64(319), second.EndBlock)
+	require.Len(t, second.ValidatorSet.Validators, 3)
+	require.Len(t, second.SelectedProducers, 2)
+
+	// The producers rotate by one validator per span
+	require.Equal(t, common.HexToAddress("0x1"), first.SelectedProducers[0].Address)
+	require.Equal(t, common.HexToAddress("0x2"), second.SelectedProducers[0].Address)
+	require.Equal(t, common.HexToAddress("0x3"), second.SelectedProducers[1].Address)
+}
+
+func TestStateSyncEventQueue(t *testing.T) {
+	t.Parallel()
+
+	sim, client := newTestSimulator(t)
+	contract := common.HexToAddress("0x1001")
+
+	for i := 0; i < 60; i++ {
+		sim.AddStateSyncEvent(contract, []byte{byte(i)})
+	}
+
+	// The client pages through the events in batches of 50
+	events, err := client.StateSyncEvents(context.Background(), 1, time.Now().Add(time.Minute).Unix())
+	require.NoError(t, err)
+	require.Len(t, events, 60)
+	require.Equal(t, uint64(60), events[59].ID)
+	require.Equal(t, "15001", events[0].ChainID)
+
+	// Events added after the time bound are not returned yet
+	events, err = client.StateSyncEvents(context.Background(), 10, time.Now().Add(-time.Minute).Unix())
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
+
+func TestCheckpointsAndMilestones(t *testing.T) {
+	t.Parallel()
+
+	sim, client := newTestSimulator(t)
+	ctx := context.Background()
+
+	// Without a chain, there's nothing to checkpoint yet
+	_, err := client.FetchMilestone(ctx)
+	require.ErrorIs(t, err, heimdall.ErrServiceUnavailable)
+
+	chain := newFakeChain(600)
+	sim.SetChain(chain)
+
+	count, err := client.FetchCheckpointCount(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	cp, err := client.FetchCheckpoint(ctx, -1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(256), cp.StartBlock.Uint64())
+	require.Equal(t, uint64(511), cp.EndBlock.Uint64())
+	require.NotEqual(t, common.Hash{}, cp.RootHash)
+
+	m, err := client.FetchMilestone(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(575), m.EndBlock.Uint64())
+	require.Equal(t, chain.headers[575].Hash(), m.Hash)
+
+	// Milestone IDs are known, and can be rejected
+	require.NoError(t, client.FetchMilestoneID(ctx, MilestoneID(m)))
+	require.ErrorIs(t, client.FetchMilestoneID(ctx, "1 - 0x00"), heimdall.ErrNotInMilestoneList)
+
+	require.ErrorIs(t, client.FetchNoAckMilestone(ctx, MilestoneID(m)), heimdall.ErrNotInRejectedList)
+
+	sim.RejectMilestone(MilestoneID(m))
+
+	last, err := client.FetchLastNoAckMilestone(ctx)
+	require.NoError(t, err)
+	require.Equal(t, MilestoneID(m), last)
+	require.NoError(t, client.FetchNoAckMilestone(ctx, MilestoneID(m)))
+}
+
+func TestSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	sim, client := newTestSimulator(t)
+
+	chain := newFakeChain(100)
+	sim.SetChain(chain)
+
+	milestones := make(chan *milestone.Milestone)
+	sub := client.SubscribeMilestones(milestones)
+
+	defer sub.Unsubscribe()
+
+	spans := make(chan *span.HeimdallSpan)
+	spanSub := client.SubscribeSpans(0, spans)
+
+	defer spanSub.Unsubscribe()
+
+	receive := func() *milestone.Milestone {
+		select {
+		case m := <-milestones:
+			return m
+		case <-time.After(heimdall.LongPollWait):
+			t.Fatal("no milestone received")
+			return nil
+		}
+	}
+
+	require.Equal(t, uint64(79), receive().EndBlock.Uint64())
+
+	// The simulator supports long-polling, so the next milestone arrives right away
+	chain.extend(20)
+	require.Equal(t, uint64(95), receive().EndBlock.Uint64())
+
+	// Span 0 is sent first, followed by the latest span
+	for id := uint64(0); id <= 1; id++ {
+		select {
+		case s := <-spans:
+			require.Equal(t, id, s.ID)
+		case <-time.After(heimdall.LongPollWait):
+			t.Fatal("no span received")
+		}
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	sim, _ := newTestSimulator(t)
+
+	get := func(path string) int {
+		rec := httptest.NewRecorder()
+		sim.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		return rec.Code
+	}
+
+	// Without a chain, checkpoints can't be served yet
+	require.Equal(t, http.StatusServiceUnavailable, get("/checkpoints/1"))
+
+	sim.SetChain(newFakeChain(600))
+
+	require.Equal(t, http.StatusOK, get("/checkpoints/2"))
+	require.Equal(t, http.StatusNotFound, get("/checkpoints/3"))
+}
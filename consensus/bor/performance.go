@@ -0,0 +1,209 @@
+package bor
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// MaxPerformanceRange is the maximum number of blocks that can be requested for
+// a validator performance report.
+const MaxPerformanceRange = 1 << 15
+
+var (
+	errInvalidPerformanceRange = fmt.Errorf("invalid block range, at most %d blocks starting after genesis are allowed", MaxPerformanceRange)
+	errChainEventsUnavailable  = errors.New("chain events not available")
+)
+
+// chainHeadSubscriber is implemented by chains which publish their head changes,
+// like the full node blockchain.
+type chainHeadSubscriber interface {
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+}
+
+// ValidatorPerformance is the block production record of a validator.
+type ValidatorPerformance struct {
+	Address         common.Address    `json:"address"`
+	ExpectedPrimary uint64            `json:"expectedPrimary"` // Blocks the validator was the in-turn proposer of
+	Produced        uint64            `json:"produced"`        // Blocks the validator signed, in turn or as backup
+	Backup          uint64            `json:"backup"`          // Blocks the validator signed in place of the in-turn proposer
+	Successions     map[uint64]uint64 `json:"successions"`     // Blocks the validator signed per succession number, 0 being in turn
+	MissedSprints   uint64            `json:"missedSprints"`   // Sprints the validator was proposer of without signing any block
+}
+
+// PerformanceReport is the validator performance over a range of blocks.
+type PerformanceReport struct {
+	FromBlock  uint64                  `json:"fromBlock"`
+	ToBlock    uint64                  `json:"toBlock"`
+	Sprints    uint64                  `json:"sprints"` // Sprints overlapping the range
+	Validators []*ValidatorPerformance `json:"validators"`
+}
+
+// performanceTally accumulates the block production of the validators, sprint
+// by sprint.
+type performanceTally struct {
+	report     *PerformanceReport
+	validators map[common.Address]*ValidatorPerformance
+
+	snap     *Snapshot      // Snapshot of the block before the current sprint
+	proposer common.Address // In-turn proposer of the current sprint
+	signed   bool           // Whether the proposer signed a block of the current sprint
+	fakeDiff bool           // Whether the header difficulties aren't checked
+}
+
+func newPerformanceTally(from, to uint64, fakeDiff bool) *performanceTally {
+	return &performanceTally{
+		report:     &PerformanceReport{FromBlock: from, ToBlock: to},
+		validators: make(map[common.Address]*ValidatorPerformance),
+		fakeDiff:   fakeDiff,
+	}
+}
+
+func (t *performanceTally) get(address common.Address) *ValidatorPerformance {
+	if _, ok := t.validators[address]; !ok {
+		t.validators[address] = &ValidatorPerformance{Address: address, Successions: make(map[uint64]uint64)}
+	}
+
+	return t.validators[address]
+}
+
+// startSprint closes the current sprint, if any, and starts a new one with the
+// validator set of the given snapshot.
+func (t *performanceTally) startSprint(snap *Snapshot) {
+	if t.snap != nil && !t.signed {
+		t.get(t.proposer).MissedSprints++
+	}
+
+	for _, validator := range snap.ValidatorSet.Validators {
+		t.get(validator.Address)
+	}
+
+	t.snap = snap
+	t.proposer = snap.ValidatorSet.GetProposer().Address
+	t.signed = false
+	t.report.Sprints++
+}
+
+// addBlock attributes a block of the current sprint to its author, at the
+// succession number of the author, which the header difficulty must match.
+func (t *performanceTally) addBlock(header *types.Header, author common.Address) error {
+	t.get(t.proposer).ExpectedPrimary++
+
+	succession, err := t.snap.GetSignerSuccessionNumber(author)
+	if err != nil {
+		return err
+	}
+
+	if difficulty := Difficulty(t.snap.ValidatorSet, author); !t.fakeDiff && header.Difficulty.Uint64() != difficulty {
+		return &WrongDifficultyError{header.Number.Uint64(), difficulty, header.Difficulty.Uint64(), author.Bytes()}
+	}
+
+	performance := t.get(author)
+	performance.Produced++
+	performance.Successions[uint64(succession)]++
+
+	if succession == 0 {
+		t.signed = true
+	} else {
+		performance.Backup++
+	}
+
+	return nil
+}
+
+// finish closes the current sprint and returns the report, with the validators
+// sorted by address.
+func (t *performanceTally) finish() *PerformanceReport {
+	if t.snap != nil && !t.signed {
+		t.get(t.proposer).MissedSprints++
+	}
+
+	t.report.Validators = make([]*ValidatorPerformance, 0, len(t.validators))
+	for _, performance := range t.validators {
+		t.report.Validators = append(t.report.Validators, performance)
+	}
+
+	sort.Slice(t.report.Validators, func(i, j int) bool {
+		return bytes.Compare(t.report.Validators[i].Address[:], t.report.Validators[j].Address[:]) < 0
+	})
+
+	return t.report
+}
+
+// validatorPerformance replays the block production of the canonical blocks in
+// [from, to]. The in-turn proposer of every sprint is taken from the snapshot of
+// the block before it, and every block is attributed to its author at its
+// succession number.
+func (c *Bor) validatorPerformance(chain consensus.ChainHeaderReader, from, to uint64) (*PerformanceReport, error) {
+	if from == 0 || from > to || to-from >= MaxPerformanceRange {
+		return nil, errInvalidPerformanceRange
+	}
+
+	tally := newPerformanceTally(from, to, c.fakeDiff)
+
+	for number := from; number <= to; number++ {
+		header := chain.GetHeaderByNumber(number)
+		if header == nil {
+			return nil, errUnknownBlock
+		}
+
+		if number == from || c.config.IsSprintStart(number) {
+			parent := chain.GetHeader(header.ParentHash, number-1)
+			if parent == nil {
+				return nil, consensus.ErrUnknownAncestor
+			}
+
+			snap, err := c.snapshot(chain, parent.Number.Uint64(), parent.Hash(), nil)
+			if err != nil {
+				return nil, err
+			}
+
+			tally.startSprint(snap)
+		}
+
+		author, err := c.Author(header)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := tally.addBlock(header, author); err != nil {
+			return nil, err
+		}
+	}
+
+	return tally.finish(), nil
+}
+
+// sprintStart returns the first block of the sprint containing number.
+func (c *Bor) sprintStart(number uint64) uint64 {
+	for number > 0 && !c.config.IsSprintStart(number) {
+		number--
+	}
+
+	return number
+}
+
+// endedSprints returns the ranges of the sprints starting from next which ended
+// by the block number, and the start of the first sprint still in progress.
+func (c *Bor) endedSprints(next, number uint64) ([][2]uint64, uint64) {
+	var ranges [][2]uint64
+
+	for next < number {
+		end := next + c.config.CalculateSprint(next) - 1
+		if end > number {
+			break
+		}
+
+		ranges = append(ranges, [2]uint64{next, end})
+		next = end + 1
+	}
+
+	return ranges, next
+}
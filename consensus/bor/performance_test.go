@@ -0,0 +1,190 @@
+package bor
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// performanceSnapshot returns a snapshot of three validators, whose proposer is
+// rotated by the given number of sprints.
+func performanceSnapshot(rotations int) *Snapshot {
+	validators := []*valset.Validator{
+		valset.NewValidator(common.Address{1}, 10),
+		valset.NewValidator(common.Address{2}, 10),
+		valset.NewValidator(common.Address{3}, 10),
+	}
+
+	validatorSet := valset.NewValidatorSet(validators)
+	if rotations > 0 {
+		validatorSet.IncrementProposerPriority(rotations)
+	}
+
+	return &Snapshot{ValidatorSet: validatorSet}
+}
+
+// signerAt returns the validator signing at the given succession number.
+func signerAt(snap *Snapshot, succession int) common.Address {
+	proposer, _ := snap.ValidatorSet.GetByAddress(snap.ValidatorSet.GetProposer().Address)
+	validators := snap.ValidatorSet.Validators
+
+	return validators[(proposer+succession)%len(validators)].Address
+}
+
+// Tests that the blocks are attributed to their authors at their succession
+// number, and that the sprints without a block of their proposer are missed.
+func TestValidatorPerformanceTally(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		sprints     [][]int // Succession number of the author of every block, per sprint
+		primary     []uint64
+		produced    []uint64
+		backup      []uint64
+		successions []map[uint64]uint64
+		missed      []uint64
+	}{
+		{
+			name:        "in turn",
+			sprints:     [][]int{{0, 0, 0, 0}},
+			primary:     []uint64{4, 0, 0},
+			produced:    []uint64{4, 0, 0},
+			backup:      []uint64{0, 0, 0},
+			successions: []map[uint64]uint64{{0: 4}, {}, {}},
+			missed:      []uint64{0, 0, 0},
+		},
+		{
+			name:        "backup",
+			sprints:     [][]int{{0, 1, 2, 2}},
+			primary:     []uint64{4, 0, 0},
+			produced:    []uint64{1, 1, 2},
+			backup:      []uint64{0, 1, 2},
+			successions: []map[uint64]uint64{{0: 1}, {1: 1}, {2: 2}},
+			missed:      []uint64{0, 0, 0},
+		},
+		{
+			name:        "missed sprint",
+			sprints:     [][]int{{1, 1, 2, 1}},
+			primary:     []uint64{4, 0, 0},
+			produced:    []uint64{0, 3, 1},
+			backup:      []uint64{0, 3, 1},
+			successions: []map[uint64]uint64{{}, {1: 3}, {2: 1}},
+			missed:      []uint64{1, 0, 0},
+		},
+		{
+			name:        "missed second sprint",
+			sprints:     [][]int{{0, 0}, {2, 1}},
+			primary:     []uint64{2, 2, 0},
+			produced:    []uint64{3, 0, 1},
+			backup:      []uint64{1, 0, 1},
+			successions: []map[uint64]uint64{{0: 2, 2: 1}, {}, {1: 1}},
+			missed:      []uint64{0, 1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				tally  = newPerformanceTally(1, 100, false)
+				first  = performanceSnapshot(0)
+				number int64
+			)
+
+			// The validators indexed by the test are the proposer of the first
+			// sprint and its successors
+			indexes := map[common.Address]int{}
+			for i := 0; i < 3; i++ {
+				indexes[signerAt(first, i)] = i
+			}
+
+			for i, sprint := range tt.sprints {
+				snap := performanceSnapshot(i)
+				tally.startSprint(snap)
+
+				for _, succession := range sprint {
+					number++
+
+					author := signerAt(snap, succession)
+					header := &types.Header{
+						Number:     big.NewInt(number),
+						Difficulty: new(big.Int).SetUint64(Difficulty(snap.ValidatorSet, author)),
+					}
+
+					require.NoError(t, tally.addBlock(header, author))
+				}
+			}
+
+			report := tally.finish()
+			require.Equal(t, uint64(len(tt.sprints)), report.Sprints)
+			require.Len(t, report.Validators, 3)
+
+			for _, performance := range report.Validators {
+				i := indexes[performance.Address]
+
+				require.Equal(t, tt.primary[i], performance.ExpectedPrimary, "expected primary of validator %d", i)
+				require.Equal(t, tt.produced[i], performance.Produced, "produced of validator %d", i)
+				require.Equal(t, tt.backup[i], performance.Backup, "backup of validator %d", i)
+				require.Equal(t, tt.successions[i], performance.Successions, "successions of validator %d", i)
+				require.Equal(t, tt.missed[i], performance.MissedSprints, "missed sprints of validator %d", i)
+			}
+		})
+	}
+}
+
+// Tests that a block whose difficulty doesn't match the succession number of its
+// author is rejected.
+func TestValidatorPerformanceWrongDifficulty(t *testing.T) {
+	t.Parallel()
+
+	snap := performanceSnapshot(0)
+	header := &types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(3)}
+
+	tally := newPerformanceTally(1, 1, false)
+	tally.startSprint(snap)
+
+	var wrongDifficulty *WrongDifficultyError
+
+	err := tally.addBlock(header, signerAt(snap, 1))
+	require.True(t, errors.As(err, &wrongDifficulty), "error mismatch: have %v", err)
+
+	tally = newPerformanceTally(1, 1, true)
+	tally.startSprint(snap)
+	require.NoError(t, tally.addBlock(header, signerAt(snap, 1)))
+}
+
+// Tests that the sprints are reported once their last block is imported.
+func TestEndedSprints(t *testing.T) {
+	t.Parallel()
+
+	c := &Bor{config: &params.BorConfig{Sprint: map[string]uint64{"0": 4}}}
+
+	tests := []struct {
+		next, number uint64
+		ranges       [][2]uint64
+		after        uint64
+	}{
+		{0, 2, nil, 0},
+		{0, 3, [][2]uint64{{0, 3}}, 4},
+		{4, 6, nil, 4},
+		{4, 12, [][2]uint64{{4, 7}, {8, 11}}, 12},
+	}
+
+	for _, tt := range tests {
+		ranges, after := c.endedSprints(tt.next, tt.number)
+
+		require.Equal(t, tt.ranges, ranges, "sprints from %d at %d", tt.next, tt.number)
+		require.Equal(t, tt.after, after, "next sprint from %d at %d", tt.next, tt.number)
+	}
+}
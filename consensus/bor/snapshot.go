@@ -1,8 +1,6 @@
 package bor
 
 import (
-	"encoding/json"
-
 	"github.com/ethereum/go-ethereum/consensus/bor/valset"
 
 	lru "github.com/hashicorp/golang-lru"
@@ -46,40 +44,29 @@ func newSnapshot(
 	return snap
 }
 
-// loadSnapshot loads an existing snapshot from the database.
-func loadSnapshot(config *params.BorConfig, sigcache *lru.ARCCache, db ethdb.Database, hash common.Hash) (*Snapshot, error) {
-	blob, err := db.Get(append([]byte("bor-"), hash[:]...))
-	if err != nil {
-		return nil, err
+// loadSnapshot loads an existing snapshot from the database, falling back to
+// the JSON snapshots written by older versions.
+func loadSnapshot(config *params.BorConfig, sigcache *lru.ARCCache, db ethdb.Database, number uint64, hash common.Hash, legacy bool) (*Snapshot, error) {
+	blob, err := db.Get(snapshotKey(number, hash))
+	if err != nil && legacy {
+		blob, err = db.Get(legacySnapshotKey(hash))
 	}
 
-	snap := new(Snapshot)
-
-	if err := json.Unmarshal(blob, snap); err != nil {
-		return nil, err
-	}
-
-	snap.ValidatorSet.UpdateValidatorMap()
-
-	snap.config = config
-	snap.sigcache = sigcache
-
-	// update total voting power
-	if err := snap.ValidatorSet.UpdateTotalVotingPower(); err != nil {
+	if err != nil {
 		return nil, err
 	}
 
-	return snap, nil
+	return decodeSnapshot(config, sigcache, blob)
 }
 
 // store inserts the snapshot into the database.
 func (s *Snapshot) store(db ethdb.Database) error {
-	blob, err := json.Marshal(s)
+	blob, err := encodeSnapshot(s)
 	if err != nil {
 		return err
 	}
 
-	return db.Put(append([]byte("bor-"), s.Hash[:]...), blob)
+	return db.Put(snapshotKey(s.Number, s.Hash), blob)
 }
 
 // copy creates a deep copy of the snapshot, though not the individual votes.
@@ -0,0 +1,486 @@
+package bor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// snapshotVersion is the version of the binary snapshot encoding, stored as the
+// first byte of every snapshot blob.
+const snapshotVersion byte = 1
+
+var (
+	// snapshotPrefix + num (uint64 big endian) + hash -> versioned snapshot
+	snapshotPrefix = []byte("bor-snapshot-")
+
+	// legacySnapshotPrefix + hash -> JSON snapshot, written by older versions
+	legacySnapshotPrefix = []byte("bor-")
+
+	errUnknownSnapshotVersion = errors.New("unknown snapshot version")
+)
+
+// SnapshotConfig is the on-disk policy of the consensus snapshots.
+type SnapshotConfig struct {
+	// Interval is the number of blocks between two snapshots stored on disk,
+	// e.g. the sprint or span length
+	Interval uint64
+
+	// Retention is the number of blocks below the last finalized milestone for
+	// which stored snapshots are kept, 0 keeps all of them
+	Retention uint64
+}
+
+// DefaultSnapshotConfig stores a snapshot every 1024 blocks and never prunes.
+var DefaultSnapshotConfig = SnapshotConfig{
+	Interval: checkpointInterval,
+}
+
+// storedSnapshot is the binary encoding of a snapshot.
+type storedSnapshot struct {
+	Number     uint64
+	Hash       common.Hash
+	Validators []storedValidator
+	Proposer   *storedValidator `rlp:"nil"`
+	Recents    []storedRecent
+}
+
+// storedValidator is the binary encoding of a validator, RLP doesn't support
+// signed integers.
+type storedValidator struct {
+	ID               uint64
+	Address          common.Address
+	VotingPower      uint64
+	ProposerPriority uint64
+}
+
+func newStoredValidator(validator *valset.Validator) storedValidator {
+	return storedValidator{
+		ID:               validator.ID,
+		Address:          validator.Address,
+		VotingPower:      uint64(validator.VotingPower),
+		ProposerPriority: uint64(validator.ProposerPriority),
+	}
+}
+
+func (v *storedValidator) validator() *valset.Validator {
+	return &valset.Validator{
+		ID:               v.ID,
+		Address:          v.Address,
+		VotingPower:      int64(v.VotingPower),
+		ProposerPriority: int64(v.ProposerPriority),
+	}
+}
+
+type storedRecent struct {
+	Number uint64
+	Signer common.Address
+}
+
+// snapshotKey = snapshotPrefix + num (uint64 big endian) + hash
+func snapshotKey(number uint64, hash common.Hash) []byte {
+	key := make([]byte, len(snapshotPrefix)+8+common.HashLength)
+	copy(key, snapshotPrefix)
+	binary.BigEndian.PutUint64(key[len(snapshotPrefix):], number)
+	copy(key[len(snapshotPrefix)+8:], hash[:])
+
+	return key
+}
+
+// legacySnapshotKey = legacySnapshotPrefix + hash
+func legacySnapshotKey(hash common.Hash) []byte {
+	return append(append([]byte{}, legacySnapshotPrefix...), hash[:]...)
+}
+
+// isLegacySnapshotKey reports whether key is the key of a JSON snapshot.
+func isLegacySnapshotKey(key []byte) bool {
+	return len(key) == len(legacySnapshotPrefix)+common.HashLength && bytes.HasPrefix(key, legacySnapshotPrefix)
+}
+
+// encodeSnapshot encodes the snapshot in the versioned binary format.
+func encodeSnapshot(s *Snapshot) ([]byte, error) {
+	stored := storedSnapshot{
+		Number:     s.Number,
+		Hash:       s.Hash,
+		Validators: make([]storedValidator, 0, len(s.ValidatorSet.Validators)),
+		Recents:    make([]storedRecent, 0, len(s.Recents)),
+	}
+
+	for _, validator := range s.ValidatorSet.Validators {
+		stored.Validators = append(stored.Validators, newStoredValidator(validator))
+	}
+
+	if s.ValidatorSet.Proposer != nil {
+		proposer := newStoredValidator(s.ValidatorSet.Proposer)
+		stored.Proposer = &proposer
+	}
+
+	for number, signer := range s.Recents {
+		stored.Recents = append(stored.Recents, storedRecent{Number: number, Signer: signer})
+	}
+
+	// Recents are stored in ascending order to keep the encoding deterministic
+	sort.Slice(stored.Recents, func(i, j int) bool {
+		return stored.Recents[i].Number < stored.Recents[j].Number
+	})
+
+	blob, err := rlp.EncodeToBytes(&stored)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{snapshotVersion}, blob...), nil
+}
+
+// decodeSnapshot decodes a snapshot in the binary format, or in the JSON format
+// of older versions.
+func decodeSnapshot(config *params.BorConfig, sigcache *lru.ARCCache, blob []byte) (*Snapshot, error) {
+	if len(blob) == 0 {
+		return nil, errUnknownSnapshotVersion
+	}
+
+	snap := new(Snapshot)
+
+	switch blob[0] {
+	case snapshotVersion:
+		var stored storedSnapshot
+		if err := rlp.DecodeBytes(blob[1:], &stored); err != nil {
+			return nil, err
+		}
+
+		snap.Number = stored.Number
+		snap.Hash = stored.Hash
+		snap.ValidatorSet = &valset.ValidatorSet{Validators: make([]*valset.Validator, 0, len(stored.Validators))}
+		snap.Recents = make(map[uint64]common.Address, len(stored.Recents))
+
+		for _, validator := range stored.Validators {
+			snap.ValidatorSet.Validators = append(snap.ValidatorSet.Validators, validator.validator())
+		}
+
+		if stored.Proposer != nil {
+			snap.ValidatorSet.Proposer = stored.Proposer.validator()
+		}
+
+		for _, recent := range stored.Recents {
+			snap.Recents[recent.Number] = recent.Signer
+		}
+
+	case '{':
+		if err := json.Unmarshal(blob, snap); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: %d", errUnknownSnapshotVersion, blob[0])
+	}
+
+	snap.ValidatorSet.UpdateValidatorMap()
+
+	snap.config = config
+	snap.sigcache = sigcache
+
+	// update total voting power
+	if err := snap.ValidatorSet.UpdateTotalVotingPower(); err != nil {
+		return nil, err
+	}
+
+	return snap, nil
+}
+
+// SnapshotInfo describes a snapshot stored on disk.
+type SnapshotInfo struct {
+	Number     uint64      `json:"number"`
+	Hash       common.Hash `json:"hash"`
+	Legacy     bool        `json:"legacy"` // Stored in the JSON format of older versions
+	Size       int         `json:"size"`
+	Validators int         `json:"validators"`
+}
+
+// IterateSnapshots calls fn with every snapshot stored on disk, the binary ones
+// in ascending block order followed by the legacy ones, until fn returns false.
+func IterateSnapshots(db ethdb.Database, config *params.BorConfig, fn func(info SnapshotInfo, snap *Snapshot) bool) error {
+	it := db.NewIterator(snapshotPrefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		if len(it.Key()) != len(snapshotPrefix)+8+common.HashLength {
+			continue
+		}
+
+		if !iterateSnapshot(config, it.Value(), false, fn) {
+			return it.Error()
+		}
+	}
+
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	legacy := db.NewIterator(legacySnapshotPrefix, nil)
+	defer legacy.Release()
+
+	for legacy.Next() {
+		if !isLegacySnapshotKey(legacy.Key()) {
+			continue
+		}
+
+		if !iterateSnapshot(config, legacy.Value(), true, fn) {
+			return legacy.Error()
+		}
+	}
+
+	return legacy.Error()
+}
+
+func iterateSnapshot(config *params.BorConfig, blob []byte, legacy bool, fn func(info SnapshotInfo, snap *Snapshot) bool) bool {
+	snap, err := decodeSnapshot(config, nil, blob)
+	if err != nil {
+		log.Warn("Skipping undecodable bor snapshot", "err", err)
+		return true
+	}
+
+	return fn(SnapshotInfo{
+		Number:     snap.Number,
+		Hash:       snap.Hash,
+		Legacy:     legacy,
+		Size:       len(blob),
+		Validators: len(snap.ValidatorSet.Validators),
+	}, snap)
+}
+
+// PruneSnapshots deletes the snapshots stored on disk for blocks below the given
+// number. The genesis snapshot and the newest canonical snapshot below the number
+// are kept, so snapshots above it can still be rebuilt. Legacy snapshots below the
+// number are deleted as well. It returns the number of deleted snapshots.
+func PruneSnapshots(db ethdb.Database, below uint64) (int, error) {
+	var (
+		keys   [][]byte
+		newest []byte
+	)
+
+	it := db.NewIterator(snapshotPrefix, nil)
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(snapshotPrefix)+8+common.HashLength {
+			continue
+		}
+
+		number := binary.BigEndian.Uint64(key[len(snapshotPrefix):])
+		if number >= below {
+			break
+		}
+
+		if number == 0 {
+			continue
+		}
+
+		// Snapshots of side chains can't serve as the base of a rebuild
+		if rawdb.ReadCanonicalHash(db, number) != common.BytesToHash(key[len(snapshotPrefix)+8:]) {
+			keys = append(keys, common.CopyBytes(key))
+			continue
+		}
+
+		if newest != nil {
+			keys = append(keys, newest)
+		}
+
+		newest = common.CopyBytes(key)
+	}
+
+	err := it.Error()
+
+	it.Release()
+
+	if err != nil {
+		return 0, err
+	}
+
+	legacy := db.NewIterator(legacySnapshotPrefix, nil)
+
+	for legacy.Next() {
+		if !isLegacySnapshotKey(legacy.Key()) {
+			continue
+		}
+
+		var header struct {
+			Number uint64 `json:"number"`
+		}
+
+		if err := json.Unmarshal(legacy.Value(), &header); err == nil && header.Number > 0 && header.Number < below {
+			keys = append(keys, common.CopyBytes(legacy.Key()))
+		}
+	}
+
+	err = legacy.Error()
+
+	legacy.Release()
+
+	if err != nil {
+		return 0, err
+	}
+
+	batch := db.NewBatch()
+
+	for _, key := range keys {
+		if err := batch.Delete(key); err != nil {
+			return 0, err
+		}
+
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return 0, err
+			}
+
+			batch.Reset()
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+
+	return len(keys), nil
+}
+
+// pruneSnapshots prunes the snapshots below the retention window of the last
+// finalized milestone, at most once per snapshot interval.
+func (c *Bor) pruneSnapshots() {
+	if c.snapshotConfig.Retention == 0 {
+		return
+	}
+
+	finalized, _, err := rawdb.ReadFinality[*rawdb.Milestone](c.db)
+	if err != nil || finalized <= c.snapshotConfig.Retention {
+		return
+	}
+
+	below := finalized - c.snapshotConfig.Retention
+	if below < c.snapshotsPruned.Load()+c.snapshotConfig.Interval {
+		return
+	}
+
+	if !c.snapshotsPruning.CompareAndSwap(false, true) {
+		return
+	}
+
+	go func() {
+		defer c.snapshotsPruning.Store(false)
+
+		deleted, err := PruneSnapshots(c.db, below)
+		if err != nil {
+			log.Warn("Failed to prune bor snapshots", "below", below, "err", err)
+			return
+		}
+
+		c.snapshotsPruned.Store(below)
+
+		log.Debug("Pruned bor snapshots", "below", below, "finalized", finalized, "deleted", deleted)
+	}()
+}
+
+// RebuildSnapshots regenerates the snapshots of the canonical chain above the
+// newest canonical snapshot stored at or below from, storing one every interval
+// blocks in the binary format. Snapshots stored above the base are deleted first.
+// It returns the number of stored snapshots.
+func RebuildSnapshots(db ethdb.Database, config *params.BorConfig, from uint64, interval uint64) (int, error) {
+	if interval == 0 {
+		interval = DefaultSnapshotConfig.Interval
+	}
+
+	sigcache, err := lru.NewARC(inmemorySignatures)
+	if err != nil {
+		return 0, err
+	}
+
+	// Find the snapshot to rebuild from, and the ones to replace
+	var (
+		base  *Snapshot
+		stale []SnapshotInfo
+	)
+
+	err = IterateSnapshots(db, config, func(info SnapshotInfo, snap *Snapshot) bool {
+		if info.Number > from {
+			stale = append(stale, info)
+		} else if rawdb.ReadCanonicalHash(db, info.Number) == info.Hash && (base == nil || info.Number > base.Number) {
+			base = snap
+		}
+
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if base == nil {
+		return 0, fmt.Errorf("no canonical snapshot stored at or below block %d", from)
+	}
+
+	base.config = config
+	base.sigcache = sigcache
+
+	for _, info := range stale {
+		key := snapshotKey(info.Number, info.Hash)
+		if info.Legacy {
+			key = legacySnapshotKey(info.Hash)
+		}
+
+		if err := db.Delete(key); err != nil {
+			return 0, err
+		}
+	}
+
+	head := rawdb.ReadHeaderNumber(db, rawdb.ReadHeadHeaderHash(db))
+	if head == nil {
+		return 0, errUnknownBlock
+	}
+
+	var (
+		snap    = base
+		headers = make([]*types.Header, 0, interval)
+		stored  int
+	)
+
+	for number := base.Number + 1; number <= *head; number++ {
+		header := rawdb.ReadHeader(db, rawdb.ReadCanonicalHash(db, number), number)
+		if header == nil {
+			return stored, fmt.Errorf("missing canonical header %d", number)
+		}
+
+		headers = append(headers, header)
+
+		if number%interval != 0 && number != *head {
+			continue
+		}
+
+		if snap, err = snap.apply(headers); err != nil {
+			return stored, err
+		}
+
+		headers = headers[:0]
+
+		if number%interval == 0 {
+			if err := snap.store(db); err != nil {
+				return stored, err
+			}
+
+			stored++
+		}
+	}
+
+	return stored, nil
+}
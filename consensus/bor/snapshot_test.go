@@ -1,6 +1,7 @@
 package bor
 
 import (
+	"encoding/json"
 	"math/big"
 	"sort"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	unique "github.com/ethereum/go-ethereum/common/set"
 	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 )
 
 const (
@@ -223,3 +225,72 @@ func toAddresses(vals []*valset.Validator) []common.Address {
 
 	return addrs
 }
+
+func TestSnapshotEncoding(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+
+	snap := newSnapshot(nil, nil, 2048, common.HexToHash("0x01"), buildRandomValidatorSet(numVals))
+	snap.ValidatorSet.IncrementProposerPriority(3)
+	snap.Recents[2047] = snap.ValidatorSet.Validators[1].Address
+	snap.Recents[2048] = snap.ValidatorSet.Validators[2].Address
+
+	require.NoError(t, snap.store(db))
+
+	loaded, err := loadSnapshot(nil, nil, db, snap.Number, snap.Hash, false)
+	require.NoError(t, err)
+	require.Equal(t, snap.Number, loaded.Number)
+	require.Equal(t, snap.Recents, loaded.Recents)
+	require.Equal(t, snap.ValidatorSet.Validators, loaded.ValidatorSet.Validators)
+	require.Equal(t, snap.ValidatorSet.GetProposer(), loaded.ValidatorSet.GetProposer())
+
+	// Snapshots written by older versions are still readable
+	legacy := newSnapshot(nil, nil, 1024, common.HexToHash("0x02"), buildRandomValidatorSet(numVals))
+
+	blob, err := json.Marshal(legacy)
+	require.NoError(t, err)
+	require.NoError(t, db.Put(legacySnapshotKey(legacy.Hash), blob))
+
+	_, err = loadSnapshot(nil, nil, db, legacy.Number, legacy.Hash, false)
+	require.Error(t, err)
+
+	loaded, err = loadSnapshot(nil, nil, db, legacy.Number, legacy.Hash, true)
+	require.NoError(t, err)
+	require.Equal(t, legacy.ValidatorSet.Validators, loaded.ValidatorSet.Validators)
+}
+
+func TestPruneSnapshots(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+	validators := buildRandomValidatorSet(4)
+
+	for _, number := range []uint64{0, 1024, 2048, 3072, 4096} {
+		hash := common.BigToHash(new(big.Int).SetUint64(number + 1))
+
+		rawdb.WriteCanonicalHash(db, hash, number)
+		require.NoError(t, newSnapshot(nil, nil, number, hash, validators).store(db))
+	}
+
+	// A side chain snapshot is never kept as the newest one below the pruning point
+	require.NoError(t, newSnapshot(nil, nil, 3200, common.HexToHash("0x0c80"), validators).store(db))
+
+	legacy, err := json.Marshal(newSnapshot(nil, nil, 512, common.HexToHash("0x0200"), validators))
+	require.NoError(t, err)
+	require.NoError(t, db.Put(legacySnapshotKey(common.HexToHash("0x0200")), legacy))
+
+	deleted, err := PruneSnapshots(db, 3500)
+	require.NoError(t, err)
+	require.Equal(t, 4, deleted)
+
+	var numbers []uint64
+
+	require.NoError(t, IterateSnapshots(db, nil, func(info SnapshotInfo, _ *Snapshot) bool {
+		numbers = append(numbers, info.Number)
+		return true
+	}))
+
+	// The genesis snapshot and the newest one below the pruning point are kept
+	require.Equal(t, []uint64{0, 3072, 4096}, numbers)
+}
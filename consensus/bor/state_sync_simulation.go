@@ -0,0 +1,223 @@
+package bor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/statefull"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// stateReceiverGas is the gas the state receiver contract forwards to the
+	// onStateReceive call of the receiver contract.
+	stateReceiverGas = 5000000
+
+	// stateReceiveABI is the ABI of onStateReceive, implemented by the receiver
+	// contracts of the state-sync events.
+	stateReceiveABI = `[{"inputs":[{"internalType":"uint256","name":"stateId","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"}],"name":"onStateReceive","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+)
+
+var (
+	errStateUnavailable = errors.New("state not available")
+
+	// stateCommittedTopic is the topic of StateCommitted(uint256 stateId, bool success),
+	// emitted by the state receiver for every event sent to a contract.
+	stateCommittedTopic = crypto.Keccak256Hash([]byte("StateCommitted(uint256,bool)"))
+
+	stateReceiverABI abi.ABI
+)
+
+func init() {
+	var err error
+
+	stateReceiverABI, err = abi.JSON(strings.NewReader(stateReceiveABI))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// stateReader is implemented by chains which give access to the state, like
+// the full node blockchain.
+type stateReader interface {
+	StateAt(root common.Hash) (*state.StateDB, error)
+}
+
+// StateSyncSimulation is the outcome of committing the pending state-syncs on
+// top of the latest block.
+type StateSyncSimulation struct {
+	BlockNumber uint64                `json:"blockNumber"` // Number of the simulated block
+	LastStateID uint64                `json:"lastStateId"` // Last state id committed on chain
+	Events      []*StateSyncExecution `json:"events"`
+	Error       string                `json:"error,omitempty"` // Reason the events after the simulated ones can't be committed
+}
+
+// StateSyncExecution is the outcome of committing a single state-sync event.
+type StateSyncExecution struct {
+	ID           uint64         `json:"id"`
+	Contract     common.Address `json:"contract"`
+	TxHash       common.Hash    `json:"txHash"`
+	Time         time.Time      `json:"time"`
+	GasUsed      uint64         `json:"gasUsed"`
+	Success      bool           `json:"success"`
+	Logs         []*types.Log   `json:"logs"`
+	Error        string         `json:"error,omitempty"`
+	RevertReason string         `json:"revertReason,omitempty"`
+}
+
+// SimulateStateSyncs fetches the state-sync events heimdall has queued after the
+// last state id committed at head and commits them, in order, on a copy of the
+// head state, without producing a block.
+func (c *Bor) SimulateStateSyncs(ctx context.Context, chain consensus.ChainHeaderReader, head *types.Header) (*StateSyncSimulation, error) {
+	reader, ok := chain.(stateReader)
+	if !ok {
+		return nil, errStateUnavailable
+	}
+
+	statedb, err := reader.StateAt(head.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	lastStateIDBig, err := c.GenesisContractsClient.LastStateId(statedb.Copy(), head.Number.Uint64(), head.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	lastStateID := lastStateIDBig.Uint64()
+
+	// Commit the events in the block following head
+	header := types.CopyHeader(head)
+	header.ParentHash = head.Hash()
+	header.Number = new(big.Int).Add(head.Number, common.Big1)
+	header.Time = head.Time + c.config.CalculatePeriod(header.Number.Uint64())
+
+	// Only the events recorded early enough to be committed in that block are
+	// simulated, based on its timestamp like CommitStates
+	to := c.stateSyncTo(chain, header)
+
+	eventRecords, err := c.HeimdallClient.StateSyncEvents(ctx, lastStateID+1, to.Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	cx := statefull.ChainContext{Chain: chain, Bor: c}
+	stateReceiver := common.HexToAddress(c.config.StateReceiverContract)
+	chainID := c.chainConfig.ChainID.String()
+
+	simulation := &StateSyncSimulation{
+		BlockNumber: header.Number.Uint64(),
+		LastStateID: lastStateID,
+		Events:      make([]*StateSyncExecution, 0, len(eventRecords)),
+	}
+
+	for i, eventRecord := range eventRecords {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if eventRecord.ID <= lastStateID {
+			continue
+		}
+
+		// CommitStates stops at the first invalid event, and so does the simulation
+		if err := validateEventRecord(eventRecord, header.Number.Uint64(), to, lastStateID, chainID); err != nil {
+			simulation.Error = err.Error()
+			break
+		}
+
+		execution := &StateSyncExecution{
+			ID:       eventRecord.ID,
+			Contract: eventRecord.Contract,
+			TxHash:   eventRecord.TxHash,
+			Time:     eventRecord.Time,
+		}
+
+		simulation.Events = append(simulation.Events, execution)
+
+		// The events are committed as system calls, key their logs by state id
+		thash := common.BigToHash(new(big.Int).SetUint64(eventRecord.ID))
+		before := statedb.Copy()
+		statedb.SetTxContext(thash, i)
+
+		execution.GasUsed, err = c.GenesisContractsClient.CommitState(eventRecord, statedb, header, cx)
+		if err != nil {
+			return nil, err
+		}
+
+		execution.Logs = statedb.GetLogs(thash, header.Number.Uint64(), common.Hash{})
+
+		var committed bool
+
+		committed, execution.Success = stateCommitted(execution.Logs, stateReceiver, eventRecord.ID)
+
+		switch {
+		case !committed:
+			execution.Error = "receiver is not a contract"
+		case !execution.Success:
+			// The state receiver swallows the failure, replay the receiver call to explain it
+			execution.Error, execution.RevertReason = c.replayStateReceive(before, header, cx, eventRecord)
+		}
+
+		lastStateID = eventRecord.ID
+	}
+
+	return simulation, nil
+}
+
+// stateCommitted looks up the StateCommitted log of the state receiver for the
+// given state id, and returns whether it was found and the success it reports.
+func stateCommitted(logs []*types.Log, stateReceiver common.Address, id uint64) (bool, bool) {
+	topic := common.BigToHash(new(big.Int).SetUint64(id))
+
+	for _, l := range logs {
+		if l.Address == stateReceiver && len(l.Topics) > 1 && l.Topics[0] == stateCommittedTopic && l.Topics[1] == topic {
+			return true, new(big.Int).SetBytes(l.Data).Sign() != 0
+		}
+	}
+
+	return false, false
+}
+
+// replayStateReceive calls onStateReceive of the receiver like the state receiver
+// contract does, and returns the error and revert reason of the call.
+func (c *Bor) replayStateReceive(statedb *state.StateDB, header *types.Header, cx core.ChainContext, eventRecord *clerk.EventRecordWithTime) (string, string) {
+	input, err := stateReceiverABI.Pack("onStateReceive", new(big.Int).SetUint64(eventRecord.ID), []byte(eventRecord.Data))
+	if err != nil {
+		return err.Error(), ""
+	}
+
+	blockContext := core.NewEVMBlockContext(header, cx, &header.Coinbase)
+	vmenv := vm.NewEVM(blockContext, vm.TxContext{}, statedb, c.chainConfig, vm.Config{})
+
+	ret, _, err := vmenv.Call(vm.AccountRef(common.HexToAddress(c.config.StateReceiverContract)), eventRecord.Contract, input, stateReceiverGas, common.Big0, nil)
+	if err == nil {
+		// The replay can differ from the commit, e.g. if the receiver depends on
+		// the state receiver's storage
+		return "execution failed", ""
+	}
+
+	if errors.Is(err, vm.ErrExecutionReverted) {
+		if reason, unpackErr := abi.UnpackRevert(ret); unpackErr == nil {
+			return err.Error(), reason
+		}
+
+		if len(ret) > 0 {
+			return err.Error(), fmt.Sprintf("0x%x", ret)
+		}
+	}
+
+	return err.Error(), ""
+}
@@ -0,0 +1,187 @@
+package bor
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/statefull"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/tests/bor/mocks"
+)
+
+// simulationChain is a chain header reader giving access to a single state.
+type simulationChain struct {
+	consensus.ChainHeaderReader
+	statedb *state.StateDB
+}
+
+func (c *simulationChain) StateAt(root common.Hash) (*state.StateDB, error) {
+	return c.statedb, nil
+}
+
+// stateCommittedLog returns the StateCommitted log of the state receiver.
+func stateCommittedLog(stateReceiver common.Address, id uint64, success bool) *types.Log {
+	data := common.LeftPadBytes(nil, 32)
+	if success {
+		data = common.LeftPadBytes([]byte{1}, 32)
+	}
+
+	return &types.Log{
+		Address: stateReceiver,
+		Topics:  []common.Hash{stateCommittedTopic, common.BigToHash(new(big.Int).SetUint64(id))},
+		Data:    data,
+	}
+}
+
+// revertingCode returns a contract code reverting with the given reason.
+func revertingCode(reason string) []byte {
+	data := append(crypto.Keccak256([]byte("Error(string)"))[:4], common.LeftPadBytes([]byte{0x20}, 32)...)
+	data = append(data, common.LeftPadBytes([]byte{byte(len(reason))}, 32)...)
+	data = append(data, common.RightPadBytes([]byte(reason), 32)...)
+
+	// CODECOPY the data after the 14 bytes of code into memory and revert with it
+	code := []byte{0x61, 0, byte(len(data)), 0x60, 0x0e, 0x60, 0x00, 0x39, 0x61, 0, byte(len(data)), 0x60, 0x00, 0xfd}
+
+	return append(code, data...)
+}
+
+// Tests that the pending state-syncs are committed on a copy of the head state,
+// reporting the gas, logs and revert reason of every event, up to the first
+// invalid one.
+func TestSimulateStateSyncs(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		stateReceiver = common.HexToAddress("0x0000000000000000000000000000000000001001")
+		receiver      = common.Address{0x01}
+		reverting     = common.Address{0x02}
+	)
+
+	chainConfig := *params.TestChainConfig
+	chainConfig.Bor = &params.BorConfig{
+		Period:                     map[string]uint64{"0": 2},
+		Sprint:                     map[string]uint64{"0": 16},
+		StateSyncConfirmationDelay: map[string]uint64{"0": 128},
+		IndoreBlock:                big.NewInt(0),
+		StateReceiverContract:      stateReceiver.Hex(),
+	}
+
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+
+	statedb.SetCode(reverting, revertingCode("not accepted"))
+
+	record := func(id uint64, contract common.Address, chainID string) *clerk.EventRecordWithTime {
+		return &clerk.EventRecordWithTime{
+			EventRecord: clerk.EventRecord{ID: id, Contract: contract, ChainID: chainID},
+			Time:        time.Unix(100, 0),
+		}
+	}
+
+	chainID := chainConfig.ChainID.String()
+	records := []*clerk.EventRecordWithTime{
+		record(6, receiver, chainID),
+		record(7, reverting, chainID),
+		record(8, receiver, chainID),
+		record(9, receiver, "0"), // Wrong chain id, stopping the simulation
+		record(10, receiver, chainID),
+	}
+
+	genesisContracts := NewMockGenesisContract(ctrl)
+	genesisContracts.EXPECT().LastStateId(gomock.Any(), uint64(10), gomock.Any()).Return(big.NewInt(5), nil)
+	genesisContracts.EXPECT().CommitState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(event *clerk.EventRecordWithTime, statedb *state.StateDB, header *types.Header, _ statefull.ChainContext) (uint64, error) {
+			switch event.ID {
+			case 6:
+				statedb.AddLog(stateCommittedLog(stateReceiver, 6, true))
+			case 7:
+				statedb.AddLog(stateCommittedLog(stateReceiver, 7, false))
+			case 8:
+				// A log for another state id doesn't commit the event
+				statedb.AddLog(stateCommittedLog(stateReceiver, 9, true))
+			}
+
+			return 30000 + event.ID, nil
+		}).Times(3)
+
+	heimdallClient := mocks.NewMockIHeimdallClient(ctrl)
+	heimdallClient.EXPECT().StateSyncEvents(gomock.Any(), uint64(6), int64(1002-128)).Return(records, nil)
+
+	c := &Bor{
+		chainConfig:            &chainConfig,
+		config:                 chainConfig.Bor,
+		GenesisContractsClient: genesisContracts,
+		HeimdallClient:         heimdallClient,
+	}
+
+	head := &types.Header{Number: big.NewInt(10), Time: 1000, Difficulty: big.NewInt(1)}
+
+	simulation, err := c.SimulateStateSyncs(context.Background(), &simulationChain{statedb: statedb}, head)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(11), simulation.BlockNumber)
+	require.Equal(t, uint64(5), simulation.LastStateID)
+	require.Contains(t, simulation.Error, "9")
+	require.Len(t, simulation.Events, 3)
+
+	committed := simulation.Events[0]
+	require.Equal(t, uint64(6), committed.ID)
+	require.Equal(t, uint64(30006), committed.GasUsed)
+	require.True(t, committed.Success)
+	require.Len(t, committed.Logs, 1)
+	require.Empty(t, committed.Error)
+
+	reverted := simulation.Events[1]
+	require.Equal(t, uint64(7), reverted.ID)
+	require.False(t, reverted.Success)
+	require.Equal(t, "execution reverted", reverted.Error)
+	require.Equal(t, "not accepted", reverted.RevertReason)
+
+	uncommitted := simulation.Events[2]
+	require.Equal(t, uint64(8), uncommitted.ID)
+	require.False(t, uncommitted.Success)
+	require.Equal(t, "receiver is not a contract", uncommitted.Error)
+}
+
+// Tests that the StateCommitted log of an event is looked up by state id.
+func TestStateCommitted(t *testing.T) {
+	t.Parallel()
+
+	stateReceiver := common.HexToAddress("0x0000000000000000000000000000000000001001")
+
+	tests := []struct {
+		name      string
+		logs      []*types.Log
+		committed bool
+		success   bool
+	}{
+		{"no logs", nil, false, false},
+		{"success", []*types.Log{stateCommittedLog(stateReceiver, 1, true)}, true, true},
+		{"failure", []*types.Log{stateCommittedLog(stateReceiver, 1, false)}, true, false},
+		{"other state id", []*types.Log{stateCommittedLog(stateReceiver, 2, true)}, false, false},
+		{"other contract", []*types.Log{stateCommittedLog(common.Address{0x01}, 1, true)}, false, false},
+		{"among other logs", []*types.Log{stateCommittedLog(stateReceiver, 2, false), stateCommittedLog(stateReceiver, 1, true)}, true, true},
+	}
+
+	for _, tt := range tests {
+		committed, success := stateCommitted(tt.logs, stateReceiver, 1)
+
+		require.Equal(t, tt.committed, committed, tt.name)
+		require.Equal(t, tt.success, success, tt.name)
+	}
+}
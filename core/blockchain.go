@@ -239,9 +239,12 @@ type BlockChain struct {
 	engine                       consensus.Engine
 	validator                    Validator // Block and state validator interface
 	prefetcher                   Prefetcher
-	processor                    Processor // Block transaction processor interface
-	parallelProcessor            Processor // Parallel block transaction processor interface
-	parallelSpeculativeProcesses int       // Number of parallel speculative processes
+	processor                    Processor          // Block transaction processor interface
+	parallelProcessor            Processor          // Parallel block transaction processor interface
+	parallelSpeculativeProcesses int                // Number of parallel speculative processes
+	parallelScheduler            blockstm.Scheduler // Scheduling policy of the parallel processor
+	verifyTxDependency           bool               // Whether to verify the transaction dependencies of block headers
+	enforceTxDependency          bool               // Whether to reject blocks with unsound transaction dependencies
 	forker                       *ForkChoice
 	vmConfig                     vm.Config
 
@@ -503,6 +506,7 @@ func NewParallelBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis
 
 	bc.parallelProcessor = NewParallelStateProcessor(chainConfig, bc, engine)
 	bc.parallelSpeculativeProcesses = numprocs
+	bc.parallelScheduler = blockstm.NewStaticScheduler(numprocs)
 
 	return bc, nil
 }
@@ -568,6 +572,19 @@ func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header) (ty
 		}
 	}
 
+	// Only the parallel processor verifies the transaction dependencies, so wait
+	// for it before accepting a block it may reject
+	if processorCount == 2 && result.err == nil && result.counter == blockExecutionSerialCounter && bc.enforceTxDependency && block.GetTxDependency() != nil {
+		if second := <-resultChan; errors.Is(second.err, ErrUnsoundTxDependency) {
+			result.statedb.StopPrefetcher()
+			result = second
+		} else {
+			second.statedb.StopPrefetcher()
+		}
+
+		processorCount--
+	}
+
 	result.counter.Inc(1)
 
 	// Make sure we are not leaking any prefetchers
@@ -685,6 +702,9 @@ func (bc *BlockChain) loadLastState() error {
 // SetHead rewinds the local chain to a new head. Depending on whether the node
 // was snap synced or full synced and in which state, the method will try to
 // delete minimal data from disk whilst retaining chain consistency.
+//
+// The rewind isn't limited by the finality policy, automatic rewinds must use
+// RewindWithinFinality instead.
 func (bc *BlockChain) SetHead(head uint64) error {
 	if _, err := bc.setHeadBeyondRoot(head, 0, common.Hash{}, false); err != nil {
 		return err
@@ -910,6 +930,7 @@ func (bc *BlockChain) setHeadBeyondRoot(head uint64, time uint64, root common.Ha
 			rawdb.DeleteReceipts(db, hash, num)
 			rawdb.DeleteBorReceipt(db, hash, num)
 			rawdb.DeleteBorTxLookupEntry(db, hash, num)
+			rawdb.DeleteBorStateSyncs(db, hash, num)
 		}
 		// Todo(rjl493456442) txlookup, bloombits, etc
 	}
@@ -1085,6 +1106,7 @@ func (bc *BlockChain) writeHeadBlock(block *types.Block) {
 	rawdb.WriteHeadFastBlockHash(batch, block.Hash())
 	rawdb.WriteCanonicalHash(batch, block.Hash(), block.NumberU64())
 	rawdb.WriteTxLookupEntriesByBlock(batch, block)
+	rawdb.WriteBorStateSyncLookupEntries(batch, block.NumberU64(), rawdb.ReadBorStateSyncs(bc.db, block.Hash(), block.NumberU64()))
 	rawdb.WriteHeadBlockHash(batch, block.Hash())
 
 	// Flush the whole batch into the disk, exit the node if failed
@@ -1640,6 +1662,17 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 		}
 	}
 
+	// Index the state-syncs committed in the block. Their lookup entries are
+	// only written once the block becomes canonical.
+	if stateSyncs := state.StateSyncs(); len(stateSyncs) > 0 {
+		records := make([]*types.StateSyncRecord, 0, len(stateSyncs))
+		for _, data := range stateSyncs {
+			records = append(records, types.NewStateSyncRecord(data))
+		}
+
+		rawdb.WriteBorStateSyncs(blockBatch, block.Hash(), block.NumberU64(), records)
+	}
+
 	rawdb.WritePreimages(blockBatch, state.Preimages())
 
 	if err := blockBatch.Write(); err != nil {
@@ -1763,12 +1796,15 @@ func (bc *BlockChain) writeBlockAndSetHead(ctx context.Context, block *types.Blo
 		if reorg {
 			// Reorganise the chain if the parent is not the head block
 			if block.ParentHash() != currentBlock.Hash() {
-				if err = bc.reorg(currentBlock, block); err != nil {
-					status = NonStatTy
-				}
+				err = bc.reorg(currentBlock, block)
 			}
 
-			status = CanonStatTy
+			// Keep the head if the reorg is refused, e.g. by the finality policy
+			if err != nil {
+				status = NonStatTy
+			} else {
+				status = CanonStatTy
+			}
 		} else {
 			status = SideStatTy
 		}
@@ -2622,6 +2658,19 @@ func (bc *BlockChain) reorg(oldHead *types.Header, newHead *types.Block) error {
 		}
 	}
 
+	// Reject the reorgs forking off below the finalized block or deeper than allowed
+	if len(oldChain) > 0 {
+		if err := bc.forker.ValidateReorgDepth(oldHead, commonBlock.Header()); err != nil {
+			log.Warn("Reorg rejected by the finality policy", "number", commonBlock.Number(), "hash", commonBlock.Hash(), "drop", len(oldChain), "err", err)
+			return err
+		}
+	}
+
+	var (
+		depth = uint64(len(oldChain))
+		deep  = bc.forker.policy.IsDeep(depth)
+	)
+
 	// Ensure the user sees large reorgs
 	if len(oldChain) > 0 && len(newChain) > 0 {
 		bc.chain2HeadFeed.Send(Chain2HeadEvent{
@@ -2633,7 +2682,7 @@ func (bc *BlockChain) reorg(oldHead *types.Header, newHead *types.Block) error {
 		logFn := log.Info
 
 		msg := "Chain reorg detected"
-		if len(oldChain) > 63 {
+		if deep {
 			msg = "Large chain reorg detected"
 			logFn = log.Warn
 		}
@@ -2731,7 +2780,7 @@ func (bc *BlockChain) reorg(oldHead *types.Header, newHead *types.Block) error {
 
 	for i := len(oldChain) - 1; i >= 0; i-- {
 		// Also send event for blocks removed from the canon chain.
-		bc.chainSideFeed.Send(ChainSideEvent{Block: oldChain[i]})
+		bc.chainSideFeed.Send(ChainSideEvent{Block: oldChain[i], Depth: depth, Deep: deep})
 
 		// Collect deleted logs for notification
 		if logs := bc.collectLogs(oldChain[i], true); len(logs) > 0 {
@@ -3090,6 +3139,21 @@ func (bc *BlockChain) SetBlockValidatorAndProcessorForTesting(v Validator, p Pro
 	bc.processor = p
 }
 
+// SetParallelScheduler sets the scheduling policy of the parallel processor. It
+// must be called before block import starts.
+func (bc *BlockChain) SetParallelScheduler(scheduler blockstm.Scheduler) {
+	bc.parallelScheduler = scheduler
+}
+
+// SetTxDependencyCheck configures whether the parallel state processor verifies
+// the transaction dependencies declared in block headers against the observed
+// ones, and whether it rejects blocks with unsound dependencies. Enforcing the
+// dependencies implies verifying them.
+func (bc *BlockChain) SetTxDependencyCheck(verify bool, enforce bool) {
+	bc.verifyTxDependency = verify || enforce
+	bc.enforceTxDependency = enforce
+}
+
 // SetTrieFlushInterval configures how often in-memory tries are persisted to disk.
 // The interval is in terms of block processing time, not wall clock.
 // It is thread-safe and can be called repeatedly without side effects.
@@ -21,12 +21,22 @@ type TxDep struct {
 }
 
 func HasReadDep(txFrom TxnOutput, txTo TxnInput) bool {
-	reads := make(map[Key]bool)
+	return hasReadDep(txFrom, readSet(txTo))
+}
+
+// readSet returns the set of keys read by a transaction.
+func readSet(txTo TxnInput) map[Key]bool {
+	reads := make(map[Key]bool, len(txTo))
 
 	for _, v := range txTo {
 		reads[v.Path] = true
 	}
 
+	return reads
+}
+
+// hasReadDep reports whether any of the writes of txFrom is in the read set.
+func hasReadDep(txFrom TxnOutput, reads map[Key]bool) bool {
 	for _, rd := range txFrom {
 		if _, ok := reads[rd.Path]; ok {
 			return true
@@ -199,3 +209,87 @@ func (d DAG) Report(stats map[int]ExecutionStat, out func(string)) {
 	out(fmt.Sprintf("Longest path ideal execution time: %v of %v (serial total), %v%%", time.Duration(weight),
 		time.Duration(serialWeight), fmt.Sprintf("%.1f", float64(weight)*100.0/float64(serialWeight))))
 }
+
+// DepsCheck is the outcome of comparing declared transaction dependencies with
+// the dependencies observed during execution. Dependencies are {tx, dependency}
+// pairs of transaction indices.
+type DepsCheck struct {
+	Missing [][2]int // Observed dependencies not implied by the declared ones
+	Extra   [][2]int // Declared dependencies not implied by the observed ones
+}
+
+// Sound reports whether the declared dependencies imply every observed one.
+func (c DepsCheck) Sound() bool {
+	return len(c.Missing) == 0
+}
+
+// CheckDeps compares the declared dependencies of every transaction with the
+// reads and writes recorded in deps. The comparison is made up to transitivity,
+// so a dependency is only missing if it can't be reached through the declared
+// ones, and only extra if it can't be reached through the observed ones.
+// Declared dependencies on the transaction itself or on a later transaction are
+// always extra.
+func CheckDeps(declared map[int][]int, deps TxnInputOutput) (check DepsCheck) {
+	numTx := len(deps.inputs)
+
+	observed := make([][]int, numTx)
+	valid := make([][]int, numTx)
+
+	for i := 0; i < numTx; i++ {
+		reads := readSet(deps.inputs[i])
+
+		for j := 0; j < i; j++ {
+			if hasReadDep(deps.allOutputs[j], reads) {
+				observed[i] = append(observed[i], j)
+			}
+		}
+
+		for _, j := range declared[i] {
+			if j < 0 || j >= i {
+				check.Extra = append(check.Extra, [2]int{i, j})
+				continue
+			}
+
+			valid[i] = append(valid[i], j)
+		}
+	}
+
+	observedReach := reachability(observed)
+	declaredReach := reachability(valid)
+
+	for i := 0; i < numTx; i++ {
+		for _, j := range observed[i] {
+			if !declaredReach[i][j] {
+				check.Missing = append(check.Missing, [2]int{i, j})
+			}
+		}
+
+		for _, j := range valid[i] {
+			if !observedReach[i][j] {
+				check.Extra = append(check.Extra, [2]int{i, j})
+			}
+		}
+	}
+
+	return check
+}
+
+// reachability returns the transitive closure of dependencies which only point
+// to earlier transactions.
+func reachability(deps [][]int) []map[int]bool {
+	reach := make([]map[int]bool, len(deps))
+
+	for i := range deps {
+		reach[i] = make(map[int]bool, len(deps[i]))
+
+		for _, j := range deps[i] {
+			reach[i][j] = true
+
+			for k := range reach[j] {
+				reach[i][k] = true
+			}
+		}
+	}
+
+	return reach
+}
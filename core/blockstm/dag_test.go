@@ -0,0 +1,47 @@
+package blockstm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestCheckDeps(t *testing.T) {
+	t.Parallel()
+
+	a := NewAddressKey(common.HexToAddress("0xa"))
+	b := NewAddressKey(common.HexToAddress("0xb"))
+
+	// tx0 writes a, tx1 reads a and writes b, tx2 reads a and b, tx3 is independent
+	io := MakeTxnInputOutput(4)
+	io.RecordReadAtOnce([][]ReadDescriptor{
+		nil,
+		{{Path: a}},
+		{{Path: a}, {Path: b}},
+		nil,
+	})
+	io.RecordAllWriteAtOnce([][]WriteDescriptor{
+		{{Path: a}},
+		{{Path: b}},
+		nil,
+		nil,
+	})
+
+	// Dependencies implied through tx1 don't need to be declared
+	check := CheckDeps(map[int][]int{1: {0}, 2: {1}}, *io)
+	assert.True(t, check.Sound())
+	assert.Empty(t, check.Missing)
+	assert.Empty(t, check.Extra)
+
+	check = CheckDeps(map[int][]int{2: {1}}, *io)
+	assert.False(t, check.Sound())
+	assert.Equal(t, [][2]int{{1, 0}, {2, 0}}, check.Missing)
+	assert.Empty(t, check.Extra)
+
+	check = CheckDeps(map[int][]int{1: {0, 1}, 2: {1, 0}, 3: {0}}, *io)
+	assert.True(t, check.Sound())
+	assert.Empty(t, check.Missing)
+	assert.Equal(t, [][2]int{{1, 1}, {3, 0}}, check.Extra)
+}
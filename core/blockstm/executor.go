@@ -250,6 +250,12 @@ type ExecutionStat struct {
 	Start       uint64
 	End         uint64
 	Worker      int
+
+	// Busy is the time spent executing all the incarnations of the task
+	Busy uint64
+
+	// Aborts is the number of incarnations which aborted or failed validation
+	Aborts int
 }
 
 func NewParallelExecutor(tasks []ExecTask, profile bool, metadata bool, numProcs int) *ParallelExecutor {
@@ -359,6 +365,7 @@ func (pe *ParallelExecutor) Prepare() error {
 						Start:       uint64(start),
 						End:         uint64(end),
 						Worker:      procNum,
+						Busy:        pe.stats[res.ver.TxnIndex].Busy + uint64(end-start),
 					}
 					pe.statsMutex.Unlock()
 				}
@@ -565,6 +572,11 @@ func (pe *ParallelExecutor) Step(res *ExecResult) (result ParallelExecutionResul
 		if pe.profile {
 			allDeps = GetDep(*pe.lastTxIO)
 			deps = BuildDAG(*pe.lastTxIO)
+
+			for tx, stat := range pe.stats {
+				stat.Aborts = pe.diagExecAbort[tx]
+				pe.stats[tx] = stat
+			}
 		}
 
 		return ParallelExecutionResult{pe.lastTxIO, &pe.stats, &deps, allDeps}, err
@@ -582,8 +594,8 @@ func (pe *ParallelExecutor) Step(res *ExecResult) (result ParallelExecutionResul
 		}
 	}
 
-	// Send speculative tasks
-	for pe.execTasks.minPending() != -1 {
+	// Send speculative tasks, unless executing serially
+	for pe.numSpeculativeProcs > 0 && pe.execTasks.minPending() != -1 {
 		nextTx := pe.execTasks.takeNextPending()
 
 		if nextTx != -1 {
@@ -602,11 +614,29 @@ func (pe *ParallelExecutor) Step(res *ExecResult) (result ParallelExecutionResul
 type PropertyCheck func(*ParallelExecutor) error
 
 func executeParallelWithCheck(tasks []ExecTask, profile bool, check PropertyCheck, metadata bool, numProcs int, interruptCtx context.Context) (result ParallelExecutionResult, err error) {
+	result, _, err = executePlan(tasks, profile, check, Plan{Procs: numProcs, FIFO: metadata}, interruptCtx)
+
+	return
+}
+
+// executePlan executes the tasks with the number of workers and the queue order
+// of the plan, and summarizes the execution.
+func executePlan(tasks []ExecTask, profile bool, check PropertyCheck, plan Plan, interruptCtx context.Context) (result ParallelExecutionResult, summary ExecutionSummary, err error) {
 	if len(tasks) == 0 {
-		return ParallelExecutionResult{MakeTxnInputOutput(len(tasks)), nil, nil, nil}, nil
+		return ParallelExecutionResult{MakeTxnInputOutput(len(tasks)), nil, nil, nil}, summary, nil
 	}
 
-	pe := NewParallelExecutor(tasks, profile, metadata, numProcs)
+	pe := NewParallelExecutor(tasks, profile, plan.FIFO, plan.Procs)
+
+	defer func() {
+		summary = ExecutionSummary{
+			Tasks:              len(tasks),
+			Execs:              pe.cntExec,
+			Aborts:             pe.cntAbort,
+			ValidationFailures: pe.cntValidationFail,
+		}
+	}()
+
 	err = pe.Prepare()
 
 	if err != nil {
@@ -617,7 +647,7 @@ func executeParallelWithCheck(tasks []ExecTask, profile bool, check PropertyChec
 	for range pe.chResults {
 		if interruptCtx != nil && interruptCtx.Err() != nil {
 			pe.Close(true)
-			return result, interruptCtx.Err()
+			return result, summary, interruptCtx.Err()
 		}
 
 		res := pe.resultQueue.Pop().(ExecResult)
@@ -625,7 +655,7 @@ func executeParallelWithCheck(tasks []ExecTask, profile bool, check PropertyChec
 		result, err = pe.Step(&res)
 
 		if err != nil {
-			return result, err
+			return result, summary, err
 		}
 
 		if check != nil {
@@ -633,7 +663,7 @@ func executeParallelWithCheck(tasks []ExecTask, profile bool, check PropertyChec
 		}
 
 		if result.TxIO != nil || err != nil {
-			return result, err
+			return result, summary, err
 		}
 	}
 
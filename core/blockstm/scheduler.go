@@ -0,0 +1,229 @@
+package blockstm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// StaticPolicy always executes with the configured number of workers
+	StaticPolicy = "static"
+
+	// AdaptivePolicy adjusts the number of workers to the recent conflict rate
+	AdaptivePolicy = "adaptive"
+)
+
+const (
+	// conflictSmoothing is the weight of the latest block in the conflict rate
+	conflictSmoothing = 0.2
+
+	// Conflict rates, in re-executions per transaction, below which workers are
+	// added and above which they are removed
+	lowConflictRate  = 0.1
+	highConflictRate = 0.5
+)
+
+var (
+	schedulerProcsGauge      = metrics.NewRegisteredGauge("blockstm/scheduler/procs", nil)
+	schedulerSerialMeter     = metrics.NewRegisteredMeter("blockstm/scheduler/serial", nil)
+	schedulerConflictsHist   = metrics.NewRegisteredHistogram("blockstm/scheduler/conflicts", nil, metrics.NewExpDecaySample(1028, 0.015))
+	schedulerReexecutionsCtr = metrics.NewRegisteredCounter("blockstm/scheduler/reexecutions", nil)
+)
+
+// Plan is how the parallel executor runs the tasks of a block.
+type Plan struct {
+	Procs int  // Number of speculative workers, zero to execute the tasks serially
+	FIFO  bool // Schedule tasks in submission order instead of by index
+}
+
+// ExecutionSummary is the outcome of executing the tasks of a block.
+type ExecutionSummary struct {
+	Tasks              int // Number of tasks
+	Execs              int // Number of incarnations executed
+	Aborts             int // Number of incarnations which aborted
+	ValidationFailures int // Number of incarnations which failed validation
+}
+
+// Reexecutions returns the number of incarnations beyond the first of every task.
+func (s ExecutionSummary) Reexecutions() int {
+	if s.Execs < s.Tasks {
+		return 0
+	}
+
+	return s.Execs - s.Tasks
+}
+
+// ConflictRate returns the number of re-executions per task.
+func (s ExecutionSummary) ConflictRate() float64 {
+	if s.Tasks == 0 {
+		return 0
+	}
+
+	return float64(s.Reexecutions()) / float64(s.Tasks)
+}
+
+// Scheduler is a scheduling policy of the parallel executor. It plans the
+// execution of every block and is told about its outcome, so it may adapt to
+// the recent workload. It must be safe for concurrent use.
+type Scheduler interface {
+	// Policy returns the name of the scheduling policy
+	Policy() string
+
+	// Plan returns how to execute a block of numTasks tasks, whose
+	// dependencies may be known from the block metadata
+	Plan(numTasks int, metadata bool) Plan
+
+	// Observe records the outcome of a block executed as planned
+	Observe(plan Plan, summary ExecutionSummary)
+}
+
+// NewScheduler creates the scheduler of the given policy. Static schedulers use
+// procs workers. Adaptive ones start with procs workers, adjust them between
+// minProcs and maxProcs, and fall back to serial execution while the conflict
+// rate is at least serialThreshold (zero disables the fallback).
+func NewScheduler(policy string, minProcs int, procs int, maxProcs int, serialThreshold float64) (Scheduler, error) {
+	switch policy {
+	case "", StaticPolicy:
+		return NewStaticScheduler(procs), nil
+	case AdaptivePolicy:
+		return NewAdaptiveScheduler(minProcs, procs, maxProcs, serialThreshold), nil
+	default:
+		return nil, fmt.Errorf("unknown blockstm scheduling policy %q", policy)
+	}
+}
+
+// StaticScheduler always executes with the same number of workers, in
+// submission order when the dependencies are known and by index otherwise.
+type StaticScheduler struct {
+	procs int
+}
+
+// NewStaticScheduler creates a scheduler executing with procs workers.
+func NewStaticScheduler(procs int) *StaticScheduler {
+	return &StaticScheduler{procs: procs}
+}
+
+func (s *StaticScheduler) Policy() string {
+	return StaticPolicy
+}
+
+func (s *StaticScheduler) Plan(numTasks int, metadata bool) Plan {
+	return Plan{Procs: s.procs, FIFO: metadata}
+}
+
+func (s *StaticScheduler) Observe(plan Plan, summary ExecutionSummary) {}
+
+// AdaptiveScheduler adjusts the number of workers to the smoothed conflict rate
+// of the recent blocks. Workers are added one at a time while conflicts are
+// rare, and halved while they are frequent. Above the serial threshold, blocks
+// are executed serially until the conflict rate decays again.
+type AdaptiveScheduler struct {
+	minProcs        int
+	maxProcs        int
+	serialThreshold float64
+
+	lock  sync.Mutex
+	procs int
+	rate  float64
+}
+
+// NewAdaptiveScheduler creates an adaptive scheduler, starting with procs
+// workers and keeping them between minProcs and maxProcs. A maxProcs below
+// procs is raised to it.
+func NewAdaptiveScheduler(minProcs int, procs int, maxProcs int, serialThreshold float64) *AdaptiveScheduler {
+	if minProcs < 1 {
+		minProcs = 1
+	}
+
+	if procs < minProcs {
+		procs = minProcs
+	}
+
+	if maxProcs < procs {
+		maxProcs = procs
+	}
+
+	return &AdaptiveScheduler{
+		minProcs:        minProcs,
+		maxProcs:        maxProcs,
+		serialThreshold: serialThreshold,
+		procs:           procs,
+	}
+}
+
+func (s *AdaptiveScheduler) Policy() string {
+	return AdaptivePolicy
+}
+
+func (s *AdaptiveScheduler) Plan(numTasks int, metadata bool) Plan {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.serialThreshold > 0 && s.rate >= s.serialThreshold {
+		return Plan{Procs: 0, FIFO: metadata}
+	}
+
+	procs := s.procs
+	if numTasks > 0 && procs > numTasks {
+		procs = numTasks
+	}
+
+	return Plan{Procs: procs, FIFO: metadata}
+}
+
+func (s *AdaptiveScheduler) Observe(plan Plan, summary ExecutionSummary) {
+	if summary.Tasks == 0 {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	rate := summary.ConflictRate()
+
+	s.rate = conflictSmoothing*rate + (1-conflictSmoothing)*s.rate
+
+	// Serial blocks can't conflict, only the decay of the rate matters
+	if plan.Procs == 0 {
+		return
+	}
+
+	switch {
+	case s.rate < lowConflictRate && s.procs < s.maxProcs:
+		s.procs++
+	case s.rate > highConflictRate && s.procs > s.minProcs:
+		s.procs /= 2
+		if s.procs < s.minProcs {
+			s.procs = s.minProcs
+		}
+	}
+}
+
+// ExecuteWithScheduler executes the tasks as planned by the scheduler, and
+// reports the outcome back to it.
+func ExecuteWithScheduler(tasks []ExecTask, profile bool, metadata bool, scheduler Scheduler, interruptCtx context.Context) (ParallelExecutionResult, error) {
+	plan := scheduler.Plan(len(tasks), metadata)
+
+	result, summary, err := executePlan(tasks, profile, nil, plan, interruptCtx)
+	if err != nil {
+		return result, err
+	}
+
+	// The meter of the policy exposes which one is in use
+	metrics.GetOrRegisterMeter("blockstm/scheduler/policy/"+scheduler.Policy(), nil).Mark(1)
+	schedulerProcsGauge.Update(int64(plan.Procs))
+
+	if plan.Procs == 0 {
+		schedulerSerialMeter.Mark(1)
+	}
+
+	schedulerConflictsHist.Update(int64(summary.ConflictRate() * 100))
+	schedulerReexecutionsCtr.Inc(int64(summary.Reexecutions()))
+
+	scheduler.Observe(plan, summary)
+
+	return result, nil
+}
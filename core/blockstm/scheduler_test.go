@@ -0,0 +1,85 @@
+package blockstm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestNewScheduler(t *testing.T) {
+	t.Parallel()
+
+	s, err := NewScheduler("", 1, 8, 16, 0)
+	require.NoError(t, err)
+	assert.Equal(t, StaticPolicy, s.Policy())
+	assert.Equal(t, Plan{Procs: 8, FIFO: true}, s.Plan(100, true))
+
+	s, err = NewScheduler(AdaptivePolicy, 2, 8, 16, 1)
+	require.NoError(t, err)
+	assert.Equal(t, AdaptivePolicy, s.Policy())
+
+	_, err = NewScheduler("random", 1, 8, 16, 0)
+	assert.Error(t, err)
+}
+
+func TestAdaptiveScheduler(t *testing.T) {
+	t.Parallel()
+
+	s := NewAdaptiveScheduler(1, 8, 12, 1)
+
+	// The workers never outnumber the tasks
+	assert.Equal(t, Plan{Procs: 8}, s.Plan(100, false))
+	assert.Equal(t, Plan{Procs: 3}, s.Plan(3, false))
+
+	// Frequent conflicts halve the workers, down to serial execution
+	plan := s.Plan(100, false)
+	for plan.Procs > 0 {
+		s.Observe(plan, ExecutionSummary{Tasks: 100, Execs: 300})
+
+		next := s.Plan(100, false)
+		assert.LessOrEqual(t, next.Procs, plan.Procs)
+
+		plan = next
+	}
+
+	assert.Equal(t, 1, s.procs)
+
+	// Serial blocks let the conflict rate decay until workers are used again
+	for plan.Procs == 0 {
+		s.Observe(plan, ExecutionSummary{Tasks: 100, Execs: 100})
+		plan = s.Plan(100, false)
+	}
+
+	assert.Equal(t, 1, plan.Procs)
+
+	// Rare conflicts add workers, past the initial count up to the maximum
+	for i := 0; i < 100; i++ {
+		s.Observe(plan, ExecutionSummary{Tasks: 100, Execs: 100})
+		plan = s.Plan(100, false)
+	}
+
+	assert.Equal(t, 12, plan.Procs)
+
+	// The maximum is never below the initial count
+	assert.Equal(t, Plan{Procs: 8}, NewAdaptiveScheduler(1, 8, 4, 0).Plan(100, false))
+}
+
+func TestSerialPlan(t *testing.T) {
+	t.Parallel()
+
+	sender := func(i int) common.Address { return common.BigToAddress(big.NewInt(int64(i % 10))) }
+	tasks, _ := taskFactory(100, sender, 20, 20, 10, randomPathGenerator, readTime, writeTime, nonIOTime)
+
+	checks := composeValidations([]PropertyCheck{checkNoStatusOverlap, checkNoDroppedTx})
+
+	result, summary, err := executePlan(tasks, false, checks, Plan{Procs: 0}, nil)
+	require.NoError(t, err)
+	require.NotNil(t, result.TxIO)
+
+	// Serially executed tasks never conflict
+	assert.Equal(t, ExecutionSummary{Tasks: 100, Execs: 100}, summary)
+}
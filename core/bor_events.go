@@ -1,6 +1,7 @@
 package core
 
 import (
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -21,3 +22,18 @@ type Chain2HeadEvent struct {
 	OldChain []*types.Block
 	Type     string
 }
+
+var (
+	FinalityCheckpointEvent = "checkpoint"
+	FinalityMilestoneEvent  = "milestone"
+)
+
+// FinalityEvent is posted when a checkpoint or milestone is whitelisted, which
+// finalizes the blocks up to its end block
+type FinalityEvent struct {
+	Type        string
+	MilestoneID string // Heimdall id of the milestone, if known
+	StartBlock  uint64
+	EndBlock    uint64
+	Hash        common.Hash // Hash of the end block
+}
@@ -33,6 +33,10 @@ var (
 	ErrNoGenesis = errors.New("genesis not found in chain")
 
 	errSideChainReceipts = errors.New("side blocks can't be accepted as ancient chain data")
+
+	// ErrUnsoundTxDependency is returned if the transaction dependencies declared
+	// in a block header miss dependencies observed during its execution.
+	ErrUnsoundTxDependency = errors.New("unsound transaction dependency metadata")
 )
 
 // List of evm-call-message pre-checking errors. All state transition messages will
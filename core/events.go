@@ -36,8 +36,13 @@ type ChainEvent struct {
 	Logs  []*types.Log
 }
 
+// ChainSideEvent is posted for the blocks written as a side chain, and for the
+// blocks dropped from the canonical chain by a reorg, in which case Depth is
+// the number of blocks dropped by the reorg.
 type ChainSideEvent struct {
 	Block *types.Block
+	Depth uint64 // Number of blocks dropped by the reorg, zero for side chain blocks
+	Deep  bool   // Whether the reorg is deep according to the finality policy
 }
 
 type ChainHeadEvent struct{ Block *types.Block }
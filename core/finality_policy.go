@@ -0,0 +1,167 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+var (
+	// ErrRewindBelowFinality is returned when rewinding the chain below the
+	// whitelisted milestone or checkpoint.
+	ErrRewindBelowFinality = errors.New("rewind below the finalized block")
+
+	// ErrReorgBelowFinality is returned when a reorg forks off the chain below
+	// the whitelisted milestone or checkpoint.
+	ErrReorgBelowFinality = errors.New("reorg below the finalized block")
+
+	// ErrReorgTooDeep is returned when a reorg drops more blocks than allowed by
+	// the finality policy.
+	ErrReorgTooDeep = errors.New("reorg too deep")
+)
+
+// FinalityPolicyConfig are the reorg and rewind depth limits of the finality
+// policy.
+type FinalityPolicyConfig struct {
+	MaxRewind     uint64 // Maximum number of blocks dropped by an automatic rewind on a checkpoint or milestone mismatch (0 = unlimited)
+	MaxReorgDepth uint64 // Maximum number of blocks dropped by a reorg (0 = unlimited)
+	DeepReorg     uint64 // Number of dropped blocks from which a reorg is reported as deep (0 = never)
+}
+
+// DefaultFinalityPolicyConfig are the limits used when no finality policy is set
+var DefaultFinalityPolicyConfig = FinalityPolicyConfig{
+	MaxRewind:     255,
+	MaxReorgDepth: 0,
+	DeepReorg:     64,
+}
+
+// FinalityPolicy ties the reorgs and rewinds of the chain to the bor finality:
+// the chain never forks off or rewinds below the whitelisted milestone or
+// checkpoint, and the reorgs above it are limited in depth. A nil policy uses
+// the default limits without finality.
+type FinalityPolicy struct {
+	config    FinalityPolicyConfig
+	validator ethereum.ChainValidator
+}
+
+// NewFinalityPolicy creates a finality policy with the given limits, reading
+// the finalized block from the whitelist service.
+func NewFinalityPolicy(config FinalityPolicyConfig, validator ethereum.ChainValidator) *FinalityPolicy {
+	return &FinalityPolicy{
+		config:    config,
+		validator: validator,
+	}
+}
+
+// Config returns the limits of the policy
+func (p *FinalityPolicy) Config() FinalityPolicyConfig {
+	if p == nil {
+		return DefaultFinalityPolicyConfig
+	}
+
+	return p.config
+}
+
+// Finalized returns the highest whitelisted milestone or checkpoint
+func (p *FinalityPolicy) Finalized() (uint64, bool) {
+	if p == nil || p.validator == nil {
+		return 0, false
+	}
+
+	var (
+		number uint64
+		ok     bool
+	)
+
+	if doExist, milestone, _ := p.validator.GetWhitelistedMilestone(); doExist {
+		number, ok = milestone, true
+	}
+
+	if doExist, checkpoint, _ := p.validator.GetWhitelistedCheckpoint(); doExist && (!ok || checkpoint > number) {
+		number, ok = checkpoint, true
+	}
+
+	return number, ok
+}
+
+// LowestAncestor returns the lowest block a reorg of the chain at the given
+// head can fork off from.
+func (p *FinalityPolicy) LowestAncestor(head uint64) uint64 {
+	var lowest uint64
+
+	if depth := p.Config().MaxReorgDepth; depth > 0 && head > depth {
+		lowest = head - depth
+	}
+
+	// The finalized block is only enforced once the chain reached it, a node
+	// behind it may still have to leave a stale fork
+	if finalized, ok := p.Finalized(); ok && finalized <= head && finalized > lowest {
+		lowest = finalized
+	}
+
+	return lowest
+}
+
+// CheckRewind checks that rewinding the chain at the given head to the target
+// doesn't drop the finalized block.
+func (p *FinalityPolicy) CheckRewind(head uint64, target uint64) error {
+	if finalized, ok := p.Finalized(); ok && finalized <= head && target < finalized {
+		return fmt.Errorf("%w: target %d, finalized %d", ErrRewindBelowFinality, target, finalized)
+	}
+
+	return nil
+}
+
+// ClampRewind returns the target of an automatic rewind of the chain at the
+// given head, capped to the maximum rewind.
+func (p *FinalityPolicy) ClampRewind(head uint64, target uint64) uint64 {
+	if limit := p.Config().MaxRewind; limit > 0 && head > target && head-target > limit {
+		return head - limit
+	}
+
+	return target
+}
+
+// CheckReorg checks that a reorg of the chain at the given head forking off at
+// the given ancestor is allowed.
+func (p *FinalityPolicy) CheckReorg(head uint64, ancestor uint64) error {
+	if finalized, ok := p.Finalized(); ok && finalized <= head && ancestor < finalized {
+		return fmt.Errorf("%w: ancestor %d, finalized %d", ErrReorgBelowFinality, ancestor, finalized)
+	}
+
+	if limit := p.Config().MaxReorgDepth; limit > 0 && head > ancestor && head-ancestor > limit {
+		return fmt.Errorf("%w: depth %d, limit %d", ErrReorgTooDeep, head-ancestor, limit)
+	}
+
+	return nil
+}
+
+// IsDeep reports whether a reorg dropping the given number of blocks is deep
+func (p *FinalityPolicy) IsDeep(depth uint64) bool {
+	limit := p.Config().DeepReorg
+
+	return limit > 0 && depth >= limit
+}
+
+// RewindWithinFinality rewinds the local chain to a new head like SetHead, but
+// refuses to rewind below the block finalized by the finality policy. It is used
+// by the automatic rewinds, while SetHead lets operators force any rewind.
+func (bc *BlockChain) RewindWithinFinality(head uint64) error {
+	if err := bc.forker.policy.CheckRewind(bc.CurrentBlock().Number.Uint64(), head); err != nil {
+		return err
+	}
+
+	return bc.SetHead(head)
+}
+
+// SetFinalityPolicy sets the policy limiting the reorgs and rewinds of the chain
+func (bc *BlockChain) SetFinalityPolicy(policy *FinalityPolicy) {
+	bc.forker.policy = policy
+}
+
+// FinalityPolicy returns the policy limiting the reorgs and rewinds of the
+// chain, nil if the default limits are used.
+func (bc *BlockChain) FinalityPolicy() *FinalityPolicy {
+	return bc.forker.policy
+}
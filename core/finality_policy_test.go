@@ -0,0 +1,167 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// finalityValidatorFake is a chain validator accepting all chains, with a
+// whitelisted milestone
+type finalityValidatorFake struct {
+	chainValidatorFake
+	milestone uint64
+}
+
+func newFinalityValidatorFake(milestone uint64) *finalityValidatorFake {
+	return &finalityValidatorFake{
+		chainValidatorFake: chainValidatorFake{
+			validate: func(currentHeader *types.Header, chain []*types.Header) (bool, error) {
+				return true, nil
+			},
+		},
+		milestone: milestone,
+	}
+}
+
+func (w *finalityValidatorFake) GetWhitelistedMilestone() (bool, uint64, common.Hash) {
+	return w.milestone > 0, w.milestone, common.Hash{}
+}
+
+// Tests the limits of the finality policy, with and without a finalized block.
+func TestFinalityPolicy(t *testing.T) {
+	t.Parallel()
+
+	var policy *FinalityPolicy
+
+	if got := policy.ClampRewind(1000, 100); got != 1000-DefaultFinalityPolicyConfig.MaxRewind {
+		t.Fatalf("default rewind mismatch: have %d, want %d", got, 1000-DefaultFinalityPolicyConfig.MaxRewind)
+	}
+
+	if err := policy.CheckReorg(1000, 0); err != nil {
+		t.Fatalf("default policy rejected reorg: %v", err)
+	}
+
+	policy = NewFinalityPolicy(FinalityPolicyConfig{MaxRewind: 10, MaxReorgDepth: 50, DeepReorg: 5}, newFinalityValidatorFake(100))
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"rewind to finalized", policy.CheckRewind(150, 100), nil},
+		{"rewind below finalized", policy.CheckRewind(150, 99), ErrRewindBelowFinality},
+		{"rewind behind finalized", policy.CheckRewind(50, 10), nil},
+		{"reorg above finalized", policy.CheckReorg(120, 100), nil},
+		{"reorg below finalized", policy.CheckReorg(120, 99), ErrReorgBelowFinality},
+		{"reorg too deep", policy.CheckReorg(200, 140), ErrReorgTooDeep},
+		{"reorg behind finalized", policy.CheckReorg(90, 80), nil},
+	}
+
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, tt.err, tt.want)
+		}
+	}
+
+	if lowest := policy.LowestAncestor(120); lowest != 100 {
+		t.Errorf("lowest ancestor mismatch: have %d, want 100", lowest)
+	}
+
+	if lowest := policy.LowestAncestor(200); lowest != 150 {
+		t.Errorf("lowest ancestor mismatch: have %d, want 150", lowest)
+	}
+
+	if got := policy.ClampRewind(150, 100); got != 140 {
+		t.Errorf("clamped rewind mismatch: have %d, want 140", got)
+	}
+
+	if !policy.IsDeep(5) || policy.IsDeep(4) {
+		t.Errorf("deep reorg threshold mismatch")
+	}
+}
+
+// Tests that the blockchain doesn't rewind nor reorg below the finalized block,
+// and reports the depth of the allowed reorgs in the side events.
+func TestFinalityPolicyReorg(t *testing.T) {
+	t.Parallel()
+
+	for _, milestone := range []uint64{0, 3} {
+		genDb, _, blockchain, err := newCanonical(ethash.NewFaker(), 0, true)
+		if err != nil {
+			t.Fatalf("failed to create pristine chain: %v", err)
+		}
+		defer blockchain.Stop()
+
+		blockchain.SetFinalityPolicy(NewFinalityPolicy(FinalityPolicyConfig{DeepReorg: 5}, newFinalityValidatorFake(milestone)))
+
+		genesis := blockchain.GetBlockByHash(blockchain.CurrentBlock().Hash())
+		easyBlocks, _ := GenerateChain(params.TestChainConfig, genesis, ethash.NewFaker(), genDb, 10, func(i int, b *BlockGen) {})
+		diffBlocks, _ := GenerateChain(params.TestChainConfig, genesis, ethash.NewFaker(), genDb, 12, func(i int, b *BlockGen) {
+			b.OffsetTime(-9)
+		})
+
+		if _, err := blockchain.InsertChain(easyBlocks); err != nil {
+			t.Fatalf("failed to insert easy chain: %v", err)
+		}
+
+		sideCh := make(chan ChainSideEvent, 64)
+		sub := blockchain.SubscribeChainSideEvent(sideCh)
+
+		_, err = blockchain.InsertChain(diffBlocks)
+
+		sub.Unsubscribe()
+		close(sideCh)
+
+		if milestone > 0 {
+			if !errors.Is(err, ErrReorgBelowFinality) {
+				t.Fatalf("milestone %d: reorg error mismatch: have %v, want %v", milestone, err, ErrReorgBelowFinality)
+			}
+
+			if head := blockchain.CurrentBlock().Hash(); head != easyBlocks[len(easyBlocks)-1].Hash() {
+				t.Fatalf("milestone %d: head changed to %x", milestone, head)
+			}
+
+			if err := blockchain.RewindWithinFinality(milestone - 1); !errors.Is(err, ErrRewindBelowFinality) {
+				t.Fatalf("milestone %d: rewind error mismatch: have %v, want %v", milestone, err, ErrRewindBelowFinality)
+			}
+
+			if err := blockchain.RewindWithinFinality(milestone); err != nil {
+				t.Fatalf("milestone %d: failed to rewind to the finalized block: %v", milestone, err)
+			}
+
+			// Operators may still force a rewind below the finalized block
+			if err := blockchain.SetHead(milestone - 1); err != nil {
+				t.Fatalf("milestone %d: failed to force rewind below the finalized block: %v", milestone, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("failed to insert difficult chain: %v", err)
+		}
+
+		var dropped int
+
+		for ev := range sideCh {
+			if ev.Depth == 0 {
+				continue
+			}
+
+			if ev.Depth != uint64(len(easyBlocks)) || !ev.Deep {
+				t.Fatalf("side event mismatch: have depth %d, deep %v", ev.Depth, ev.Deep)
+			}
+
+			dropped++
+		}
+
+		if dropped != len(easyBlocks) {
+			t.Fatalf("dropped block events mismatch: have %d, want %d", dropped, len(easyBlocks))
+		}
+	}
+}
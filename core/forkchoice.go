@@ -56,6 +56,9 @@ type ForkChoice struct {
 	preserve func(header *types.Header) bool
 
 	validator ethereum.ChainValidator
+
+	// policy limits the depth of the reorgs, it can be nil for the default limits
+	policy *FinalityPolicy
 }
 
 type Floater interface {
@@ -131,3 +134,9 @@ func (f *ForkChoice) ValidateReorg(current *types.Header, chain []*types.Header)
 
 	return true, nil
 }
+
+// ValidateReorgDepth checks the reorg of the current head forking off at the
+// given ancestor against the finality policy
+func (f *ForkChoice) ValidateReorgDepth(current *types.Header, ancestor *types.Header) error {
+	return f.policy.CheckReorg(current.Number.Uint64(), ancestor.Number.Uint64())
+}
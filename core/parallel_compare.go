@@ -0,0 +1,296 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/blockstm"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// ParallelKeyDifference is a state key written while executing a block whose
+// value differs between the serial and the parallel execution.
+type ParallelKeyDifference struct {
+	Address  common.Address `json:"address"`
+	Field    string         `json:"field"`          // balance, nonce, code, selfdestruct, account or storage
+	Slot     *common.Hash   `json:"slot,omitempty"` // Storage slot, for storage keys
+	Serial   string         `json:"serial"`
+	Parallel string         `json:"parallel"`
+	Writers  []int          `json:"writers"` // Transactions which wrote the key in the parallel execution
+}
+
+// ParallelComparison is the result of processing a block with both the serial
+// and the parallel state processor.
+type ParallelComparison struct {
+	Number       uint64                  `json:"number"`
+	Hash         common.Hash             `json:"hash"`
+	SerialRoot   common.Hash             `json:"serialRoot"`
+	ParallelRoot common.Hash             `json:"parallelRoot"`
+	SerialGas    uint64                  `json:"serialGas"`
+	ParallelGas  uint64                  `json:"parallelGas"`
+	Differences  []string                `json:"differences"` // Differences of receipts, logs, gas used and state root
+	Keys         []ParallelKeyDifference `json:"keys"`
+	PreState     GenesisAlloc            `json:"-"` // Parent state of every key read or written by the block
+}
+
+// Diverged reports whether the serial and the parallel execution differ.
+func (c *ParallelComparison) Diverged() bool {
+	return len(c.Differences) > 0
+}
+
+// Compare processes the block on top of copies of its parent state with both
+// the serial and the parallel state processor, and compares their receipts,
+// logs, gas used and state roots. If they diverge, the comparison includes the
+// state keys written in the parallel execution whose values differ, and the
+// parent state of all the keys accessed by the block. The parent state is left
+// untouched.
+func (p *ParallelStateProcessor) Compare(block *types.Block, parent *state.StateDB, cfg vm.Config, interruptCtx context.Context) (*ParallelComparison, error) {
+	serialState := parent.Copy()
+
+	serialReceipts, serialLogs, serialGas, err := NewStateProcessor(p.config, p.bc, p.engine).Process(block, serialState, cfg, interruptCtx)
+	if err != nil {
+		return nil, fmt.Errorf("serial execution failed: %w", err)
+	}
+
+	parallelState := parent.Copy()
+
+	parallelReceipts, parallelLogs, parallelGas, result, err := p.process(block, parallelState, cfg, false, p.bc.parallelScheduler, interruptCtx)
+	if err != nil {
+		return nil, fmt.Errorf("parallel execution failed: %w", err)
+	}
+
+	p.engine.Finalize(p.bc, block.Header(), parallelState, block.Transactions(), block.Uncles(), nil)
+
+	eip158 := p.config.IsEIP158(block.Number())
+
+	comparison := &ParallelComparison{
+		Number:       block.NumberU64(),
+		Hash:         block.Hash(),
+		SerialRoot:   serialState.IntermediateRoot(eip158),
+		ParallelRoot: parallelState.IntermediateRoot(eip158),
+		SerialGas:    serialGas,
+		ParallelGas:  parallelGas,
+	}
+
+	comparison.Differences = compareReceipts(serialReceipts, parallelReceipts)
+
+	if len(serialLogs) != len(parallelLogs) {
+		comparison.Differences = append(comparison.Differences, fmt.Sprintf("logs: %d serial, %d parallel", len(serialLogs), len(parallelLogs)))
+	}
+
+	if serialGas != parallelGas {
+		comparison.Differences = append(comparison.Differences, fmt.Sprintf("gas used: %d serial, %d parallel", serialGas, parallelGas))
+	}
+
+	if comparison.SerialRoot != comparison.ParallelRoot {
+		comparison.Differences = append(comparison.Differences, fmt.Sprintf("state root: %s serial, %s parallel", comparison.SerialRoot, comparison.ParallelRoot))
+	}
+
+	if !comparison.Diverged() {
+		return comparison, nil
+	}
+
+	var (
+		accessed = make(map[blockstm.Key]struct{})
+		writers  = make(map[blockstm.Key][]int)
+	)
+
+	for i := range block.Transactions() {
+		for _, r := range result.TxIO.ReadSet(i) {
+			accessed[r.Path] = struct{}{}
+		}
+
+		for _, w := range result.TxIO.AllWriteSet(i) {
+			accessed[w.Path] = struct{}{}
+			writers[w.Path] = append(writers[w.Path], i)
+		}
+	}
+
+	for key, txs := range writers {
+		serialValue, parallelValue := keyValue(serialState, key), keyValue(parallelState, key)
+		if serialValue == parallelValue {
+			continue
+		}
+
+		difference := ParallelKeyDifference{
+			Address:  key.GetAddress(),
+			Field:    keyField(key),
+			Serial:   serialValue,
+			Parallel: parallelValue,
+			Writers:  txs,
+		}
+
+		if key.IsState() {
+			slot := key.GetStateKey()
+			difference.Slot = &slot
+		}
+
+		comparison.Keys = append(comparison.Keys, difference)
+	}
+
+	sort.Slice(comparison.Keys, func(i, j int) bool {
+		a, b := comparison.Keys[i], comparison.Keys[j]
+		if a.Address != b.Address {
+			return bytes.Compare(a.Address[:], b.Address[:]) < 0
+		}
+
+		if a.Field != b.Field {
+			return a.Field < b.Field
+		}
+
+		// Keys without a slot sort first
+		if a.Slot == nil || b.Slot == nil {
+			return a.Slot == nil && b.Slot != nil
+		}
+
+		return bytes.Compare(a.Slot[:], b.Slot[:]) < 0
+	})
+
+	comparison.PreState = preState(parent, accessed)
+
+	return comparison, nil
+}
+
+// compareReceipts lists the differences between the receipts of the serial and
+// the parallel execution.
+func compareReceipts(serial, parallel types.Receipts) []string {
+	if len(serial) != len(parallel) {
+		return []string{fmt.Sprintf("receipts: %d serial, %d parallel", len(serial), len(parallel))}
+	}
+
+	var differences []string
+
+	for i := range serial {
+		a, b := serial[i], parallel[i]
+
+		if a.Status != b.Status {
+			differences = append(differences, fmt.Sprintf("receipt %d: status %d serial, %d parallel", i, a.Status, b.Status))
+		}
+
+		if a.GasUsed != b.GasUsed {
+			differences = append(differences, fmt.Sprintf("receipt %d: gas used %d serial, %d parallel", i, a.GasUsed, b.GasUsed))
+		}
+
+		if a.CumulativeGasUsed != b.CumulativeGasUsed {
+			differences = append(differences, fmt.Sprintf("receipt %d: cumulative gas used %d serial, %d parallel", i, a.CumulativeGasUsed, b.CumulativeGasUsed))
+		}
+
+		if a.ContractAddress != b.ContractAddress {
+			differences = append(differences, fmt.Sprintf("receipt %d: contract address %s serial, %s parallel", i, a.ContractAddress, b.ContractAddress))
+		}
+
+		if len(a.Logs) != len(b.Logs) {
+			differences = append(differences, fmt.Sprintf("receipt %d: %d logs serial, %d parallel", i, len(a.Logs), len(b.Logs)))
+			continue
+		}
+
+		for j := range a.Logs {
+			if !equalLogs(a.Logs[j], b.Logs[j]) {
+				differences = append(differences, fmt.Sprintf("receipt %d: log %d differs", i, j))
+			}
+		}
+	}
+
+	return differences
+}
+
+func equalLogs(a, b *types.Log) bool {
+	if a.Address != b.Address || a.Index != b.Index || !bytes.Equal(a.Data, b.Data) || len(a.Topics) != len(b.Topics) {
+		return false
+	}
+
+	for i := range a.Topics {
+		if a.Topics[i] != b.Topics[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// keyField names the part of the state a Block-STM key refers to.
+func keyField(key blockstm.Key) string {
+	switch {
+	case key.IsState():
+		return "storage"
+	case key.IsAddress():
+		return "account"
+	}
+
+	switch key.GetSubpath() {
+	case state.BalancePath:
+		return "balance"
+	case state.NoncePath:
+		return "nonce"
+	case state.CodePath:
+		return "code"
+	case state.SuicidePath:
+		return "selfdestruct"
+	default:
+		return fmt.Sprintf("subpath %d", key.GetSubpath())
+	}
+}
+
+// keyValue renders the value of a Block-STM key in the state.
+func keyValue(statedb *state.StateDB, key blockstm.Key) string {
+	addr := key.GetAddress()
+
+	switch {
+	case key.IsState():
+		return statedb.GetState(addr, key.GetStateKey()).Hex()
+	case key.IsAddress():
+		return fmt.Sprint(statedb.Exist(addr))
+	}
+
+	switch key.GetSubpath() {
+	case state.BalancePath:
+		return statedb.GetBalance(addr).String()
+	case state.NoncePath:
+		return fmt.Sprint(statedb.GetNonce(addr))
+	case state.CodePath:
+		return statedb.GetCodeHash(addr).Hex()
+	case state.SuicidePath:
+		return fmt.Sprint(statedb.HasSelfDestructed(addr))
+	default:
+		return ""
+	}
+}
+
+// preState extracts the accounts and storage slots of the keys from the state.
+func preState(statedb *state.StateDB, keys map[blockstm.Key]struct{}) GenesisAlloc {
+	alloc := make(GenesisAlloc)
+
+	for key := range keys {
+		addr := key.GetAddress()
+
+		account, ok := alloc[addr]
+		if !ok {
+			if !statedb.Exist(addr) {
+				continue
+			}
+
+			account = GenesisAccount{
+				Code:    statedb.GetCode(addr),
+				Balance: statedb.GetBalance(addr),
+				Nonce:   statedb.GetNonce(addr),
+			}
+		}
+
+		if key.IsState() {
+			if account.Storage == nil {
+				account.Storage = make(map[common.Hash]common.Hash)
+			}
+
+			account.Storage[key.GetStateKey()] = statedb.GetState(addr, key.GetStateKey())
+		}
+
+		alloc[addr] = account
+	}
+
+	return alloc
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/blockstm"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestCompareReceipts(t *testing.T) {
+	t.Parallel()
+
+	addr := common.HexToAddress("0x1")
+
+	serial := types.Receipts{
+		{Status: types.ReceiptStatusSuccessful, GasUsed: 21000, CumulativeGasUsed: 21000},
+		{Status: types.ReceiptStatusSuccessful, GasUsed: 30000, CumulativeGasUsed: 51000, Logs: []*types.Log{{Address: addr, Data: []byte{1}}}},
+	}
+
+	if differences := compareReceipts(serial, serial); len(differences) != 0 {
+		t.Fatalf("identical receipts differ: %v", differences)
+	}
+
+	parallel := types.Receipts{
+		{Status: types.ReceiptStatusSuccessful, GasUsed: 21000, CumulativeGasUsed: 21000},
+		{Status: types.ReceiptStatusFailed, GasUsed: 30000, CumulativeGasUsed: 51000, Logs: []*types.Log{{Address: addr, Data: []byte{2}}}},
+	}
+
+	want := []string{
+		"receipt 1: status 1 serial, 0 parallel",
+		"receipt 1: log 0 differs",
+	}
+
+	differences := compareReceipts(serial, parallel)
+	if len(differences) != len(want) {
+		t.Fatalf("differences mismatch: have %v, want %v", differences, want)
+	}
+
+	for i := range want {
+		if differences[i] != want[i] {
+			t.Errorf("difference %d mismatch: have %q, want %q", i, differences[i], want[i])
+		}
+	}
+
+	if differences := compareReceipts(serial, parallel[:1]); len(differences) != 1 {
+		t.Errorf("expected a single difference for missing receipts, have %v", differences)
+	}
+}
+
+func TestParallelPreState(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr    = common.HexToAddress("0x1")
+		missing = common.HexToAddress("0x2")
+		slot    = common.HexToHash("0x3")
+	)
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.SetBalance(addr, big.NewInt(100))
+	statedb.SetNonce(addr, 2)
+	statedb.SetState(addr, slot, common.HexToHash("0x4"))
+
+	alloc := preState(statedb, map[blockstm.Key]struct{}{
+		blockstm.NewSubpathKey(addr, state.BalancePath):  {},
+		blockstm.NewStateKey(addr, slot):                 {},
+		blockstm.NewSubpathKey(missing, state.NoncePath): {},
+	})
+
+	if len(alloc) != 1 {
+		t.Fatalf("expected a single account, have %d", len(alloc))
+	}
+
+	account := alloc[addr]
+	if account.Balance.Cmp(big.NewInt(100)) != 0 || account.Nonce != 2 {
+		t.Errorf("account mismatch: balance %v, nonce %d", account.Balance, account.Nonce)
+	}
+
+	if account.Storage[slot] != common.HexToHash("0x4") {
+		t.Errorf("storage mismatch: have %v", account.Storage)
+	}
+}
@@ -0,0 +1,170 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/blockstm"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// ParallelTxProfile is the Block-STM execution profile of a transaction.
+type ParallelTxProfile struct {
+	Index        int           `json:"index"`
+	Hash         common.Hash   `json:"hash"`
+	Incarnations int           `json:"incarnations"` // Number of times the transaction was executed
+	Aborts       int           `json:"aborts"`       // Incarnations which aborted or failed validation
+	Worker       int           `json:"worker"`       // Worker which executed the final incarnation
+	Start        time.Duration `json:"start"`        // Start of the final incarnation, since the start of the block
+	Duration     time.Duration `json:"duration"`     // Execution time of the final incarnation
+	Busy         time.Duration `json:"busy"`         // Execution time of all the incarnations
+	Wait         time.Duration `json:"wait"`         // Time spent not executing before the final incarnation completed
+	Dependencies []int         `json:"dependencies"` // Transactions whose writes were read by the transaction
+}
+
+// ParallelBlockProfile is the Block-STM execution profile of a block.
+type ParallelBlockProfile struct {
+	Number           uint64               `json:"number"`
+	Hash             common.Hash          `json:"hash"`
+	Metadata         bool                 `json:"metadata"` // Whether the dependencies of the block header were used
+	Transactions     []*ParallelTxProfile `json:"transactions"`
+	CriticalPath     []int                `json:"criticalPath"`
+	CriticalPathTime time.Duration        `json:"criticalPathTime"` // Ideal execution time with unlimited workers
+	SerialTime       time.Duration        `json:"serialTime"`       // Sum of the final incarnation execution times
+	Dot              string               `json:"dot"`              // Dependency DAG in Graphviz DOT format
+}
+
+// Profile re-executes the transactions of the block on top of statedb with
+// Block-STM profiling enabled. The block is not finalized, statedb is left in
+// the post-transactions state.
+func (p *ParallelStateProcessor) Profile(block *types.Block, statedb *state.StateDB, cfg vm.Config, numProcs int, interruptCtx context.Context) (*ParallelBlockProfile, error) {
+	_, _, _, result, err := p.process(block, statedb, cfg, true, blockstm.NewStaticScheduler(numProcs), interruptCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &ParallelBlockProfile{
+		Number:       block.NumberU64(),
+		Hash:         block.Hash(),
+		Metadata:     block.GetTxDependency() != nil,
+		Transactions: make([]*ParallelTxProfile, len(block.Transactions())),
+	}
+
+	if len(block.Transactions()) == 0 {
+		profile.Dot = profile.dot()
+		return profile, nil
+	}
+
+	var (
+		weights = make([]time.Duration, len(block.Transactions()))
+		prev    = make([]int, len(block.Transactions()))
+		last    = -1
+	)
+
+	// Dependencies always point to earlier transactions, so the critical path
+	// can be built in a single pass
+	for i, tx := range block.Transactions() {
+		stat := (*result.Stats)[i]
+
+		txProfile := &ParallelTxProfile{
+			Index:        i,
+			Hash:         tx.Hash(),
+			Incarnations: stat.Incarnation + 1,
+			Aborts:       stat.Aborts,
+			Worker:       stat.Worker,
+			Start:        time.Duration(stat.Start),
+			Duration:     time.Duration(stat.End - stat.Start),
+			Busy:         time.Duration(stat.Busy),
+			Dependencies: make([]int, 0, len(result.AllDeps[i])),
+		}
+
+		// Incarnations of a transaction may overlap, in which case it never waited
+		if stat.End > stat.Busy {
+			txProfile.Wait = time.Duration(stat.End - stat.Busy)
+		}
+
+		for dep := range result.AllDeps[i] {
+			txProfile.Dependencies = append(txProfile.Dependencies, dep)
+		}
+
+		sort.Ints(txProfile.Dependencies)
+
+		prev[i] = -1
+
+		for _, dep := range txProfile.Dependencies {
+			if weights[dep] > weights[i] {
+				weights[i], prev[i] = weights[dep], dep
+			}
+		}
+
+		weights[i] += txProfile.Duration
+
+		if last == -1 || weights[i] > weights[last] {
+			last = i
+		}
+
+		profile.SerialTime += txProfile.Duration
+		profile.Transactions[i] = txProfile
+	}
+
+	for i := last; i != -1; i = prev[i] {
+		profile.CriticalPath = append([]int{i}, profile.CriticalPath...)
+	}
+
+	if last != -1 {
+		profile.CriticalPathTime = weights[last]
+	}
+
+	profile.Dot = profile.dot()
+
+	return profile, nil
+}
+
+// dot renders the dependency DAG in Graphviz DOT format, highlighting the
+// critical path.
+func (p *ParallelBlockProfile) dot() string {
+	critical := make(map[int]bool, len(p.CriticalPath))
+	criticalEdges := make(map[[2]int]bool, len(p.CriticalPath))
+
+	for k, i := range p.CriticalPath {
+		critical[i] = true
+
+		if k > 0 {
+			criticalEdges[[2]int{p.CriticalPath[k-1], i}] = true
+		}
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "digraph \"block %d\" {\n", p.Number)
+
+	for _, tx := range p.Transactions {
+		attrs := ""
+		if critical[tx.Index] {
+			attrs = ", color=red"
+		}
+
+		fmt.Fprintf(&b, "  %d [label=\"%d\\n%s\\n%v\"%s];\n", tx.Index, tx.Index, tx.Hash.TerminalString(), tx.Duration, attrs)
+	}
+
+	for _, tx := range p.Transactions {
+		for _, dep := range tx.Dependencies {
+			attrs := ""
+			if criticalEdges[[2]int{dep, tx.Index}] {
+				attrs = " [color=red]"
+			}
+
+			fmt.Fprintf(&b, "  %d -> %d%s;\n", dep, tx.Index, attrs)
+		}
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
@@ -0,0 +1,137 @@
+package core
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"golang.org/x/exp/slices"
+)
+
+// Tests that profiling a block re-executes it with Block-STM and reports the
+// per-transaction statistics, the dependency DAG and its critical path.
+func TestParallelStateProcessorProfile(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		key2, _ = crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2   = crypto.PubkeyToAddress(key2.PublicKey)
+		gspec   = &Genesis{
+			Config:   params.TestChainConfig,
+			GasLimit: 3141592,
+			Alloc: GenesisAlloc{
+				addr1: {Balance: big.NewInt(1000000000000000)},
+			},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+
+	// Transfers from the same sender, each one depending on the previous nonce
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 1, func(i int, gen *BlockGen) {
+		for j := 0; j < 3; j++ {
+			tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(addr1), addr2, big.NewInt(1000), params.TxGas, gen.header.BaseFee, nil), signer, key1)
+			gen.AddTx(tx)
+		}
+	})
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	statedb, err := chain.State()
+	if err != nil {
+		t.Fatalf("failed to get genesis state: %v", err)
+	}
+
+	block := blocks[0]
+
+	profile, err := NewParallelStateProcessor(gspec.Config, chain, chain.engine).Profile(block, statedb, vm.Config{}, 4, context.Background())
+	if err != nil {
+		t.Fatalf("failed to profile block: %v", err)
+	}
+
+	if profile.Number != block.NumberU64() || profile.Hash != block.Hash() {
+		t.Errorf("block mismatch: have #%d [%x], want #%d [%x]", profile.Number, profile.Hash, block.NumberU64(), block.Hash())
+	}
+
+	if len(profile.Transactions) != len(block.Transactions()) {
+		t.Fatalf("transaction count mismatch: have %d, want %d", len(profile.Transactions), len(block.Transactions()))
+	}
+
+	for i, tx := range profile.Transactions {
+		if tx.Index != i || tx.Hash != block.Transactions()[i].Hash() {
+			t.Errorf("transaction %d mismatch: have %d [%x]", i, tx.Index, tx.Hash)
+		}
+
+		if tx.Incarnations < 1 || tx.Aborts >= tx.Incarnations {
+			t.Errorf("transaction %d: invalid incarnations %d with %d aborts", i, tx.Incarnations, tx.Aborts)
+		}
+
+		if tx.Busy < tx.Duration {
+			t.Errorf("transaction %d: busy time %v below final incarnation time %v", i, tx.Busy, tx.Duration)
+		}
+
+		if i > 0 && !slices.Contains(tx.Dependencies, i-1) {
+			t.Errorf("transaction %d: missing dependency on %d, have %v", i, i-1, tx.Dependencies)
+		}
+	}
+
+	if len(profile.CriticalPath) == 0 || profile.CriticalPath[len(profile.CriticalPath)-1] != 2 {
+		t.Errorf("critical path should end with the last transaction, have %v", profile.CriticalPath)
+	}
+
+	if profile.CriticalPathTime > profile.SerialTime {
+		t.Errorf("critical path time %v exceeds serial time %v", profile.CriticalPathTime, profile.SerialTime)
+	}
+
+	if !strings.Contains(profile.Dot, "  1 -> 2") {
+		t.Errorf("dot output missing dependency edge:\n%s", profile.Dot)
+	}
+
+	// The block isn't finalized, the state only holds the transaction effects
+	if balance := statedb.GetBalance(addr2); balance.Cmp(big.NewInt(3000)) != 0 {
+		t.Errorf("post-state balance mismatch: have %v, want 3000", balance)
+	}
+}
+
+func TestParallelBlockProfileDot(t *testing.T) {
+	t.Parallel()
+
+	profile := &ParallelBlockProfile{
+		Number: 1,
+		Transactions: []*ParallelTxProfile{
+			{Index: 0},
+			{Index: 1},
+			{Index: 2, Dependencies: []int{0, 1}},
+		},
+		CriticalPath: []int{1, 2},
+	}
+
+	dot := profile.dot()
+
+	for _, want := range []string{
+		"digraph \"block 1\" {\n",
+		"  0 -> 2;\n",
+		"  1 -> 2 [color=red];\n",
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("dot output missing %q:\n%s", want, dot)
+		}
+	}
+
+	// Transactions 1 and 2 and the edge between them
+	if n := strings.Count(dot, "color=red"); n != 3 {
+		t.Errorf("expected 3 highlighted elements, got %d:\n%s", n, dot)
+	}
+}
@@ -0,0 +1,119 @@
+package core
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that replaying a block with Block-STM delivers the same intermediate
+// states as applying its transactions one after another, including when a
+// transaction reads the coinbase balance and the fees can't be delayed.
+func TestReplayTransactionsParallel(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		key2, _ = crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2   = crypto.PubkeyToAddress(key2.PublicKey)
+		miner   = common.HexToAddress("0xc0ffee")
+		gspec   = &Genesis{
+			Config:   params.TestChainConfig,
+			GasLimit: 3141592,
+			Alloc: GenesisAlloc{
+				addr1: {Balance: big.NewInt(1000000000000000)},
+				addr2: {Balance: big.NewInt(1000000000000000)},
+			},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 1, func(i int, gen *BlockGen) {
+		gen.SetCoinbase(miner)
+
+		transfer := func(key, to common.Address) *types.Transaction {
+			return types.NewTransaction(gen.TxNonce(key), to, big.NewInt(1000), params.TxGas, gen.header.BaseFee, nil)
+		}
+
+		for j := 0; j < 3; j++ {
+			tx, _ := types.SignTx(transfer(addr1, addr2), signer, key1)
+			gen.AddTx(tx)
+		}
+
+		tx, _ := types.SignTx(transfer(addr2, miner), signer, key2)
+		gen.AddTx(tx)
+
+		tx, _ = types.SignTx(transfer(addr1, addr2), signer, key1)
+		gen.AddTx(tx)
+	})
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	parent, err := chain.State()
+	if err != nil {
+		t.Fatalf("failed to get genesis state: %v", err)
+	}
+
+	var (
+		block   = blocks[0]
+		serial  []common.Hash
+		statedb = parent.Copy()
+		gp      = new(GasPool).AddGas(block.GasLimit())
+		usedGas uint64
+	)
+
+	for i, tx := range block.Transactions() {
+		serial = append(serial, statedb.Copy().IntermediateRoot(true))
+
+		statedb.SetTxContext(tx.Hash(), i)
+
+		if _, err := ApplyTransaction(gspec.Config, chain, nil, gp, statedb, block.Header(), tx, &usedGas, vm.Config{}, context.Background()); err != nil {
+			t.Fatalf("failed to apply tx %d: %v", i, err)
+		}
+	}
+
+	var parallel []common.Hash
+
+	result, err := ReplayTransactionsParallel(gspec.Config, chain, parent, block.Header(), block.Transactions(), vm.Config{}, 4, func(index int, prestate *state.StateDB) {
+		if index != len(parallel) {
+			t.Errorf("pre-state %d delivered out of order, expected %d", index, len(parallel))
+		}
+
+		parallel = append(parallel, prestate.IntermediateRoot(true))
+	}, context.Background())
+	if err != nil {
+		t.Fatalf("failed to replay block: %v", err)
+	}
+
+	if len(parallel) != len(serial) {
+		t.Fatalf("pre-state count mismatch: have %d, want %d", len(parallel), len(serial))
+	}
+
+	for i := range serial {
+		if parallel[i] != serial[i] {
+			t.Errorf("pre-state %d mismatch: have %x, want %x", i, parallel[i], serial[i])
+		}
+	}
+
+	if root, want := result.State.IntermediateRoot(true), statedb.IntermediateRoot(true); root != want {
+		t.Errorf("post-state mismatch: have %x, want %x", root, want)
+	}
+
+	if result.UsedGas != usedGas {
+		t.Errorf("gas used mismatch: have %d, want %d", result.UsedGas, usedGas)
+	}
+}
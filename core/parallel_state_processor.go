@@ -38,6 +38,12 @@ import (
 type ParallelEVMConfig struct {
 	Enable               bool
 	SpeculativeProcesses int
+	Scheduler            string  // Scheduling policy of Block-STM, static or adaptive
+	MinProcesses         int     // Minimum number of speculative processes of the adaptive policy
+	MaxProcesses         int     // Maximum number of speculative processes of the adaptive policy, 0 for the number of CPUs
+	SerialThreshold      float64 // Re-executions per transaction above which the adaptive policy executes serially, 0 to disable
+	VerifyTxDependency   bool    // Compare the dependencies declared in block headers with the observed ones
+	EnforceTxDependency  bool    // Reject blocks past the parallel universe fork with unsound dependencies
 }
 
 // StateProcessor is a basic Processor, which takes care of transitioning
@@ -89,6 +95,10 @@ type ExecutionTask struct {
 	dependencies []int
 	coinbase     common.Address
 	blockContext vm.BlockContext
+
+	// onSettle, if set, is called before the task is settled, in order, while
+	// finalStateDB holds the state the transaction is applied to
+	onSettle func(task *ExecutionTask)
 }
 
 func (task *ExecutionTask) Execute(mvh *blockstm.MVHashMap, incarnation int) (err error) {
@@ -174,6 +184,10 @@ func (task *ExecutionTask) Dependencies() []int {
 }
 
 func (task *ExecutionTask) Settle() {
+	if task.onSettle != nil {
+		task.onSettle(task)
+	}
+
 	task.finalStateDB.SetTxContext(task.tx.Hash(), task.index)
 
 	coinbaseBalance := task.finalStateDB.GetBalance(task.coinbase)
@@ -261,8 +275,28 @@ var parallelizabilityTimer = metrics.NewRegisteredTimer("block/parallelizability
 // Process returns the receipts and logs accumulated during the process and
 // returns the amount of gas that was used in the process. If any of the
 // transactions failed to execute due to insufficient gas it will return an error.
-// nolint:gocognit
 func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg vm.Config, interruptCtx context.Context) (types.Receipts, []*types.Log, uint64, error) {
+	receipts, allLogs, usedGas, result, err := p.process(block, statedb, cfg, false, p.bc.parallelScheduler, interruptCtx)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	if p.bc.verifyTxDependency {
+		if err := p.verifyTxDependency(block, result.TxIO); err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
+	// Finalize the block, applying any consensus engine specific extras (e.g. block rewards)
+	p.engine.Finalize(p.bc, block.Header(), statedb, block.Transactions(), block.Uncles(), nil)
+
+	return receipts, allLogs, usedGas, nil
+}
+
+// process executes the transactions of the block with Block-STM, without
+// finalizing it.
+// nolint:gocognit
+func (p *ParallelStateProcessor) process(block *types.Block, statedb *state.StateDB, cfg vm.Config, profile bool, scheduler blockstm.Scheduler, interruptCtx context.Context) (types.Receipts, []*types.Log, uint64, *blockstm.ParallelExecutionResult, error) {
 	var (
 		receipts    types.Receipts
 		header      = block.Header()
@@ -299,7 +333,7 @@ func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.Stat
 		msg, err := TransactionToMessage(tx, types.MakeSigner(p.config, header.Number, header.Time), header.BaseFee)
 		if err != nil {
 			log.Error("error creating message", "err", err)
-			return nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+			return nil, nil, 0, nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
 		}
 
 		cleansdb := statedb.Copy()
@@ -363,8 +397,7 @@ func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.Stat
 
 	backupStateDB := statedb.Copy()
 
-	profile := false
-	result, err := blockstm.ExecuteParallel(tasks, profile, metadata, p.bc.parallelSpeculativeProcesses, interruptCtx)
+	result, err := blockstm.ExecuteWithScheduler(tasks, profile, metadata, scheduler, interruptCtx)
 
 	if err == nil && profile && result.Deps != nil {
 		_, weight := result.Deps.LongestPath(*result.Stats)
@@ -375,7 +408,9 @@ func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.Stat
 			serialWeight += (*result.Stats)[i].End - (*result.Stats)[i].Start
 		}
 
-		parallelizabilityTimer.Update(time.Duration(serialWeight * 100 / weight))
+		if weight > 0 {
+			parallelizabilityTimer.Update(time.Duration(serialWeight * 100 / weight))
+		}
 	}
 
 	for _, task := range tasks {
@@ -398,20 +433,156 @@ func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.Stat
 				t.totalUsedGas = usedGas
 			}
 
-			_, err = blockstm.ExecuteParallel(tasks, false, metadata, p.bc.parallelSpeculativeProcesses, interruptCtx)
+			result, err = blockstm.ExecuteWithScheduler(tasks, profile, metadata, scheduler, interruptCtx)
 
 			break
 		}
 	}
 
 	if err != nil {
-		return nil, nil, 0, err
+		return nil, nil, 0, nil, err
 	}
 
-	// Finalize the block, applying any consensus engine specific extras (e.g. block rewards)
-	p.engine.Finalize(p.bc, header, statedb, block.Transactions(), block.Uncles(), nil)
+	return receipts, allLogs, *usedGas, &result, nil
+}
+
+// ParallelApplyResult is the outcome of applying a batch of transactions with
+// Block-STM.
+type ParallelApplyResult struct {
+	State    *state.StateDB           // State after the batch
+	Receipts types.Receipts           // Receipts of the transactions, in order
+	Logs     []*types.Log             // Logs of the transactions, in order
+	UsedGas  uint64                   // Cumulative gas used in the block after the batch
+	TxIO     *blockstm.TxnInputOutput // Reads and writes of every transaction of the batch
+}
+
+// ApplyTransactionsParallel speculatively executes a batch of transactions with
+// Block-STM on top of statedb and settles them in the given order, as if they
+// were applied one after another starting at transaction index txIndex of the
+// block. The caller must ensure the transactions fit in the remaining block gas.
+// It fails if any of the transactions can't be applied, statedb is left untouched
+// in any case.
+func ApplyTransactionsParallel(config *params.ChainConfig, bc *BlockChain, author *common.Address, statedb *state.StateDB, header *types.Header, txs []*types.Transaction, txIndex int, usedGas uint64, cfg vm.Config, numProcs int, interruptCtx context.Context) (*ParallelApplyResult, error) {
+	return applyTransactionsParallel(config, bc, author, statedb, header, txs, txIndex, usedGas, cfg, numProcs, nil, interruptCtx)
+}
+
+// ReplayTransactionsParallel re-executes the transactions of a block with
+// Block-STM on top of statedb, the state of its parent, and calls onPreState
+// with a copy of the state every transaction is applied to, in order, as soon
+// as it is known. This allows the transactions to be processed further, e.g.
+// traced, while the rest of the block is still executing. It returns the state
+// after the transactions, statedb is left untouched.
+func ReplayTransactionsParallel(config *params.ChainConfig, chain ChainContext, statedb *state.StateDB, header *types.Header, txs []*types.Transaction, cfg vm.Config, numProcs int, onPreState func(index int, statedb *state.StateDB), interruptCtx context.Context) (*ParallelApplyResult, error) {
+	return applyTransactionsParallel(config, chain, nil, statedb, header, txs, 0, 0, cfg, numProcs, onPreState, interruptCtx)
+}
+
+func applyTransactionsParallel(config *params.ChainConfig, chain ChainContext, author *common.Address, statedb *state.StateDB, header *types.Header, txs []*types.Transaction, txIndex int, usedGas uint64, cfg vm.Config, numProcs int, onPreState func(int, *state.StateDB), interruptCtx context.Context) (*ParallelApplyResult, error) {
+	var (
+		blockHash         = header.Hash()
+		blockContext      = NewEVMBlockContext(header, chain, author)
+		coinbase          = blockContext.Coinbase
+		signer            = types.MakeSigner(config, header.Number, header.Time)
+		shouldDelayFeeCal = true
+		tasks             = make([]blockstm.ExecTask, 0, len(txs))
+	)
+
+	bc, _ := chain.(*BlockChain)
+
+	for i, tx := range txs {
+		msg, err := TransactionToMessage(tx, signer, header.BaseFee)
+		if err != nil {
+			return nil, fmt.Errorf("could not apply tx %d [%v]: %w", txIndex+i, tx.Hash().Hex(), err)
+		}
+
+		if msg.From == coinbase {
+			shouldDelayFeeCal = false
+		}
+
+		tasks = append(tasks, &ExecutionTask{
+			msg:               *msg,
+			config:            config,
+			gasLimit:          header.GasLimit,
+			blockNumber:       header.Number,
+			blockHash:         blockHash,
+			tx:                tx,
+			index:             txIndex + i,
+			cleanStateDB:      statedb.Copy(),
+			blockChain:        bc,
+			header:            header,
+			evmConfig:         cfg,
+			shouldDelayFeeCal: &shouldDelayFeeCal,
+			sender:            msg.From,
+			coinbase:          coinbase,
+			blockContext:      blockContext,
+		})
+	}
+
+	// Once a transaction settled with delayed fees has read the coinbase or
+	// burnt contract balance, the states after it are wrong until the batch is
+	// re-executed. Every pre-state is delivered exactly once, when it's right.
+	var (
+		delivered int
+		stale     bool
+	)
+
+	if onPreState != nil {
+		for _, task := range tasks {
+			task.(*ExecutionTask).onSettle = func(task *ExecutionTask) {
+				if stale {
+					return
+				}
+
+				if task.index-txIndex == delivered {
+					onPreState(task.index, task.finalStateDB.Copy())
+					delivered++
+				}
+
+				if *task.shouldDelayFeeCal && task.shouldRerunWithoutFeeDelay {
+					stale = true
+				}
+			}
+		}
+	}
+
+	execute := func() (*ParallelApplyResult, error) {
+		result := &ParallelApplyResult{State: statedb.Copy(), UsedGas: usedGas}
+
+		for _, task := range tasks {
+			task := task.(*ExecutionTask)
+			task.finalStateDB = result.State
+			task.receipts = &result.Receipts
+			task.allLogs = &result.Logs
+			task.totalUsedGas = &result.UsedGas
+		}
+
+		stale = false
+
+		parallel, err := blockstm.ExecuteParallel(tasks, false, false, numProcs, interruptCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		result.TxIO = parallel.TxIO
+
+		return result, nil
+	}
+
+	result, err := execute()
+	if err != nil {
+		return nil, err
+	}
+
+	// Transactions reading the coinbase or burnt contract balance need the fees
+	// of the previous transactions, settle them without delaying the fees
+	for _, task := range tasks {
+		if task.(*ExecutionTask).shouldRerunWithoutFeeDelay {
+			shouldDelayFeeCal = false
+
+			return execute()
+		}
+	}
 
-	return receipts, allLogs, *usedGas, nil
+	return result, nil
 }
 
 func GetDeps(txDependency [][]uint64) map[int][]int {
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/blockstm"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	txDependencyMissingMeter = metrics.NewRegisteredMeter("chain/execution/parallel/deps/missing", nil)
+	txDependencyExtraMeter   = metrics.NewRegisteredMeter("chain/execution/parallel/deps/extra", nil)
+	txDependencyUnsoundMeter = metrics.NewRegisteredMeter("chain/execution/parallel/deps/unsound", nil)
+)
+
+// TxDependencyCheck is the result of comparing the transaction dependencies
+// declared in a block header with the dependencies observed while executing it.
+// Dependencies are {tx, dependency} pairs of transaction indices.
+type TxDependencyCheck struct {
+	Number   uint64      `json:"number"`
+	Hash     common.Hash `json:"hash"`
+	Declared bool        `json:"declared"` // Whether the block header declares dependencies
+	Sound    bool        `json:"sound"`    // Whether the declared dependencies imply all the observed ones
+	Missing  [][2]int    `json:"missing"`  // Observed dependencies not implied by the declared ones
+	Extra    [][2]int    `json:"extra"`    // Declared dependencies not implied by the observed ones
+}
+
+// CheckTxDependency re-executes the transactions of the block on top of statedb
+// and compares the dependencies declared in its header with the observed ones.
+// The block is not finalized, statedb is left in the post-transactions state.
+func (p *ParallelStateProcessor) CheckTxDependency(block *types.Block, statedb *state.StateDB, cfg vm.Config, numProcs int, interruptCtx context.Context) (*TxDependencyCheck, error) {
+	declared := block.GetTxDependency()
+
+	check := &TxDependencyCheck{
+		Number:   block.NumberU64(),
+		Hash:     block.Hash(),
+		Declared: declared != nil,
+		Sound:    true,
+	}
+
+	if declared == nil {
+		return check, nil
+	}
+
+	_, _, _, result, err := p.process(block, statedb, cfg, false, blockstm.NewStaticScheduler(numProcs), interruptCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := blockstm.CheckDeps(GetDeps(declared), *result.TxIO)
+
+	check.Sound = deps.Sound()
+	check.Missing = deps.Missing
+	check.Extra = deps.Extra
+
+	return check, nil
+}
+
+// verifyTxDependency compares the dependencies declared in the block header with
+// the reads and writes observed while executing it, and rejects the block if
+// they are unsound, enforcement is enabled and the block is past the parallel
+// universe fork.
+func (p *ParallelStateProcessor) verifyTxDependency(block *types.Block, txio *blockstm.TxnInputOutput) error {
+	declared := block.GetTxDependency()
+	if declared == nil {
+		return nil
+	}
+
+	check := blockstm.CheckDeps(GetDeps(declared), *txio)
+
+	txDependencyMissingMeter.Mark(int64(len(check.Missing)))
+	txDependencyExtraMeter.Mark(int64(len(check.Extra)))
+
+	if check.Sound() {
+		return nil
+	}
+
+	txDependencyUnsoundMeter.Mark(1)
+
+	log.Warn("Unsound transaction dependency metadata", "number", block.Number(), "hash", block.Hash(), "missing", len(check.Missing), "extra", len(check.Extra))
+
+	if p.bc.enforceTxDependency && p.config.Bor != nil && p.config.Bor.IsParallelUniverse(block.Number()) {
+		return fmt.Errorf("%w: tx %d depends on tx %d (%d missing dependencies)", ErrUnsoundTxDependency, check.Missing[0][0], check.Missing[0][1], len(check.Missing))
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package rawdb
+
+import (
+	json "github.com/json-iterator/go"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// ConditionalTxPending is the status of a conditional transaction waiting
+	// in the pool
+	ConditionalTxPending = "pending"
+
+	// ConditionalTxIncluded is the status of a conditional transaction included
+	// in a block
+	ConditionalTxIncluded = "included"
+
+	// ConditionalTxExpired is the status of a conditional transaction whose
+	// block number or timestamp window passed
+	ConditionalTxExpired = "expired"
+
+	// ConditionalTxInvalidated is the status of a conditional transaction whose
+	// known accounts don't match the state anymore
+	ConditionalTxInvalidated = "invalidated"
+
+	// ConditionalTxDropped is the status of a conditional transaction which left
+	// the pool without being included, e.g. replaced or evicted
+	ConditionalTxDropped = "dropped"
+)
+
+// ConditionalTxEntry is an entry of the journal of the conditional transactions.
+// It keeps the transaction with its options, which are not part of the legacy
+// pool journal, and its last known status.
+type ConditionalTxEntry struct {
+	Hash         common.Hash              `json:"hash"`
+	Tx           hexutil.Bytes            `json:"tx"`
+	Options      *types.OptionsAA4337     `json:"options"`
+	Time         uint64                   `json:"time"` // Unix time in seconds of the submission
+	Status       string                   `json:"status"`
+	BlockNumber  uint64                   `json:"blockNumber,omitempty"`
+	BlockHash    common.Hash              `json:"blockHash,omitempty"`
+	Reason       string                   `json:"reason,omitempty"`
+	KnownAccount *types.KnownAccountError `json:"knownAccount,omitempty"` // Mismatching known account of an invalidated transaction
+}
+
+// conditionalTxKey = conditionalTxPrefix + hash
+func conditionalTxKey(hash common.Hash) []byte {
+	return append(append([]byte{}, conditionalTxPrefix...), hash.Bytes()...)
+}
+
+// WriteConditionalTx stores an entry of the conditional transaction journal
+func WriteConditionalTx(db ethdb.KeyValueWriter, entry *ConditionalTxEntry) {
+	enc, err := json.Marshal(entry)
+	if err != nil {
+		log.Error("Failed to marshal the conditional transaction", "hash", entry.Hash, "err", err)
+		return
+	}
+
+	if err := db.Put(conditionalTxKey(entry.Hash), enc); err != nil {
+		log.Error("Failed to store the conditional transaction", "hash", entry.Hash, "err", err)
+	}
+}
+
+// ReadConditionalTx retrieves an entry of the conditional transaction journal
+func ReadConditionalTx(db ethdb.KeyValueReader, hash common.Hash) *ConditionalTxEntry {
+	data, err := db.Get(conditionalTxKey(hash))
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+
+	entry := new(ConditionalTxEntry)
+	if err := json.Unmarshal(data, entry); err != nil {
+		log.Error("Unable to unmarshal the conditional transaction", "hash", hash, "err", err)
+		return nil
+	}
+
+	return entry
+}
+
+// ReadConditionalTxs retrieves all the entries of the conditional transaction
+// journal.
+func ReadConditionalTxs(db ethdb.Iteratee) []*ConditionalTxEntry {
+	var entries []*ConditionalTxEntry
+
+	it := db.NewIterator(conditionalTxPrefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		if len(it.Key()) != len(conditionalTxPrefix)+common.HashLength {
+			continue
+		}
+
+		entry := new(ConditionalTxEntry)
+		if err := json.Unmarshal(it.Value(), entry); err != nil {
+			log.Error("Unable to unmarshal the conditional transaction", "key", it.Key(), "err", err)
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// DeleteConditionalTx removes an entry of the conditional transaction journal
+func DeleteConditionalTx(db ethdb.KeyValueWriter, hash common.Hash) {
+	if err := db.Delete(conditionalTxKey(hash)); err != nil {
+		log.Error("Failed to delete the conditional transaction", "hash", hash, "err", err)
+	}
+}
@@ -0,0 +1,74 @@
+package rawdb
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Tests that the conditional transactions are stored with their options and
+// status, and can be deleted.
+func TestConditionalTxJournal(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	hash := common.HexToHash("0x01")
+	if entry := ReadConditionalTx(db, hash); entry != nil {
+		t.Fatalf("unknown transaction returned %+v", entry)
+	}
+
+	slot, actual := common.HexToHash("0x10"), common.HexToHash("0x20")
+	root := common.HexToHash("0x30")
+
+	WriteConditionalTx(db, &ConditionalTxEntry{
+		Hash: hash,
+		Tx:   []byte{0x01, 0x02},
+		Options: &types.OptionsAA4337{
+			KnownAccounts: types.KnownAccounts{
+				common.HexToAddress("0x02"): types.SingleFromHex("0x30"),
+			},
+			BlockNumberMax: big.NewInt(100),
+		},
+		Time:   1,
+		Status: ConditionalTxInvalidated,
+		KnownAccount: &types.KnownAccountError{
+			Address:  common.HexToAddress("0x02"),
+			Slot:     &slot,
+			Expected: common.HexToHash("0x21"),
+			Actual:   &actual,
+		},
+	})
+	WriteConditionalTx(db, &ConditionalTxEntry{Hash: common.HexToHash("0x02"), Time: 2, Status: ConditionalTxPending})
+
+	entry := ReadConditionalTx(db, hash)
+	if entry == nil {
+		t.Fatal("stored transaction not found")
+	}
+
+	if entry.Status != ConditionalTxInvalidated || entry.Options.BlockNumberMax.Uint64() != 100 || len(entry.Tx) != 2 {
+		t.Fatalf("entry mismatch: have %+v", entry)
+	}
+
+	if known := entry.Options.KnownAccounts[common.HexToAddress("0x02")]; known.Single == nil || *known.Single != root {
+		t.Fatalf("known accounts mismatch: have %+v", entry.Options.KnownAccounts)
+	}
+
+	if account := entry.KnownAccount; account == nil || *account.Slot != slot || *account.Actual != actual {
+		t.Fatalf("known account error mismatch: have %+v", entry.KnownAccount)
+	}
+
+	if entries := ReadConditionalTxs(db); len(entries) != 2 {
+		t.Fatalf("entry count mismatch: have %d, want 2", len(entries))
+	}
+
+	DeleteConditionalTx(db, hash)
+
+	if entry := ReadConditionalTx(db, hash); entry != nil {
+		t.Fatalf("deleted transaction returned %+v", entry)
+	}
+
+	if entries := ReadConditionalTxs(db); len(entries) != 1 || entries[0].Time != 2 {
+		t.Fatalf("remaining entries mismatch: have %+v", entries)
+	}
+}
@@ -0,0 +1,123 @@
+package rawdb
+
+import (
+	"encoding/binary"
+
+	json "github.com/json-iterator/go"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// journal is a log of JSON encoded entries stored under a prefix and keyed by
+// time, keeping only the most recent entries. The number of entries is tracked
+// separately, so appending an entry only visits the ones it evicts.
+type journal[T any] struct {
+	name     string // Name of the entries, for logging
+	prefix   []byte // prefix + time (uint64 big endian) -> entry
+	countKey []byte // Number of entries (uint64 big endian)
+	capacity uint64 // Number of most recent entries kept
+}
+
+// key = prefix + time (uint64 big endian)
+func (j *journal[T]) key(time uint64) []byte {
+	key := make([]byte, len(j.prefix)+8)
+	copy(key, j.prefix)
+	binary.BigEndian.PutUint64(key[len(j.prefix):], time)
+
+	return key
+}
+
+// write appends an entry to the journal, dropping the oldest entries beyond its
+// capacity.
+func (j *journal[T]) write(db ethdb.KeyValueStore, time uint64, entry *T) {
+	enc, err := json.Marshal(entry)
+	if err != nil {
+		log.Error("Failed to marshal the "+j.name, "err", err)
+		return
+	}
+
+	key := j.key(time)
+	count := j.count(db)
+
+	if has, _ := db.Has(key); !has {
+		count++
+	}
+
+	batch := db.NewBatch()
+
+	if err := batch.Put(key, enc); err != nil {
+		log.Error("Failed to store the "+j.name, "err", err)
+		return
+	}
+
+	if count > j.capacity {
+		it := db.NewIterator(j.prefix, nil)
+		for count > j.capacity && it.Next() {
+			if err := batch.Delete(common.CopyBytes(it.Key())); err != nil {
+				log.Error("Failed to delete the "+j.name, "err", err)
+				break
+			}
+
+			count--
+		}
+		it.Release()
+	}
+
+	if err := batch.Put(j.countKey, encodeBlockNumber(count)); err != nil {
+		log.Error("Failed to store the "+j.name+" count", "err", err)
+		return
+	}
+
+	if err := batch.Write(); err != nil {
+		log.Error("Failed to store the "+j.name, "err", err)
+	}
+}
+
+// count returns the number of entries of the journal. Journals written before
+// the count was tracked are counted once.
+func (j *journal[T]) count(db ethdb.KeyValueStore) uint64 {
+	if data, _ := db.Get(j.countKey); len(data) == 8 {
+		return binary.BigEndian.Uint64(data)
+	}
+
+	var count uint64
+
+	it := db.NewIterator(j.prefix, nil)
+	for it.Next() {
+		count++
+	}
+	it.Release()
+
+	return count
+}
+
+// read returns up to limit entries of the journal, the most recent first. A
+// limit of zero returns all of them.
+func (j *journal[T]) read(db ethdb.Iteratee, limit int) []*T {
+	var entries []*T
+
+	it := db.NewIterator(j.prefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		entry := new(T)
+		if err := json.Unmarshal(it.Value(), entry); err != nil {
+			log.Error("Unable to unmarshal the "+j.name, "key", it.Key(), "err", err)
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	for l, r := 0, len(entries)-1; l < r; l, r = l+1, r-1 {
+		entries[l], entries[r] = entries[r], entries[l]
+	}
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries
+}
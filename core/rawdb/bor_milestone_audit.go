@@ -0,0 +1,65 @@
+package rawdb
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+const (
+	// MilestoneAuditUnlock is the action of an entry recording the manual
+	// unlock of the locked milestone
+	MilestoneAuditUnlock = "unlock"
+
+	// MilestoneAuditPurgeFuture is the action of an entry recording the manual
+	// purge of the future milestone queue
+	MilestoneAuditPurgeFuture = "purge future milestones"
+
+	// maxMilestoneAuditEntries is the number of most recent entries kept in the
+	// audit log
+	maxMilestoneAuditEntries = 256
+)
+
+// FutureMilestone is an entry of the future milestone queue
+type FutureMilestone struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+}
+
+// MilestoneLockState is the lock and the future milestone queue of the
+// milestone whitelist, which decide whether reorgs are allowed.
+type MilestoneLockState struct {
+	Locked           bool              `json:"locked"`
+	LockedNumber     uint64            `json:"lockedNumber"`
+	LockedHash       common.Hash       `json:"lockedHash"`
+	LockedIDs        []string          `json:"lockedIDs"`
+	FutureMilestones []FutureMilestone `json:"futureMilestones"` // In queue order
+}
+
+// MilestoneAuditEntry is an entry of the audit log of the manual changes to the
+// milestone lock and the future milestone queue.
+type MilestoneAuditEntry struct {
+	Time   uint64              `json:"time"` // Unix time in nanoseconds
+	Action string              `json:"action"`
+	Reason string              `json:"reason"`
+	Before *MilestoneLockState `json:"before"` // State before the change
+}
+
+// milestoneAudit is the audit log of the manual changes to the milestone lock
+var milestoneAudit = &journal[MilestoneAuditEntry]{
+	name:     "milestone audit entry",
+	prefix:   milestoneAuditPrefix,
+	countKey: milestoneAuditCountKey,
+	capacity: maxMilestoneAuditEntries,
+}
+
+// WriteMilestoneAuditEntry appends an entry to the milestone audit log, dropping
+// the oldest entries beyond its capacity.
+func WriteMilestoneAuditEntry(db ethdb.KeyValueStore, entry *MilestoneAuditEntry) {
+	milestoneAudit.write(db, entry.Time, entry)
+}
+
+// ReadMilestoneAudit returns up to limit entries of the milestone audit log, the
+// most recent first. A limit of zero returns all of them.
+func ReadMilestoneAudit(db ethdb.Iteratee, limit int) []*MilestoneAuditEntry {
+	return milestoneAudit.read(db, limit)
+}
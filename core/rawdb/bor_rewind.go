@@ -0,0 +1,61 @@
+package rawdb
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+const (
+	// RewindCheckpointMismatch is the reason of a rewind caused by a checkpoint
+	// whose root hash doesn't match the local blocks
+	RewindCheckpointMismatch = "checkpoint mismatch"
+
+	// RewindMilestoneMismatch is the reason of a rewind caused by a milestone
+	// whose end block hash doesn't match the local block
+	RewindMilestoneMismatch = "milestone mismatch"
+
+	// RewindReorgRejected is the reason of a reorg rejected because it conflicts
+	// with the locked milestone
+	RewindReorgRejected = "reorg rejected"
+
+	// maxRewindEntries is the number of most recent entries kept in the journal
+	maxRewindEntries = 1024
+)
+
+// RewindEntry is an entry of the rewind journal. It records a rewind of the
+// chain caused by a mismatching checkpoint or milestone, or a reorg rejected by
+// the milestone whitelist, in which case nothing is rewound and RewindTo is the
+// head.
+type RewindEntry struct {
+	Time         uint64        `json:"time"` // Unix time in nanoseconds
+	Reason       string        `json:"reason"`
+	StartBlock   uint64        `json:"startBlock"`
+	EndBlock     uint64        `json:"endBlock"`
+	ExpectedHash string        `json:"expectedHash"` // Hash of the checkpoint or locked milestone
+	LocalHash    string        `json:"localHash"`    // Hash computed from the local (or incoming) blocks
+	Head         uint64        `json:"head"`
+	RewindTo     uint64        `json:"rewindTo"`
+	Dropped      []common.Hash `json:"dropped"`      // Dropped local blocks, or the rejected incoming ones
+	Transactions []common.Hash `json:"transactions"` // Transactions of the dropped blocks returned to the pool
+	Error        string        `json:"error,omitempty"`
+}
+
+// rewindJournal is the journal of the chain rewinds
+var rewindJournal = &journal[RewindEntry]{
+	name:     "rewind entry",
+	prefix:   rewindJournalPrefix,
+	countKey: rewindJournalCountKey,
+	capacity: maxRewindEntries,
+}
+
+// WriteRewindEntry appends an entry to the rewind journal, dropping the oldest
+// entries beyond the capacity of the journal.
+func WriteRewindEntry(db ethdb.KeyValueStore, entry *RewindEntry) {
+	rewindJournal.write(db, entry.Time, entry)
+}
+
+// ReadRewindHistory returns up to limit entries of the rewind journal, the most
+// recent first. A limit of zero returns all of them.
+func ReadRewindHistory(db ethdb.Iteratee, limit int) []*RewindEntry {
+	return rewindJournal.read(db, limit)
+}
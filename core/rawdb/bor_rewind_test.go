@@ -0,0 +1,65 @@
+package rawdb
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Tests that rewind entries are returned most recent first, and that the
+// journal only keeps the most recent entries.
+func TestRewindJournal(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	if entries := ReadRewindHistory(db, 0); len(entries) != 0 {
+		t.Fatalf("empty journal returned %d entries", len(entries))
+	}
+
+	WriteRewindEntry(db, &RewindEntry{
+		Time:         1,
+		Reason:       RewindCheckpointMismatch,
+		StartBlock:   0,
+		EndBlock:     255,
+		ExpectedHash: "0x01",
+		LocalHash:    "0x02",
+		Head:         300,
+		RewindTo:     255,
+		Dropped:      []common.Hash{common.HexToHash("0x100"), common.HexToHash("0x101")},
+		Transactions: []common.Hash{common.HexToHash("0x200")},
+	})
+	WriteRewindEntry(db, &RewindEntry{Time: 2, Reason: RewindReorgRejected, Head: 310, RewindTo: 310})
+
+	entries := ReadRewindHistory(db, 0)
+	if len(entries) != 2 {
+		t.Fatalf("entry count mismatch: have %d, want 2", len(entries))
+	}
+
+	if entries[0].Reason != RewindReorgRejected || entries[1].Reason != RewindCheckpointMismatch {
+		t.Fatalf("entries out of order: have %s, %s", entries[0].Reason, entries[1].Reason)
+	}
+
+	if len(entries[1].Dropped) != 2 || entries[1].Transactions[0] != common.HexToHash("0x200") {
+		t.Fatalf("entry mismatch: have %+v", entries[1])
+	}
+
+	if entries := ReadRewindHistory(db, 1); len(entries) != 1 || entries[0].Time != 2 {
+		t.Fatalf("limited history mismatch: have %+v", entries)
+	}
+
+	for i := 0; i < maxRewindEntries; i++ {
+		WriteRewindEntry(db, &RewindEntry{Time: uint64(3 + i), Reason: RewindMilestoneMismatch})
+	}
+
+	entries = ReadRewindHistory(db, 0)
+	if len(entries) != maxRewindEntries {
+		t.Fatalf("pruned entry count mismatch: have %d, want %d", len(entries), maxRewindEntries)
+	}
+
+	if last := entries[len(entries)-1]; last.Time != 3 {
+		t.Fatalf("oldest entry mismatch: have %d, want 3", last.Time)
+	}
+
+	if count := rewindJournal.count(db); count != maxRewindEntries {
+		t.Fatalf("tracked entry count mismatch: have %d, want %d", count, maxRewindEntries)
+	}
+}
@@ -0,0 +1,131 @@
+package rawdb
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// borStateSyncsKey = borStateSyncsPrefix + num (uint64 big endian) + hash
+func borStateSyncsKey(number uint64, hash common.Hash) []byte {
+	return append(append(borStateSyncsPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// borStateSyncLookupKey = borStateSyncLookupPrefix + state id (uint64 big endian)
+func borStateSyncLookupKey(id uint64) []byte {
+	return append(borStateSyncLookupPrefix, encodeBlockNumber(id)...)
+}
+
+// ReadBorStateSyncs retrieves the state-syncs committed in a block.
+func ReadBorStateSyncs(db ethdb.KeyValueReader, hash common.Hash, number uint64) []*types.StateSyncRecord {
+	data, _ := db.Get(borStateSyncsKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+
+	return decodeBorStateSyncs(data, hash, number)
+}
+
+func decodeBorStateSyncs(data []byte, hash common.Hash, number uint64) []*types.StateSyncRecord {
+	var records []*types.StateSyncRecord
+	if err := rlp.DecodeBytes(data, &records); err != nil {
+		log.Error("Invalid bor state-sync index RLP", "hash", hash, "number", number, "err", err)
+		return nil
+	}
+
+	for _, record := range records {
+		record.BlockNumber = number
+		record.BlockHash = hash
+	}
+
+	return records
+}
+
+// WriteBorStateSyncs stores the state-syncs committed in a block. The lookup
+// entries of their state ids are written separately, once the block is canonical.
+func WriteBorStateSyncs(db ethdb.KeyValueWriter, hash common.Hash, number uint64, records []*types.StateSyncRecord) {
+	data, err := rlp.EncodeToBytes(records)
+	if err != nil {
+		log.Crit("Failed to encode bor state-sync index", "err", err)
+	}
+
+	if err := db.Put(borStateSyncsKey(number, hash), data); err != nil {
+		log.Crit("Failed to store bor state-sync index", "err", err)
+	}
+}
+
+// WriteBorStateSyncLookupEntries points the state ids of the records to the
+// canonical block with the given number.
+func WriteBorStateSyncLookupEntries(db ethdb.KeyValueWriter, number uint64, records []*types.StateSyncRecord) {
+	for _, record := range records {
+		if err := db.Put(borStateSyncLookupKey(record.ID), encodeBlockNumber(number)); err != nil {
+			log.Crit("Failed to store bor state-sync lookup entry", "err", err)
+		}
+	}
+}
+
+// DeleteBorStateSyncs removes the state-sync index of a block. The lookup entries
+// are overwritten once the state-syncs are committed in the new canonical block.
+func DeleteBorStateSyncs(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(borStateSyncsKey(number, hash)); err != nil {
+		log.Crit("Failed to delete bor state-sync index", "err", err)
+	}
+}
+
+// ReadBorStateSync retrieves a committed state-sync by its state id from the
+// canonical chain.
+func ReadBorStateSync(db ethdb.Reader, id uint64) *types.StateSyncRecord {
+	data, _ := db.Get(borStateSyncLookupKey(id))
+	if len(data) != 8 {
+		return nil
+	}
+
+	number := binary.BigEndian.Uint64(data)
+
+	hash := ReadCanonicalHash(db, number)
+	if hash == (common.Hash{}) {
+		return nil
+	}
+
+	for _, record := range ReadBorStateSyncs(db, hash, number) {
+		if record.ID == id {
+			return record
+		}
+	}
+
+	return nil
+}
+
+// IterateBorStateSyncs calls fn with the state-syncs of every canonical block in
+// the range [from, to] which committed some, in ascending order, until fn returns
+// false.
+func IterateBorStateSyncs(db ethdb.Database, from, to uint64, fn func(records []*types.StateSyncRecord) bool) {
+	it := db.NewIterator(borStateSyncsPrefix, encodeBlockNumber(from))
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(borStateSyncsPrefix)+8+common.HashLength || !bytes.HasPrefix(key, borStateSyncsPrefix) {
+			continue
+		}
+
+		number := binary.BigEndian.Uint64(key[len(borStateSyncsPrefix):])
+		if number > to {
+			return
+		}
+
+		hash := common.BytesToHash(key[len(borStateSyncsPrefix)+8:])
+		if ReadCanonicalHash(db, number) != hash {
+			continue
+		}
+
+		if !fn(decodeBorStateSyncs(it.Value(), hash, number)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package rawdb
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Tests that the state-sync index can be stored, looked up and iterated along
+// the canonical chain.
+func TestBorStateSyncStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	contract := common.HexToAddress("0x1001")
+	canonical := map[uint64]common.Hash{
+		16: common.HexToHash("0x10"),
+		32: common.HexToHash("0x20"),
+		48: common.HexToHash("0x30"),
+	}
+
+	for number, hash := range canonical {
+		WriteCanonicalHash(db, hash, number)
+	}
+
+	canonicalRecords := map[uint64][]*types.StateSyncRecord{
+		16: {{ID: 1, Contract: contract, GasUsed: 100}, {ID: 2, GasUsed: 200}},
+		48: {{ID: 3, Contract: contract}},
+	}
+
+	for number, records := range canonicalRecords {
+		WriteBorStateSyncs(db, canonical[number], number, records)
+		WriteBorStateSyncLookupEntries(db, number, records)
+	}
+
+	// Side chain blocks are indexed, but their state ids aren't looked up
+	WriteBorStateSyncs(db, common.HexToHash("0x31"), 32, []*types.StateSyncRecord{{ID: 3}, {ID: 4}})
+
+	records := ReadBorStateSyncs(db, canonical[16], 16)
+	if len(records) != 2 {
+		t.Fatalf("state-sync count mismatch: have %d, want 2", len(records))
+	}
+
+	if records[1].GasUsed != 200 || records[1].BlockNumber != 16 || records[1].BlockHash != canonical[16] {
+		t.Fatalf("state-sync record mismatch: have %+v", records[1])
+	}
+
+	if record := ReadBorStateSync(db, 3); record == nil || record.BlockNumber != 48 || record.Contract != contract {
+		t.Fatalf("state-sync lookup mismatch: have %+v", record)
+	}
+
+	if record := ReadBorStateSync(db, 4); record != nil {
+		t.Fatalf("non-canonical state-sync returned: %+v", record)
+	}
+
+	var ids []uint64
+
+	IterateBorStateSyncs(db, 1, 40, func(records []*types.StateSyncRecord) bool {
+		for _, record := range records {
+			ids = append(ids, record.ID)
+		}
+
+		return true
+	})
+
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Fatalf("iterated state-syncs mismatch: have %v, want [1 2]", ids)
+	}
+
+	DeleteBorStateSyncs(db, canonical[16], 16)
+
+	if records := ReadBorStateSyncs(db, canonical[16], 16); records != nil {
+		t.Fatalf("deleted state-syncs returned: %v", records)
+	}
+}
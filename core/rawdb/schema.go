@@ -129,6 +129,26 @@ var (
 
 	CliqueSnapshotPrefix = []byte("clique-")
 
+	heimdallSpanPrefix       = []byte("heimdall-span-")       // heimdallSpanPrefix + span id (uint64 big endian) -> span
+	heimdallEventPrefix      = []byte("heimdall-event-")      // heimdallEventPrefix + state id (uint64 big endian) -> event record
+	heimdallCheckpointPrefix = []byte("heimdall-checkpoint-") // heimdallCheckpointPrefix + number (uint64 big endian) -> checkpoint
+
+	borStateSyncsPrefix      = []byte("matic-bor-state-syncs-")       // borStateSyncsPrefix + num (uint64 big endian) + hash -> state-syncs committed in the block
+	borStateSyncLookupPrefix = []byte("matic-bor-state-sync-lookup-") // borStateSyncLookupPrefix + state id (uint64 big endian) -> block number
+
+	// HeimdallEventCoverageKey tracks the range of state-sync events known to be complete in the heimdall cache.
+	HeimdallEventCoverageKey = []byte("heimdall-event-coverage")
+
+	rewindJournalPrefix  = []byte("RewindJournal-")  // rewindJournalPrefix + time (uint64 big endian) -> rewind entry
+	milestoneAuditPrefix = []byte("MilestoneAudit-") // milestoneAuditPrefix + time (uint64 big endian) -> milestone audit entry
+	conditionalTxPrefix  = []byte("ConditionalTx-")  // conditionalTxPrefix + hash -> conditional transaction entry
+
+	// rewindJournalCountKey tracks the number of entries of the rewind journal.
+	rewindJournalCountKey = []byte("RewindJournalCount")
+
+	// milestoneAuditCountKey tracks the number of entries of the milestone audit log.
+	milestoneAuditCountKey = []byte("MilestoneAuditCount")
+
 	preimageCounter    = metrics.NewRegisteredCounter("db/preimage/total", nil)
 	preimageHitCounter = metrics.NewRegisteredCounter("db/preimage/hits", nil)
 )
@@ -308,3 +328,18 @@ func IsStorageTrieNode(key []byte) (bool, common.Hash, []byte) {
 	accountHash := common.BytesToHash(key[len(trieNodeStoragePrefix) : len(trieNodeStoragePrefix)+common.HashLength])
 	return true, accountHash, key[len(trieNodeStoragePrefix)+common.HashLength:]
 }
+
+// HeimdallSpanKey = heimdallSpanPrefix + span id (uint64 big endian)
+func HeimdallSpanKey(id uint64) []byte {
+	return append(append([]byte{}, heimdallSpanPrefix...), encodeBlockNumber(id)...)
+}
+
+// HeimdallEventKey = heimdallEventPrefix + state id (uint64 big endian)
+func HeimdallEventKey(id uint64) []byte {
+	return append(append([]byte{}, heimdallEventPrefix...), encodeBlockNumber(id)...)
+}
+
+// HeimdallCheckpointKey = heimdallCheckpointPrefix + number (uint64 big endian)
+func HeimdallCheckpointKey(number uint64) []byte {
+	return append(append([]byte{}, heimdallCheckpointPrefix...), encodeBlockNumber(number)...)
+}
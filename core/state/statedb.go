@@ -121,6 +121,9 @@ type StateDB struct {
 	// Preimages occurred seen by VM in the scope of block.
 	preimages map[common.Hash][]byte
 
+	// Bor state-syncs committed in the scope of block.
+	stateSyncs []*types.StateSyncData
+
 	// Per-transaction access list
 	accessList *accessList
 
@@ -547,6 +550,16 @@ func (s *StateDB) Preimages() map[common.Hash][]byte {
 	return s.preimages
 }
 
+// SetStateSyncs sets the bor state-syncs committed in the block.
+func (s *StateDB) SetStateSyncs(stateSyncs []*types.StateSyncData) {
+	s.stateSyncs = stateSyncs
+}
+
+// StateSyncs returns the bor state-syncs committed in the block.
+func (s *StateDB) StateSyncs() []*types.StateSyncData {
+	return s.stateSyncs
+}
+
 // AddRefund adds gas to the refund counter
 func (s *StateDB) AddRefund(gas uint64) {
 	s.journal.append(refundChange{prev: s.refund})
@@ -1215,6 +1228,7 @@ func (s *StateDB) Copy() *StateDB {
 		logs:                 make(map[common.Hash][]*types.Log, len(s.logs)),
 		logSize:              s.logSize,
 		preimages:            make(map[common.Hash][]byte, len(s.preimages)),
+		stateSyncs:           s.stateSyncs,
 		journal:              newJournal(),
 		hasher:               crypto.NewKeccakState(),
 
@@ -1855,16 +1869,17 @@ func (s *StateDB) ValidateKnownAccounts(knownAccounts types.KnownAccounts) error
 			if trie != nil {
 				actualRootHash := trie.Hash()
 				if *v.Single != actualRootHash {
-					return fmt.Errorf("invalid root hash for: %v root hash: %v actual root hash: %v", k, v.Single, actualRootHash)
+					return &types.KnownAccountError{Address: k, Expected: *v.Single, Actual: &actualRootHash}
 				}
 			} else {
-				return fmt.Errorf("Storage Trie is nil for: %v", k)
+				return &types.KnownAccountError{Address: k, Expected: *v.Single}
 			}
 		case v.IsStorage():
 			for slot, value := range v.Storage {
 				actualValue := s.GetState(k, slot)
 				if value != actualValue {
-					return fmt.Errorf("invalid slot value at address: %v slot: %v value: %v actual value: %v", k, slot, value, actualValue)
+					slot := slot
+					return &types.KnownAccountError{Address: k, Slot: &slot, Expected: value, Actual: &actualValue}
 				}
 			}
 		default:
@@ -54,4 +54,12 @@ var (
 	// ErrFutureReplacePending is returned if a future transaction replaces a pending
 	// transaction. Future transactions should only be able to replace other future transactions.
 	ErrFutureReplacePending = errors.New("future transaction tries to replace pending")
+
+	// ErrPrivateTxUnsupported is returned if a private transaction is submitted
+	// to a subpool which can't keep it out of the p2p announcements.
+	ErrPrivateTxUnsupported = errors.New("private transactions not supported")
+
+	// ErrPrivateTxExpired is returned if a private transaction is submitted with
+	// an expiry block which isn't after the current head.
+	ErrPrivateTxExpired = errors.New("private transaction expiry block already reached")
 )
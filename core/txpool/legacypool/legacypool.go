@@ -232,6 +232,9 @@ type LegacyPool struct {
 	all     *lookup                      // All transactions to allow lookups
 	priced  *pricedList                  // All transactions sorted by price
 
+	privateMu sync.RWMutex               // Lock for the private transactions, taken after mu
+	private   map[common.Hash]*privateTx // Private transactions kept out of the announcements
+
 	reqResetCh      chan *txpoolResetRequest
 	reqPromoteCh    chan *accountSet
 	queueTxEventCh  chan *types.Transaction
@@ -265,6 +268,7 @@ func New(config Config, chain BlockChain, options ...func(pool *LegacyPool)) *Le
 		queue:           make(map[common.Address]*list),
 		beats:           make(map[common.Address]time.Time),
 		all:             newLookup(),
+		private:         make(map[common.Hash]*privateTx),
 		reqResetCh:      make(chan *txpoolResetRequest),
 		reqPromoteCh:    make(chan *accountSet),
 		queueTxEventCh:  make(chan *types.Transaction),
@@ -529,6 +533,9 @@ func (pool *LegacyPool) Pending(enforceTips bool) map[common.Address][]*txpool.L
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 
+	pool.privateMu.RLock()
+	defer pool.privateMu.RUnlock()
+
 	pending := make(map[common.Address][]*txpool.LazyTransaction, len(pool.pending))
 	for addr, list := range pool.pending {
 		txs := list.Flatten()
@@ -545,10 +552,11 @@ func (pool *LegacyPool) Pending(enforceTips bool) map[common.Address][]*txpool.L
 		if len(txs) > 0 {
 			lazies := make([]*txpool.LazyTransaction, len(txs))
 			for i := 0; i < len(txs); i++ {
+				_, private := pool.private[txs[i].Hash()]
 				lazies[i] = &txpool.LazyTransaction{
 					Pool:      pool,
 					Hash:      txs[i].Hash(),
-					Tx:        &txpool.Transaction{Tx: txs[i]},
+					Tx:        &txpool.Transaction{Tx: txs[i], Private: private},
 					Time:      txs[i].Time(),
 					GasFeeCap: txs[i].GasFeeCap(),
 					GasTipCap: txs[i].GasTipCap(),
@@ -580,6 +588,11 @@ func (pool *LegacyPool) local() map[common.Address]types.Transactions {
 		if queued := pool.queue[addr]; queued != nil {
 			txs[addr] = append(txs[addr], queued.Flatten()...)
 		}
+		// Keep the private transactions out of the journal, which is reloaded
+		// as public transactions
+		if list := txs[addr]; len(list) > 0 {
+			txs[addr] = pool.publicTxs(list)
+		}
 	}
 	return txs
 }
@@ -869,7 +882,7 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, local
 // deemed to have been sent from a local account.
 func (pool *LegacyPool) journalTx(from common.Address, tx *types.Transaction) {
 	// Only journal if it's enabled and the transaction is local
-	if pool.journal == nil || !pool.locals.contains(from) {
+	if pool.journal == nil || !pool.locals.contains(from) || pool.IsPrivate(tx.Hash()) {
 		return
 	}
 	if err := pool.journal.insert(tx); err != nil {
@@ -1060,7 +1073,7 @@ func (pool *LegacyPool) Get(hash common.Hash) *txpool.Transaction {
 	if tx == nil {
 		return nil
 	}
-	return &txpool.Transaction{Tx: tx}
+	return &txpool.Transaction{Tx: tx, Private: pool.IsPrivate(hash)}
 }
 
 // get returns a transaction if it is contained in the pool and nil otherwise.
@@ -1262,6 +1275,8 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 		// the flatten operation can be avoided.
 		promoteAddrs = dirtyAccounts.flatten()
 	}
+	var published []*types.Transaction
+
 	pool.mu.Lock()
 	if reset != nil {
 		// Reset from the old head to the new, rescheduling any reorged transactions
@@ -1303,6 +1318,11 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 			nonces[addr] = highestPending.Nonce() + 1
 		}
 		pool.pendingNonces.setAll(nonces)
+
+		// Announce or drop the private transactions whose expiry block passed
+		if reset.newHead != nil {
+			published = pool.expirePrivate(reset.newHead.Number.Uint64())
+		}
 	}
 	// Ensure pool.queue and pool.pending sizes stay within the configured limits.
 	pool.truncatePending()
@@ -1313,7 +1333,7 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 	pool.mu.Unlock()
 
 	// Notify subsystems for newly added transactions
-	for _, tx := range promoted {
+	for _, tx := range append(promoted, published...) {
 		addr, _ := types.Sender(pool.signer, tx)
 		if _, ok := events[addr]; !ok {
 			events[addr] = newSortedMap()
@@ -1325,7 +1345,10 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 		for _, set := range events {
 			txs = append(txs, set.Flatten()...)
 		}
-		pool.txFeed.Send(core.NewTxsEvent{Txs: txs})
+		// Private transactions are only offered to the local miner
+		if txs = pool.publicTxs(txs); len(txs) > 0 {
+			pool.txFeed.Send(core.NewTxsEvent{Txs: txs})
+		}
 	}
 }
 
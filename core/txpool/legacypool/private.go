@@ -0,0 +1,138 @@
+package legacypool
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	privateTxMeter       = metrics.NewRegisteredMeter("txpool/private", nil)
+	privateFallbackMeter = metrics.NewRegisteredMeter("txpool/private/fallback", nil) // Announced after the expiry block
+	privateExpiredMeter  = metrics.NewRegisteredMeter("txpool/private/expired", nil)  // Dropped after the expiry block
+)
+
+// privateTx is a transaction offered to the local miner only, and kept out of
+// the p2p announcements until its expiry block.
+type privateTx struct {
+	expiry   uint64 // Last block the transaction is kept private for
+	fallback bool   // Whether the transaction is announced, rather than dropped, after the expiry
+}
+
+// AddPrivate adds a private transaction to the pool. Until the expiry block,
+// the transaction is only offered to the local miner: it's neither announced
+// nor served to the peers, nor journaled. Afterwards, it's announced like any
+// other transaction if fallback is set, otherwise it's dropped.
+func (pool *LegacyPool) AddPrivate(tx *types.Transaction, expiry uint64, fallback bool) error {
+	hash := tx.Hash()
+
+	if head := pool.currentHead.Load(); head != nil && head.Number.Uint64() >= expiry {
+		return txpool.ErrPrivateTxExpired
+	}
+
+	// Mark the transaction before adding it, so that it's never announced
+	pool.privateMu.Lock()
+	if _, ok := pool.private[hash]; ok || pool.all.Get(hash) != nil {
+		pool.privateMu.Unlock()
+		return ErrAlreadyKnown
+	}
+	pool.private[hash] = &privateTx{expiry: expiry, fallback: fallback}
+	pool.privateMu.Unlock()
+
+	if err := pool.addTxs([]*types.Transaction{tx}, !pool.config.NoLocals, true)[0]; err != nil {
+		pool.privateMu.Lock()
+		delete(pool.private, hash)
+		pool.privateMu.Unlock()
+
+		return err
+	}
+
+	privateTxMeter.Mark(1)
+
+	return nil
+}
+
+// IsPrivate returns whether the transaction is kept out of the p2p announcements.
+func (pool *LegacyPool) IsPrivate(hash common.Hash) bool {
+	pool.privateMu.RLock()
+	defer pool.privateMu.RUnlock()
+
+	_, ok := pool.private[hash]
+
+	return ok
+}
+
+// publicTxs returns the given transactions without the private ones.
+func (pool *LegacyPool) publicTxs(txs []*types.Transaction) []*types.Transaction {
+	pool.privateMu.RLock()
+	defer pool.privateMu.RUnlock()
+
+	if len(pool.private) == 0 {
+		return txs
+	}
+
+	public := make([]*types.Transaction, 0, len(txs))
+
+	for _, tx := range txs {
+		if _, ok := pool.private[tx.Hash()]; !ok {
+			public = append(public, tx)
+		}
+	}
+
+	return public
+}
+
+// expirePrivate forgets the private transactions which left the pool, and the
+// ones whose expiry block is reached: they are dropped, or returned to be
+// announced if they fall back to the public broadcast and are executable.
+//
+// The pool lock must be held.
+func (pool *LegacyPool) expirePrivate(number uint64) []*types.Transaction {
+	pool.privateMu.Lock()
+
+	var (
+		published []*types.Transaction
+		dropped   []common.Hash
+	)
+
+	for hash, private := range pool.private {
+		tx := pool.all.Get(hash)
+		if tx == nil {
+			// Included, replaced or evicted
+			delete(pool.private, hash)
+			continue
+		}
+
+		if number < private.expiry {
+			continue
+		}
+
+		delete(pool.private, hash)
+
+		if !private.fallback {
+			dropped = append(dropped, hash)
+			continue
+		}
+
+		// Queued transactions are announced once promoted
+		from, _ := types.Sender(pool.signer, tx) // already validated
+		if list := pool.pending[from]; list != nil && list.txs.Get(tx.Nonce()) != nil {
+			published = append(published, tx)
+		}
+
+		privateFallbackMeter.Mark(1)
+		log.Debug("Announcing expired private transaction", "hash", hash, "expiry", private.expiry)
+	}
+	pool.privateMu.Unlock()
+
+	for _, hash := range dropped {
+		pool.removeTx(hash, true, true)
+
+		privateExpiredMeter.Mark(1)
+		log.Debug("Dropped expired private transaction", "hash", hash)
+	}
+
+	return published
+}
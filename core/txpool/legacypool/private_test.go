@@ -0,0 +1,113 @@
+package legacypool
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// privateTestHead returns a head at the given number whose base fee stays the same.
+func privateTestHead(number int64) *types.Header {
+	return &types.Header{
+		Number:   big.NewInt(number),
+		GasLimit: 10000000,
+		GasUsed:  5000000,
+		BaseFee:  big.NewInt(1),
+	}
+}
+
+// Tests that private transactions are kept out of the announcements until their
+// expiry block, and are then announced or dropped.
+func TestPrivateTransactions(t *testing.T) {
+	t.Parallel()
+
+	pool, fallbackKey := setupPool()
+	defer pool.Close()
+
+	dropKey, _ := crypto.GenerateKey()
+	publicKey, _ := crypto.GenerateKey()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(fallbackKey.PublicKey), big.NewInt(1000000))
+	testAddBalance(pool, crypto.PubkeyToAddress(dropKey.PublicKey), big.NewInt(1000000))
+	testAddBalance(pool, crypto.PubkeyToAddress(publicKey.PublicKey), big.NewInt(1000000))
+
+	events := make(chan core.NewTxsEvent, 32)
+	sub := pool.txFeed.Subscribe(events)
+	defer sub.Unsubscribe()
+
+	fallbackTx := transaction(0, 100000, fallbackKey)
+	dropTx := transaction(0, 100000, dropKey)
+
+	if err := pool.AddPrivate(fallbackTx, 2, true); err != nil {
+		t.Fatalf("failed to add private transaction: %v", err)
+	}
+
+	if err := pool.AddPrivate(dropTx, 2, false); err != nil {
+		t.Fatalf("failed to add private transaction: %v", err)
+	}
+
+	if err := pool.AddPrivate(fallbackTx, 2, true); !errors.Is(err, ErrAlreadyKnown) {
+		t.Fatalf("duplicate private transaction error mismatch: have %v, want %v", err, ErrAlreadyKnown)
+	}
+
+	if err := pool.AddPrivate(transaction(1, 100000, dropKey), 0, false); !errors.Is(err, txpool.ErrPrivateTxExpired) {
+		t.Fatalf("expired private transaction error mismatch: have %v, want %v", err, txpool.ErrPrivateTxExpired)
+	}
+
+	if err := pool.addRemoteSync(transaction(0, 100000, publicKey)); err != nil {
+		t.Fatalf("failed to add public transaction: %v", err)
+	}
+
+	// Only the public transaction is announced
+	if err := validateEvents(events, 1); err != nil {
+		t.Fatalf("announcement mismatch: %v", err)
+	}
+
+	if pending, _ := pool.Stats(); pending != 3 {
+		t.Fatalf("pending transactions mismatch: have %d, want 3", pending)
+	}
+
+	if tx := pool.Get(fallbackTx.Hash()); tx == nil || !tx.Private {
+		t.Fatalf("private transaction mismatch: have %+v", tx)
+	}
+
+	// The miner is told which pending transactions are private
+	for _, txs := range pool.Pending(false) {
+		for _, tx := range txs {
+			if want := tx.Hash == fallbackTx.Hash() || tx.Hash == dropTx.Hash(); tx.Tx.Private != want {
+				t.Fatalf("pending transaction %x private mismatch: have %v, want %v", tx.Hash, tx.Tx.Private, want)
+			}
+		}
+	}
+
+	// Before the expiry block, the transactions stay private
+	<-pool.requestReset(nil, privateTestHead(1))
+
+	if err := validateEvents(events, 0); err != nil {
+		t.Fatalf("announcement mismatch before the expiry: %v", err)
+	}
+
+	// At the expiry block, one transaction is announced and the other dropped
+	<-pool.requestReset(nil, privateTestHead(2))
+
+	if err := validateEvents(events, 1); err != nil {
+		t.Fatalf("announcement mismatch at the expiry: %v", err)
+	}
+
+	if tx := pool.Get(fallbackTx.Hash()); tx == nil || tx.Private {
+		t.Fatalf("fallback transaction mismatch: have %+v", tx)
+	}
+
+	if tx := pool.Get(dropTx.Hash()); tx != nil {
+		t.Fatalf("expired private transaction not dropped")
+	}
+
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
@@ -35,6 +35,8 @@ type Transaction struct {
 	BlobTxBlobs   []kzg4844.Blob       // Blobs needed by the blob pool
 	BlobTxCommits []kzg4844.Commitment // Commitments needed by the blob pool
 	BlobTxProofs  []kzg4844.Proof      // Proofs needed by the blob pool
+
+	Private bool // Whether the transaction is kept out of the p2p announcements
 }
 
 // LazyTransaction contains a small subset of the transaction properties that is
@@ -63,6 +65,16 @@ func (ltx *LazyTransaction) Resolve() *Transaction {
 // may request (and relinquish) exclusive access to certain addresses.
 type AddressReserver func(addr common.Address, reserve bool) error
 
+// PrivateSubPool is a subpool accepting private transactions, which are only
+// offered to the local miner and kept out of the p2p announcements until their
+// expiry block.
+type PrivateSubPool interface {
+	// AddPrivate adds a private transaction to the pool. After the expiry block,
+	// the transaction is announced to the network if fallback is set, otherwise
+	// it's dropped.
+	AddPrivate(tx *types.Transaction, expiry uint64, fallback bool) error
+}
+
 // SubPool represents a specialized transaction pool that lives on its own (e.g.
 // blob pool). Since independent of how many specialized pools we have, they do
 // need to be updated in lockstep and assemble into one coherent view for block
@@ -304,6 +304,25 @@ func (p *TxPool) Add(txs []*Transaction, local bool, sync bool) []error {
 	return errs
 }
 
+// AddPrivate adds a private transaction to the subpool accepting it. The
+// transaction is only offered to the local miner until the expiry block.
+func (p *TxPool) AddPrivate(tx *types.Transaction, expiry uint64, fallback bool) error {
+	for _, subpool := range p.subpools {
+		if !subpool.Filter(tx) {
+			continue
+		}
+
+		private, ok := subpool.(PrivateSubPool)
+		if !ok {
+			return ErrPrivateTxUnsupported
+		}
+
+		return private.AddPrivate(tx, expiry, fallback)
+	}
+
+	return core.ErrTxTypeNotSupported
+}
+
 // Pending retrieves all currently processable transactions, grouped by origin
 // account and sorted by nonce.
 func (p *TxPool) Pending(enforceTips bool) map[common.Address][]*LazyTransaction {
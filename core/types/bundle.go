@@ -0,0 +1,62 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Bundle is an ordered list of transactions the local miner includes
+// all-or-nothing at the top of a block, within an optional block and
+// timestamp range like the conditional transactions (EIP-4337).
+type Bundle struct {
+	Txs               Transactions
+	BlockNumberMin    *big.Int
+	BlockNumberMax    *big.Int
+	TimestampMin      *uint64
+	TimestampMax      *uint64
+	RevertingTxHashes []common.Hash // Transactions allowed to revert without failing the bundle
+}
+
+// Hash returns the hash of the transaction hashes of the bundle.
+func (b *Bundle) Hash() common.Hash {
+	hashes := make([]byte, 0, len(b.Txs)*common.HashLength)
+	for _, tx := range b.Txs {
+		hashes = append(hashes, tx.Hash().Bytes()...)
+	}
+
+	return crypto.Keccak256Hash(hashes)
+}
+
+// CanRevert returns whether the transaction is allowed to revert without
+// failing the bundle.
+func (b *Bundle) CanRevert(hash common.Hash) bool {
+	for _, reverting := range b.RevertingTxHashes {
+		if reverting == hash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateRange validates that the block of the header is in the block and
+// timestamp range of the bundle.
+func (b *Bundle) ValidateRange(header *Header) error {
+	if err := header.ValidateBlockNumberOptions4337(b.BlockNumberMin, b.BlockNumberMax); err != nil {
+		return err
+	}
+
+	return header.ValidateTimestampOptions4337(b.TimestampMin, b.TimestampMax)
+}
+
+// Expired returns whether the block and timestamp range of the bundle ended
+// before the block of the header.
+func (b *Bundle) Expired(header *Header) bool {
+	if b.BlockNumberMax != nil && header.Number.Cmp(b.BlockNumberMax) > 0 {
+		return true
+	}
+
+	return b.TimestampMax != nil && header.Time > *b.TimestampMax
+}
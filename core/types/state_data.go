@@ -8,4 +8,27 @@ type StateSyncData struct {
 	Contract common.Address
 	Data     string
 	TxHash   common.Hash
+	GasUsed  uint64 // Gas used by the commit, set once the state was committed
+}
+
+// StateSyncRecord is the indexed summary of a state-sync committed in a block.
+type StateSyncRecord struct {
+	ID       uint64         `json:"id"`
+	Contract common.Address `json:"contract"`
+	TxHash   common.Hash    `json:"txHash"`
+	GasUsed  uint64         `json:"gasUsed"`
+
+	// Position of the record, derived when reading it
+	BlockNumber uint64      `json:"blockNumber" rlp:"-"`
+	BlockHash   common.Hash `json:"blockHash" rlp:"-"`
+}
+
+// NewStateSyncRecord creates the index record of a committed state-sync.
+func NewStateSyncRecord(data *StateSyncData) *StateSyncRecord {
+	return &StateSyncRecord{
+		ID:       data.ID,
+		Contract: data.Contract,
+		TxHash:   data.TxHash,
+		GasUsed:  data.GasUsed,
+	}
 }
@@ -122,6 +122,26 @@ type OptionsAA4337 struct {
 
 var ErrKnownAccounts = errors.New("an incorrect list of knownAccounts")
 
+// KnownAccountError is returned when a known account of a conditional
+// transaction doesn't match the state.
+type KnownAccountError struct {
+	Address  common.Address `json:"address"`
+	Slot     *common.Hash   `json:"slot,omitempty"` // Mismatching storage slot, nil for the storage root
+	Expected common.Hash    `json:"expected"`
+	Actual   *common.Hash   `json:"actual"` // Nil if the account has no storage trie
+}
+
+func (e *KnownAccountError) Error() string {
+	switch {
+	case e.Actual == nil:
+		return fmt.Sprintf("Storage Trie is nil for: %v", e.Address)
+	case e.Slot != nil:
+		return fmt.Sprintf("invalid slot value at address: %v slot: %v value: %v actual value: %v", e.Address, *e.Slot, e.Expected, *e.Actual)
+	default:
+		return fmt.Sprintf("invalid root hash for: %v root hash: %v actual root hash: %v", e.Address, e.Expected, *e.Actual)
+	}
+}
+
 func (ka KnownAccounts) ValidateLength() error {
 	if ka == nil {
 		return nil
@@ -0,0 +1,89 @@
+package eth
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+var (
+	errMilestoneLockUnavailable = errors.New("milestone whitelist not available")
+	errMilestoneAuditReason     = errors.New("a reason is required for the audit log")
+)
+
+// milestoneLockManager is the part of the milestone whitelist managing the
+// milestone lock and the future milestone queue
+type milestoneLockManager interface {
+	GetLockState() *rawdb.MilestoneLockState
+	ForceUnlock(reason string) *rawdb.MilestoneAuditEntry
+	PurgeFutureMilestones(reason string) *rawdb.MilestoneAuditEntry
+	GetMilestoneAudit(limit int) []*rawdb.MilestoneAuditEntry
+}
+
+func (api *AdminAPI) milestoneLock() (milestoneLockManager, error) {
+	lock, ok := api.eth.Downloader().ChainValidator.(milestoneLockManager)
+	if !ok {
+		return nil, errMilestoneLockUnavailable
+	}
+
+	return lock, nil
+}
+
+// GetMilestoneLock returns the locked milestone, the milestone IDs voted on and
+// the future milestone queue, which decide whether reorgs are allowed.
+func (api *AdminAPI) GetMilestoneLock() (*rawdb.MilestoneLockState, error) {
+	lock, err := api.milestoneLock()
+	if err != nil {
+		return nil, err
+	}
+
+	return lock.GetLockState(), nil
+}
+
+// GetMilestoneAudit returns the most recent manual changes to the milestone lock
+// and the future milestone queue, the most recent first. All of them are
+// returned if limit is nil or zero.
+func (api *AdminAPI) GetMilestoneAudit(limit *int) ([]*rawdb.MilestoneAuditEntry, error) {
+	lock, err := api.milestoneLock()
+	if err != nil {
+		return nil, err
+	}
+
+	n := 0
+	if limit != nil {
+		n = *limit
+	}
+
+	return lock.GetMilestoneAudit(n), nil
+}
+
+// UnlockMilestone unlocks the locked milestone and drops the milestone IDs
+// voted on, to recover a validator stuck on a milestone which never got
+// finalized. It returns the audit entry recording the state before the unlock.
+func (api *AdminAPI) UnlockMilestone(reason string) (*rawdb.MilestoneAuditEntry, error) {
+	if reason == "" {
+		return nil, errMilestoneAuditReason
+	}
+
+	lock, err := api.milestoneLock()
+	if err != nil {
+		return nil, err
+	}
+
+	return lock.ForceUnlock(reason), nil
+}
+
+// PurgeFutureMilestones drops the future milestone queue. It returns the audit
+// entry recording the queue before the purge.
+func (api *AdminAPI) PurgeFutureMilestones(reason string) (*rawdb.MilestoneAuditEntry, error) {
+	if reason == "" {
+		return nil, errMilestoneAuditReason
+	}
+
+	lock, err := api.milestoneLock()
+	if err != nil {
+		return nil, err
+	}
+
+	return lock.PurgeFutureMilestones(reason), nil
+}
@@ -333,6 +333,22 @@ func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction)
 	return b.eth.txPool.Add([]*txpool.Transaction{{Tx: signedTx}}, true, false)[0]
 }
 
+func (b *EthAPIBackend) SendPrivateTx(ctx context.Context, signedTx *types.Transaction, expiry uint64, fallback bool) error {
+	if !b.eth.Miner().GetWorker().IsRunning() {
+		return errors.New("private transactions are only offered to the local miner, which is not running")
+	}
+
+	return b.eth.txPool.AddPrivate(signedTx, expiry, fallback)
+}
+
+func (b *EthAPIBackend) SendBundle(ctx context.Context, bundle *types.Bundle) error {
+	if !b.eth.Miner().GetWorker().IsRunning() {
+		return errors.New("bundles are only included by the local miner, which is not running")
+	}
+
+	return b.eth.Miner().AddBundle(bundle)
+}
+
 func (b *EthAPIBackend) GetPoolTransactions() (types.Transactions, error) {
 	pending := b.eth.txPool.Pending(false)
 
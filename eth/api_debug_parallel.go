@@ -0,0 +1,93 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// parallelReexec is the number of blocks re-executed to regenerate a missing
+// state before re-executing a block with Block-STM.
+const parallelReexec = 128
+
+// ProfileParallelBlock re-executes a block through the parallel state processor
+// with Block-STM profiling enabled, and returns the incarnations, aborts and
+// timings of every transaction, the dependency DAG and its critical path.
+func (api *DebugAPI) ProfileParallelBlock(ctx context.Context, blockNr rpc.BlockNumber) (*core.ParallelBlockProfile, error) {
+	block, statedb, release, err := api.parallelBlockState(ctx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	processor := core.NewParallelStateProcessor(api.eth.blockchain.Config(), api.eth.blockchain, api.eth.engine)
+
+	return processor.Profile(block, statedb, *api.eth.blockchain.GetVMConfig(), api.parallelProcs(), ctx)
+}
+
+// CheckTxDependency re-executes a block through the parallel state processor and
+// compares the transaction dependencies declared in its header with the ones
+// observed during execution.
+func (api *DebugAPI) CheckTxDependency(ctx context.Context, blockNr rpc.BlockNumber) (*core.TxDependencyCheck, error) {
+	block, statedb, release, err := api.parallelBlockState(ctx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	processor := core.NewParallelStateProcessor(api.eth.blockchain.Config(), api.eth.blockchain, api.eth.engine)
+
+	return processor.CheckTxDependency(block, statedb, *api.eth.blockchain.GetVMConfig(), api.parallelProcs(), ctx)
+}
+
+// parallelBlockState returns a block along with the state of its parent.
+func (api *DebugAPI) parallelBlockState(ctx context.Context, blockNr rpc.BlockNumber) (*types.Block, *state.StateDB, tracers.StateReleaseFunc, error) {
+	var block *types.Block
+
+	switch blockNr {
+	case rpc.PendingBlockNumber:
+		return nil, nil, nil, errors.New("pending block can't be re-executed")
+	case rpc.LatestBlockNumber:
+		header := api.eth.blockchain.CurrentBlock()
+		block = api.eth.blockchain.GetBlock(header.Hash(), header.Number.Uint64())
+	default:
+		block = api.eth.blockchain.GetBlockByNumber(uint64(blockNr))
+	}
+
+	if block == nil {
+		return nil, nil, nil, fmt.Errorf("block #%d not found", blockNr)
+	}
+
+	if block.NumberU64() == 0 {
+		return nil, nil, nil, errors.New("genesis is not executable")
+	}
+
+	parent := api.eth.blockchain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, nil, nil, fmt.Errorf("parent %#x not found", block.ParentHash())
+	}
+
+	statedb, release, err := api.eth.StateAtBlock(ctx, parent, parallelReexec, nil, true, false)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return block, statedb, release, nil
+}
+
+// parallelProcs returns the number of speculative processes to re-execute
+// blocks with, as configured for Block-STM.
+func (api *DebugAPI) parallelProcs() int {
+	if procs := api.eth.config.ParallelEVM.SpeculativeProcesses; procs > 0 {
+		return procs
+	}
+
+	return runtime.NumCPU()
+}
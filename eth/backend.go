@@ -33,8 +33,11 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/consensus/bor"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/blockstm"
 	"github.com/ethereum/go-ethereum/core/bloombits"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state/pruner"
@@ -109,6 +112,10 @@ type Ethereum struct {
 
 	closeCh chan struct{} // Channel to signal the background processes to exit
 
+	finalityFeed event.Feed             // Feed of whitelisted checkpoints and milestones
+	finalityLock sync.Mutex             // Protects lastFinality
+	lastFinality map[string]common.Hash // End block hash of the last finality event posted, by type
+
 	shutdownTracker *shutdowncheck.ShutdownTracker // Tracks if and when the node has shutdown ungracefully
 }
 
@@ -248,6 +255,22 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		return nil, err
 	}
 
+	if config.ParallelEVM.Enable {
+		maxProcs := config.ParallelEVM.MaxProcesses
+		if maxProcs == 0 {
+			maxProcs = runtime.NumCPU()
+		}
+
+		scheduler, err := blockstm.NewScheduler(config.ParallelEVM.Scheduler, config.ParallelEVM.MinProcesses, config.ParallelEVM.SpeculativeProcesses, maxProcs, config.ParallelEVM.SerialThreshold)
+		if err != nil {
+			return nil, err
+		}
+
+		eth.blockchain.SetParallelScheduler(scheduler)
+	}
+
+	eth.blockchain.SetTxDependencyCheck(config.ParallelEVM.VerifyTxDependency, config.ParallelEVM.EnforceTxDependency)
+
 	_ = eth.engine.VerifyHeader(eth.blockchain, eth.blockchain.CurrentHeader()) // TODO think on it
 
 	// BOR changes
@@ -269,6 +292,8 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if err != nil {
 		return nil, err
 	}
+	eth.reloadConditionalTxs()
+
 	// Permit the downloader to use the trie cache allowance during fast sync
 	cacheLimit := cacheConfig.TrieCleanLimit + cacheConfig.TrieDirtyLimit + cacheConfig.SnapshotLimit
 	if eth.handler, err = newHandler(&handlerConfig{
@@ -288,6 +313,18 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		return nil, err
 	}
 
+	policy := core.NewFinalityPolicy(config.FinalityPolicy, checker)
+	eth.blockchain.SetFinalityPolicy(policy)
+	eth.handler.downloader.SetFinalityPolicy(policy)
+
+	if config.FinalitySync {
+		if engine, ok := eth.engine.(*bor.Bor); ok && engine.HeimdallClient != nil && config.SyncMode != downloader.LightSync {
+			eth.handler.downloader.SetFinalitySource(&heimdallFinality{bor: engine})
+		} else {
+			log.Warn("Finality sync requires full or snap sync and heimdall, falling back to regular sync")
+		}
+	}
+
 	eth.miner = miner.New(eth, &config.Miner, eth.blockchain.Config(), eth.EventMux(), eth.engine, eth.isLocalBlock)
 	eth.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
 
@@ -619,11 +656,25 @@ func (s *Ethereum) Start() error {
 	// Start the networking layer and the light server if requested
 	s.handler.Start(maxPeers)
 
-	go s.startCheckpointWhitelistService()
-	go s.startMilestoneWhitelistService()
+	// Checkpoints and milestones are pushed by heimdall clients supporting it,
+	// and polled otherwise
+	if !s.subscribeHeimdallFinality() {
+		go s.startCheckpointWhitelistService()
+		go s.startMilestoneWhitelistService()
+	}
+
 	go s.startNoAckMilestoneService()
 	go s.startNoAckMilestoneByIDService()
 
+	// Conditional transactions are tracked until their status is final. Subscribe
+	// before returning, the pool refuses subscriptions once it's stopped.
+	headCh := make(chan core.ChainHeadEvent, 16)
+	headSub := s.blockchain.SubscribeChainHeadEvent(headCh)
+	txCh := make(chan core.NewTxsEvent, 256)
+	txSub := s.txPool.SubscribeNewTxsEvent(txCh)
+
+	go s.trackConditionalTxs(headCh, headSub, txCh, txSub)
+
 	return nil
 }
 
@@ -730,7 +781,13 @@ func (s *Ethereum) handleWhitelistCheckpoint(ctx context.Context, ethHandler *et
 	// Create a new bor verifier, which will be used to verify checkpoints and milestones
 	verifier := newBorVerifier()
 
-	blockNum, blockHash, err := ethHandler.fetchWhitelistCheckpoint(ctx, bor, s, verifier)
+	finality, err := ethHandler.fetchWhitelistCheckpoint(ctx, bor, s, verifier)
+
+	return s.processWhitelistCheckpoint(ethHandler, finality, err)
+}
+
+// processWhitelistCheckpoint adds a verified checkpoint to the whitelist.
+func (s *Ethereum) processWhitelistCheckpoint(ethHandler *ethHandler, finality *core.FinalityEvent, err error) error {
 	// If the array is empty, we're bound to receive an error. Non-nill error and non-empty array
 	// means that array has partial elements and it failed for some block. We'll add those partial
 	// elements anyway.
@@ -738,7 +795,8 @@ func (s *Ethereum) handleWhitelistCheckpoint(ctx context.Context, ethHandler *et
 		return err
 	}
 
-	ethHandler.downloader.ProcessCheckpoint(blockNum, blockHash)
+	ethHandler.downloader.ProcessCheckpoint(finality.EndBlock, finality.Hash)
+	s.postFinality(*finality)
 
 	return nil
 }
@@ -749,13 +807,18 @@ type heimdallHandler func(ctx context.Context, ethHandler *ethHandler, bor *bor.
 func (s *Ethereum) handleMilestone(ctx context.Context, ethHandler *ethHandler, bor *bor.Bor) error {
 	// Create a new bor verifier, which will be used to verify checkpoints and milestones
 	verifier := newBorVerifier()
-	num, hash, err := ethHandler.fetchWhitelistMilestone(ctx, bor, s, verifier)
+	finality, err := ethHandler.fetchWhitelistMilestone(ctx, bor, s, verifier)
+
+	return s.processMilestone(ethHandler, finality, err)
+}
 
+// processMilestone adds a verified milestone to the whitelist.
+func (s *Ethereum) processMilestone(ethHandler *ethHandler, finality *core.FinalityEvent, err error) error {
 	// If the current chain head is behind the received milestone, add it to the future milestone
 	// list. Also, the hash mismatch (end block hash) error will lead to rewind so also
 	// add that milestone to the future milestone list.
 	if errors.Is(err, errMissingBlocks) || errors.Is(err, errHashMismatch) {
-		ethHandler.downloader.ProcessFutureMilestone(num, hash)
+		ethHandler.downloader.ProcessFutureMilestone(finality.EndBlock, finality.Hash)
 	}
 
 	if errors.Is(err, heimdall.ErrServiceUnavailable) {
@@ -766,11 +829,33 @@ func (s *Ethereum) handleMilestone(ctx context.Context, ethHandler *ethHandler,
 		return err
 	}
 
-	ethHandler.downloader.ProcessMilestone(num, hash)
+	ethHandler.downloader.ProcessMilestone(finality.EndBlock, finality.Hash)
+	s.postFinality(*finality)
 
 	return nil
 }
 
+// postFinality notifies the subscribers of a whitelisted checkpoint or milestone,
+// unless it was already posted. Checkpoints and milestones are polled, so the
+// latest one is usually processed several times.
+func (s *Ethereum) postFinality(finality core.FinalityEvent) {
+	s.finalityLock.Lock()
+
+	if s.lastFinality == nil {
+		s.lastFinality = make(map[string]common.Hash)
+	}
+
+	if s.lastFinality[finality.Type] == finality.Hash {
+		s.finalityLock.Unlock()
+		return
+	}
+
+	s.lastFinality[finality.Type] = finality.Hash
+	s.finalityLock.Unlock()
+
+	s.finalityFeed.Send(finality)
+}
+
 func (s *Ethereum) handleNoAckMilestone(ctx context.Context, ethHandler *ethHandler, bor *bor.Bor) error {
 	milestoneID, err := ethHandler.fetchNoAckMilestone(ctx, bor)
 
@@ -801,6 +886,102 @@ func (s *Ethereum) handleNoAckMilestoneByID(ctx context.Context, ethHandler *eth
 	return nil
 }
 
+// subscribeHeimdallFinality processes new checkpoints and milestones as soon as
+// the heimdall client pushes them. It returns false if the client doesn't support
+// subscriptions, in which case they have to be polled.
+func (s *Ethereum) subscribeHeimdallFinality() bool {
+	ethHandler, engine, err := s.getHandler()
+	if err != nil {
+		return false
+	}
+
+	subscriber, ok := engine.HeimdallClient.(bor.IHeimdallSubscriber)
+	if !ok {
+		return false
+	}
+
+	log.Info("Subscribed to heimdall checkpoints and milestones")
+
+	go func() {
+		ch := make(chan *checkpoint.Checkpoint)
+
+		handleHeimdallSubscription(subscriber.SubscribeCheckpoints(ch), ch, s.closeCh, heimdall.CheckpointSubscriptionInterval, "whitelist checkpoint", func(ctx context.Context, checkpoint *checkpoint.Checkpoint) error {
+			finality, err := ethHandler.verifyWhitelistCheckpoint(ctx, checkpoint, s, newBorVerifier())
+
+			return s.processWhitelistCheckpoint(ethHandler, finality, err)
+		})
+	}()
+
+	go func() {
+		ch := make(chan *milestone.Milestone)
+
+		handleHeimdallSubscription(subscriber.SubscribeMilestones(ch), ch, s.closeCh, heimdall.MilestoneSubscriptionInterval, "whitelist milestone", func(ctx context.Context, milestone *milestone.Milestone) error {
+			finality, err := ethHandler.verifyWhitelistMilestone(ctx, milestone, s, newBorVerifier())
+
+			return s.processMilestone(ethHandler, finality, err)
+		})
+	}()
+
+	return true
+}
+
+// handleHeimdallSubscription runs fn for every item received on ch until the
+// subscription ends or the node shuts down. Items fn fails on, e.g. because the
+// local chain didn't catch up yet, are retried every retryInterval until they
+// succeed or a newer item is received.
+func handleHeimdallSubscription[T any](sub event.Subscription, ch <-chan *T, closeCh chan struct{}, retryInterval time.Duration, fnName string, fn func(ctx context.Context, item *T) error) {
+	defer sub.Unsubscribe()
+
+	// The retry timer only runs while there's a failed item
+	var (
+		failed *T
+		retry  = time.NewTimer(retryInterval)
+	)
+
+	retry.Stop()
+
+	handle := func(item *T) {
+		ctx, cancel := context.WithTimeout(context.Background(), whitelistTimeout)
+		err := fn(ctx, item)
+
+		cancel()
+
+		if err != nil {
+			log.Warn(fmt.Sprintf("unable to handle %s", fnName), "err", err)
+
+			failed = item
+			retry.Reset(retryInterval)
+
+			return
+		}
+
+		failed = nil
+	}
+
+	for {
+		select {
+		case item := <-ch:
+			if failed != nil && !retry.Stop() {
+				<-retry.C
+			}
+
+			handle(item)
+		case <-retry.C:
+			if failed != nil {
+				handle(failed)
+			}
+		case err := <-sub.Err():
+			if err != nil {
+				log.Debug(fmt.Sprintf("%s subscription ended", fnName), "err", err)
+			}
+
+			return
+		case <-closeCh:
+			return
+		}
+	}
+}
+
 func (s *Ethereum) getHandler() (*ethHandler, *bor.Bor, error) {
 	ethHandler := (*ethHandler)(s.handler)
 
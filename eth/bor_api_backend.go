@@ -139,6 +139,11 @@ func (b *EthAPIBackend) SubscribeStateSyncEvent(ch chan<- core.StateSyncEvent) e
 	return b.eth.BlockChain().SubscribeStateSyncEvent(ch)
 }
 
+// SubscribeFinalityEvent subscribes to whitelisted checkpoints and milestones
+func (b *EthAPIBackend) SubscribeFinalityEvent(ch chan<- core.FinalityEvent) event.Subscription {
+	return b.eth.finalityFeed.Subscribe(ch)
+}
+
 // SubscribeChain2HeadEvent subscribes to reorg/head/fork event
 func (b *EthAPIBackend) SubscribeChain2HeadEvent(ch chan<- core.Chain2HeadEvent) event.Subscription {
 	return b.eth.BlockChain().SubscribeChain2HeadEvent(ch)
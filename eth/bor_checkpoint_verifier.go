@@ -5,7 +5,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -117,17 +119,27 @@ func borVerify(ctx context.Context, eth *Ethereum, handler *ethHandler, start ui
 			}
 		}
 
-		if head-rewindTo > 255 {
-			rewindTo = head - 255
+		rewindTo = eth.blockchain.FinalityPolicy().ClampRewind(head, rewindTo)
+
+		entry := &rawdb.RewindEntry{
+			Reason:       rawdb.RewindMilestoneMismatch,
+			StartBlock:   start,
+			EndBlock:     end,
+			ExpectedHash: hash,
+			LocalHash:    localHash,
 		}
 
 		if isCheckpoint {
+			entry.Reason = rawdb.RewindCheckpointMismatch
+
 			log.Warn("Rewinding chain due to checkpoint root hash mismatch", "number", rewindTo)
 		} else {
 			log.Warn("Rewinding chain due to milestone endblock hash mismatch", "number", rewindTo)
 		}
 
-		rewindBack(eth, head, rewindTo)
+		if err := rewindBack(eth, head, rewindTo, entry); err != nil {
+			return hash, fmt.Errorf("%w: %w", errHashMismatch, err)
+		}
 
 		return hash, errHashMismatch
 	}
@@ -144,28 +156,58 @@ func borVerify(ctx context.Context, eth *Ethereum, handler *ethHandler, start ui
 	return hash, nil
 }
 
-// Stop the miner if the mining process is running and rewind back the chain
-func rewindBack(eth *Ethereum, head uint64, rewindTo uint64) {
+// Stop the miner if the mining process is running and rewind back the chain,
+// recording the rewind in the journal
+func rewindBack(eth *Ethereum, head uint64, rewindTo uint64, entry *rawdb.RewindEntry) error {
 	if eth.Miner().Mining() {
 		ch := make(chan struct{})
 		eth.Miner().Stop(ch)
 
 		<-ch
-		rewind(eth, head, rewindTo)
+		err := rewind(eth, head, rewindTo, entry)
 
 		eth.Miner().Start()
-	} else {
-		rewind(eth, head, rewindTo)
+
+		return err
 	}
+
+	return rewind(eth, head, rewindTo, entry)
 }
 
-func rewind(eth *Ethereum, head uint64, rewindTo uint64) {
-	err := eth.blockchain.SetHead(rewindTo)
+func rewind(eth *Ethereum, head uint64, rewindTo uint64, entry *rawdb.RewindEntry) error {
+	entry.Head = head
+	entry.RewindTo = rewindTo
+
+	// The transactions of the dropped blocks are returned to the pool when it
+	// resets to the new head. They are collected before the blocks are gone.
+	for number := rewindTo + 1; number <= head; number++ {
+		block := eth.blockchain.GetBlockByNumber(number)
+		if block == nil {
+			continue
+		}
+
+		entry.Dropped = append(entry.Dropped, block.Hash())
+
+		for _, tx := range block.Transactions() {
+			entry.Transactions = append(entry.Transactions, tx.Hash())
+		}
+	}
+
+	err := eth.blockchain.RewindWithinFinality(rewindTo)
 
 	if err != nil {
 		log.Error("Error while rewinding the chain", "to", rewindTo, "err", err)
+
+		// Nothing was dropped nor returned to the pool
+		entry.Dropped, entry.Transactions = nil, nil
+		entry.Error = err.Error()
 	} else {
 		rewindLengthMeter.Mark(int64(head - rewindTo))
 	}
 
+	entry.Time = uint64(time.Now().UnixNano())
+
+	rawdb.WriteRewindEntry(eth.chainDb, entry)
+
+	return err
 }
@@ -0,0 +1,79 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/downloader/whitelist"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that the rewind journal lists the dropped blocks and transactions only
+// if the finality policy allowed the rewind.
+func TestRewindJournal(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = crypto.GenerateKey()
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		signer  = types.HomesteadSigner{}
+		gspec   = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  core.GenesisAlloc{address: {Balance: big.NewInt(params.Ether)}},
+		}
+	)
+
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 6, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(b.TxNonce(address), common.Address{1}, big.NewInt(1), params.TxGas, b.BaseFee(), nil), signer, key)
+		b.AddTx(tx)
+	})
+
+	db := rawdb.NewMemoryDatabase()
+	validator := whitelist.NewService(db)
+
+	chain, err := core.NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, validator)
+	require.NoError(t, err)
+
+	defer chain.Stop()
+
+	_, err = chain.InsertChain(blocks)
+	require.NoError(t, err)
+
+	validator.ProcessMilestone(blocks[2].NumberU64(), blocks[2].Hash())
+	chain.SetFinalityPolicy(core.NewFinalityPolicy(core.FinalityPolicyConfig{}, validator))
+
+	eth := &Ethereum{blockchain: chain, chainDb: db}
+
+	// Rewinding below the finalized block is refused, nothing is dropped
+	refused := &rawdb.RewindEntry{Reason: rawdb.RewindMilestoneMismatch}
+	require.ErrorIs(t, rewind(eth, 6, 2, refused), core.ErrRewindBelowFinality)
+	require.NotEmpty(t, refused.Error)
+	require.Empty(t, refused.Dropped)
+	require.Empty(t, refused.Transactions)
+	require.Equal(t, uint64(6), chain.CurrentBlock().Number.Uint64())
+
+	// Rewinding to the finalized block drops the blocks above it
+	allowed := &rawdb.RewindEntry{Reason: rawdb.RewindMilestoneMismatch}
+	require.NoError(t, rewind(eth, 6, 3, allowed))
+	require.Empty(t, allowed.Error)
+	require.Equal(t, uint64(3), chain.CurrentBlock().Number.Uint64())
+	require.Len(t, allowed.Dropped, 3)
+	require.Len(t, allowed.Transactions, 3)
+
+	for i, block := range blocks[3:] {
+		require.Equal(t, block.Hash(), allowed.Dropped[i])
+		require.Equal(t, block.Transactions()[0].Hash(), allowed.Transactions[i])
+	}
+
+	history := rawdb.ReadRewindHistory(db, 0)
+	require.Len(t, history, 2)
+}
@@ -0,0 +1,274 @@
+package eth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// conditionalTxRetention is the time the entries of the conditional
+	// transaction journal are kept to serve their status
+	conditionalTxRetention = 24 * time.Hour
+
+	// conditionalTxPruneInterval is the interval at which the entries of the
+	// conditional transaction journal older than the retention are pruned
+	conditionalTxPruneInterval = time.Hour
+
+	// conditionalTxConfirmations is the number of blocks after which the
+	// inclusion of a conditional transaction is no longer checked for reorgs
+	conditionalTxConfirmations = 128
+)
+
+// reloadConditionalTxs restores the options of the pending conditional
+// transactions after a restart, as the pool journal only keeps the
+// transactions. The entries older than the retention are pruned instead.
+func (s *Ethereum) reloadConditionalTxs() {
+	var (
+		head     = s.blockchain.CurrentBlock()
+		reloaded int
+	)
+
+	pruned := s.pruneConditionalTxs()
+
+	for _, entry := range rawdb.ReadConditionalTxs(s.chainDb) {
+		if entry.Status != rawdb.ConditionalTxPending {
+			continue
+		}
+
+		// Leave the expired transactions to the status tracker
+		if options := entry.Options; options != nil {
+			if options.BlockNumberMax != nil && head.Number.Cmp(options.BlockNumberMax) >= 0 {
+				continue
+			}
+
+			if options.TimestampMax != nil && head.Time >= *options.TimestampMax {
+				continue
+			}
+		}
+
+		// The transaction may have been loaded from the local pool journal
+		if pooled := s.txPool.Get(entry.Hash); pooled != nil {
+			pooled.Tx.PutOptions(entry.Options)
+			reloaded++
+
+			continue
+		}
+
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(entry.Tx); err != nil {
+			log.Error("Failed to decode the conditional transaction", "hash", entry.Hash, "err", err)
+			continue
+		}
+
+		tx.PutOptions(entry.Options)
+
+		if err := s.txPool.Add([]*txpool.Transaction{{Tx: tx}}, true, false)[0]; err != nil {
+			log.Debug("Failed to reload the conditional transaction", "hash", entry.Hash, "err", err)
+			continue
+		}
+
+		reloaded++
+	}
+
+	if reloaded > 0 || pruned > 0 {
+		log.Info("Reloaded conditional transactions", "reloaded", reloaded, "pruned", pruned)
+	}
+}
+
+// pruneConditionalTxs deletes the entries of the conditional transaction
+// journal older than the retention, unless their transaction is still pooled.
+// It returns the number of deleted entries.
+func (s *Ethereum) pruneConditionalTxs() int {
+	var (
+		now    = time.Now()
+		pruned int
+	)
+
+	for _, entry := range rawdb.ReadConditionalTxs(s.chainDb) {
+		if now.Sub(time.Unix(int64(entry.Time), 0)) <= conditionalTxRetention || s.txPool.Has(entry.Hash) {
+			continue
+		}
+
+		rawdb.DeleteConditionalTx(s.chainDb, entry.Hash)
+		pruned++
+	}
+
+	return pruned
+}
+
+// trackConditionalTxs keeps the status of the journaled conditional
+// transactions up to date on every new chain head, so it doesn't depend on
+// being queried. Transactions are tracked until their status can't change
+// anymore, and again if they re-enter the pool.
+func (s *Ethereum) trackConditionalTxs(headCh chan core.ChainHeadEvent, headSub event.Subscription, txCh chan core.NewTxsEvent, txSub event.Subscription) {
+	defer headSub.Unsubscribe()
+	defer txSub.Unsubscribe()
+
+	prune := time.NewTicker(conditionalTxPruneInterval)
+	defer prune.Stop()
+
+	tracked := make(map[common.Hash]*rawdb.ConditionalTxEntry)
+
+	head := s.blockchain.CurrentBlock()
+	for _, entry := range rawdb.ReadConditionalTxs(s.chainDb) {
+		if !s.conditionalTxSettled(entry, head) {
+			tracked[entry.Hash] = entry
+		}
+	}
+
+	for {
+		select {
+		case ev := <-txCh:
+			for _, tx := range ev.Txs {
+				if tx.GetOptions() == nil {
+					continue
+				}
+
+				if entry := rawdb.ReadConditionalTx(s.chainDb, tx.Hash()); entry != nil {
+					tracked[entry.Hash] = entry
+				}
+			}
+
+		case ev := <-headCh:
+			s.updateConditionalTxs(tracked, ev.Block.Header())
+
+		case <-prune.C:
+			if pruned := s.pruneConditionalTxs(); pruned > 0 {
+				log.Debug("Pruned conditional transactions", "count", pruned)
+			}
+
+		case <-headSub.Err():
+			return
+		case <-txSub.Err():
+			return
+		case <-s.closeCh:
+			return
+		}
+	}
+}
+
+// updateConditionalTxs resolves the status of the tracked conditional
+// transactions at the given head, stores the changed ones and stops tracking
+// the settled ones.
+func (s *Ethereum) updateConditionalTxs(tracked map[common.Hash]*rawdb.ConditionalTxEntry, head *types.Header) {
+	var statedb *state.StateDB
+
+	// The state is only needed for the transactions with known accounts
+	stateAt := func() (*state.StateDB, error) {
+		if statedb != nil {
+			return statedb, nil
+		}
+
+		var err error
+
+		statedb, err = s.blockchain.StateAt(head.Root)
+
+		return statedb, err
+	}
+
+	for hash, entry := range tracked {
+		before := *entry
+
+		if err := s.resolveConditionalTx(entry, head, stateAt); err != nil {
+			log.Debug("Failed to update the conditional transaction status", "hash", hash, "err", err)
+			continue
+		}
+
+		if entry.Status != before.Status || entry.BlockHash != before.BlockHash {
+			rawdb.WriteConditionalTx(s.chainDb, entry)
+		}
+
+		if s.conditionalTxSettled(entry, head) {
+			delete(tracked, hash)
+		}
+	}
+}
+
+// resolveConditionalTx resolves the status of a conditional transaction against
+// the canonical chain, the state at the given head and the pool.
+func (s *Ethereum) resolveConditionalTx(entry *rawdb.ConditionalTxEntry, head *types.Header, stateAt func() (*state.StateDB, error)) error {
+	entry.BlockNumber, entry.BlockHash = 0, common.Hash{}
+	entry.Reason, entry.KnownAccount = "", nil
+
+	if number := rawdb.ReadTxLookupEntry(s.chainDb, entry.Hash); number != nil {
+		if hash := rawdb.ReadCanonicalHash(s.chainDb, *number); hash != (common.Hash{}) {
+			entry.Status = rawdb.ConditionalTxIncluded
+			entry.BlockNumber = *number
+			entry.BlockHash = hash
+
+			return nil
+		}
+	}
+
+	if options := entry.Options; options != nil {
+		// The next block can't be included in the window anymore
+		if options.BlockNumberMax != nil && head.Number.Cmp(options.BlockNumberMax) >= 0 {
+			entry.Status = rawdb.ConditionalTxExpired
+			entry.Reason = "block number window passed"
+
+			return nil
+		}
+
+		if options.TimestampMax != nil && head.Time >= *options.TimestampMax {
+			entry.Status = rawdb.ConditionalTxExpired
+			entry.Reason = "timestamp window passed"
+
+			return nil
+		}
+
+		if len(options.KnownAccounts) > 0 {
+			statedb, err := stateAt()
+			if err != nil {
+				return err
+			}
+
+			if err := statedb.ValidateKnownAccounts(options.KnownAccounts); err != nil {
+				entry.Status = rawdb.ConditionalTxInvalidated
+				entry.Reason = err.Error()
+
+				var accountErr *types.KnownAccountError
+				if errors.As(err, &accountErr) {
+					entry.KnownAccount = accountErr
+				}
+
+				return nil
+			}
+		}
+	}
+
+	if !s.txPool.Has(entry.Hash) {
+		entry.Status = rawdb.ConditionalTxDropped
+		entry.Reason = "transaction left the pool"
+
+		return nil
+	}
+
+	entry.Status = rawdb.ConditionalTxPending
+
+	return nil
+}
+
+// conditionalTxSettled reports whether the status of a conditional transaction
+// can't change anymore at the given head: it is included deep enough in the
+// chain, expired, or out of the pool without being included.
+func (s *Ethereum) conditionalTxSettled(entry *rawdb.ConditionalTxEntry, head *types.Header) bool {
+	switch entry.Status {
+	case rawdb.ConditionalTxIncluded:
+		return head.Number.Uint64() >= entry.BlockNumber+conditionalTxConfirmations
+	case rawdb.ConditionalTxExpired:
+		return true
+	case rawdb.ConditionalTxPending:
+		return false
+	default:
+		return !s.txPool.Has(entry.Hash)
+	}
+}
@@ -0,0 +1,53 @@
+package eth
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// heimdallFinality is the finality source of the finality sync, pivoting snap
+// sync to the latest milestone, or to the latest checkpoint if heimdall has no
+// milestone yet.
+type heimdallFinality struct {
+	bor *bor.Bor
+}
+
+// Finalized implements downloader.FinalitySource
+func (f *heimdallFinality) Finalized(ctx context.Context) (*downloader.FinalizedBlock, error) {
+	milestone, err := f.bor.HeimdallClient.FetchMilestone(ctx)
+	if err == nil {
+		return &downloader.FinalizedBlock{
+			Number:    milestone.EndBlock.Uint64(),
+			Hash:      milestone.Hash,
+			Milestone: true,
+		}, nil
+	}
+
+	log.Debug("Failed to fetch latest milestone for finality sync, falling back to checkpoint", "err", err)
+
+	checkpoint, err := f.bor.HeimdallClient.FetchCheckpoint(ctx, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &downloader.FinalizedBlock{
+		Number:   checkpoint.EndBlock.Uint64(),
+		Start:    checkpoint.StartBlock.Uint64(),
+		RootHash: checkpoint.RootHash,
+	}, nil
+}
+
+// CheckpointRoot implements downloader.FinalitySource
+func (f *heimdallFinality) CheckpointRoot(headers []*types.Header) (common.Hash, error) {
+	root, err := bor.ComputeRootHash(headers)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return common.BytesToHash(root), nil
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -135,6 +136,12 @@ type Downloader struct {
 	pivotHeader *types.Header // Pivot block header to dynamically push the syncing state root
 	pivotLock   sync.RWMutex  // Lock protecting pivot header reads from updates
 
+	// Finality sync
+	finalitySource  FinalitySource         // Trusted source of finalized blocks to pivot snap sync to
+	finalityAnchors map[uint64]common.Hash // Finalized blocks the synced chain must include
+	finalityPolicy  *core.FinalityPolicy   // Policy limiting the depth of the reorgs
+	finalityLock    sync.RWMutex           // Lock protecting the finality source, anchors and policy
+
 	SnapSyncer     *snap.Syncer // TODO(karalabe): make private! hack for now
 	stateSyncStart chan *stateSync
 
@@ -519,6 +526,19 @@ func (d *Downloader) syncWithPeer(p *peerConnection, hash common.Hash, td, ttd *
 		if err != nil {
 			return err
 		}
+		// In finality sync, anchor the header chain to the latest finalized
+		// block instead of trusting the chain advertised by the peer, and
+		// pivot snap sync to it
+		if source := d.resetFinalityAnchors(); source != nil && (mode == FullSync || mode == SnapSync) {
+			var anchor *types.Header
+			if anchor, err = d.fetchFinalityPivot(p, source, latest, 0); err != nil {
+				return err
+			}
+
+			if mode == SnapSync {
+				pivot = anchor
+			}
+		}
 	} else {
 		// In beacon mode, use the skeleton chain to retrieve the headers from
 		latest, _, final, err = d.skeleton.Bounds()
@@ -951,6 +971,16 @@ func (d *Downloader) findAncestor(p *peerConnection, remoteHeader *types.Header)
 		}
 	}
 
+	// Never fork off below the finalized block, nor deeper than the finality
+	// policy allows
+	d.finalityLock.RLock()
+	lowest := d.finalityPolicy.LowestAncestor(localHeight)
+	d.finalityLock.RUnlock()
+
+	if int64(lowest)-1 > floor {
+		floor = int64(lowest) - 1
+	}
+
 	ancestor, err := d.findAncestorSpanSearch(p, mode, remoteHeight, localHeight, floor)
 	if err == nil {
 		return ancestor, nil
@@ -1133,6 +1163,16 @@ func (d *Downloader) fetchHeaders(p *peerConnection, from uint64, head uint64) e
 		)
 
 		switch {
+		case pivoting && d.finalityEnabled():
+			// The pivot only moves to newer finalized blocks
+			if err := d.moveFinalityPivot(p); err != nil {
+				return err
+			}
+
+			pivoting = false
+
+			continue
+
 		case pivoting:
 			d.pivotLock.RLock()
 			pivot := d.pivotHeader.Number.Uint64()
@@ -1507,6 +1547,11 @@ func (d *Downloader) processHeaders(origin uint64, td, ttd *big.Int, beaconMode
 				chunkHeaders := headers[:limit]
 				chunkHashes := hashes[:limit]
 
+				if err := d.checkFinalityAnchors(chunkHeaders, chunkHashes); err != nil {
+					rollbackErr = err
+					return err
+				}
+
 				// In case of header only syncing, validate the chunk immediately
 				if mode == SnapSync || mode == LightSync {
 					// Although the received headers might be all valid, a legacy
@@ -1813,17 +1858,24 @@ func (d *Downloader) processSnapSyncContent() error {
 			// Note, we have `reorgProtHeaderDelay` number of blocks withheld, Those
 			// need to be taken into account, otherwise we're detecting the pivot move
 			// late and will drop peers due to unavailable state!!!
+			//
+			// In finality sync, the pivot never moves past the latest finalized block.
 			if height := latest.Number.Uint64(); height >= pivot.Number.Uint64()+2*uint64(fsMinFullBlocks)-uint64(reorgProtHeaderDelay) {
-				log.Warn("Pivot became stale, moving", "old", pivot.Number.Uint64(), "new", height-uint64(fsMinFullBlocks)+uint64(reorgProtHeaderDelay))
-				pivot = results[len(results)-1-fsMinFullBlocks+reorgProtHeaderDelay].Header // must exist as lower old pivot is uncommitted
+				next := results[len(results)-1-fsMinFullBlocks+reorgProtHeaderDelay].Header // must exist as lower old pivot is uncommitted
+				if next = d.clampFinalityPivot(results, next); next == nil || next.Number.Uint64() <= pivot.Number.Uint64() {
+					log.Debug("Pivot became stale, waiting for a newer finalized block", "pivot", pivot.Number.Uint64(), "height", height)
+				} else {
+					log.Warn("Pivot became stale, moving", "old", pivot.Number.Uint64(), "new", next.Number.Uint64())
+					pivot = next
 
-				d.pivotLock.Lock()
-				d.pivotHeader = pivot
-				d.pivotLock.Unlock()
+					d.pivotLock.Lock()
+					d.pivotHeader = pivot
+					d.pivotLock.Unlock()
 
-				// Write out the pivot into the database so a rollback beyond it will
-				// reenable snap sync
-				rawdb.WriteLastPivotNumber(d.stateDB, pivot.Number.Uint64())
+					// Write out the pivot into the database so a rollback beyond it will
+					// reenable snap sync
+					rawdb.WriteLastPivotNumber(d.stateDB, pivot.Number.Uint64())
+				}
 			}
 		}
 
@@ -0,0 +1,291 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// finalityTimeout is the time allowed to retrieve the latest finalized block
+const finalityTimeout = 10 * time.Second
+
+var (
+	errFinalityUnavailable   = errors.New("finalized block unavailable")
+	errFinalityPivotAhead    = errors.New("peer is behind the finalized block")
+	errFinalityPivotMismatch = fmt.Errorf("%w: chain doesn't match the finalized block", errInvalidChain)
+)
+
+// FinalizedBlock is the end block of a milestone or a checkpoint finalized on
+// heimdall. Milestones carry the hash of their end block, checkpoints the root
+// hash of the headers of their range.
+type FinalizedBlock struct {
+	Number    uint64
+	Hash      common.Hash // End block hash, for milestones
+	Start     uint64      // Start block, for checkpoints
+	RootHash  common.Hash // Root hash of the start to end headers, for checkpoints
+	Milestone bool
+}
+
+// FinalitySource is a trusted source of finalized blocks used to anchor the
+// header chain and pivot snap sync, instead of relying on the chain advertised
+// by the master peer.
+type FinalitySource interface {
+	// Finalized returns the latest finalized block
+	Finalized(ctx context.Context) (*FinalizedBlock, error)
+
+	// CheckpointRoot computes the root hash of the headers of a checkpoint
+	CheckpointRoot(headers []*types.Header) (common.Hash, error)
+}
+
+// SetFinalitySource enables the finality sync: the headers leading to the latest
+// block finalized by the source are verified, and the synced chain must include
+// that block. Snap sync also pivots to it, and moves the pivot only to newer
+// finalized blocks.
+func (d *Downloader) SetFinalitySource(source FinalitySource) {
+	d.finalityLock.Lock()
+	defer d.finalityLock.Unlock()
+
+	d.finalitySource = source
+}
+
+// SetFinalityPolicy sets the policy limiting the depth of the reorgs, so that
+// the common ancestor with a peer is never searched below the finalized block.
+func (d *Downloader) SetFinalityPolicy(policy *core.FinalityPolicy) {
+	d.finalityLock.Lock()
+	defer d.finalityLock.Unlock()
+
+	d.finalityPolicy = policy
+}
+
+// resetFinalityAnchors clears the finalized blocks of the previous sync cycle,
+// and returns the finality source if the finality sync is enabled.
+func (d *Downloader) resetFinalityAnchors() FinalitySource {
+	d.finalityLock.Lock()
+	defer d.finalityLock.Unlock()
+
+	d.finalityAnchors = nil
+
+	return d.finalitySource
+}
+
+// finalityEnabled reports whether the current sync cycle is anchored to a
+// finalized block.
+func (d *Downloader) finalityEnabled() bool {
+	d.finalityLock.RLock()
+	defer d.finalityLock.RUnlock()
+
+	return len(d.finalityAnchors) > 0
+}
+
+// fetchFinalityPivot retrieves the latest finalized block and verifies the
+// headers leading to it from the peer, returning the header of the block. It
+// returns nil if the block isn't newer than the given one.
+func (d *Downloader) fetchFinalityPivot(p *peerConnection, source FinalitySource, latest *types.Header, after uint64) (*types.Header, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), finalityTimeout)
+	defer cancel()
+
+	final, err := source.Finalized(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errFinalityUnavailable, err)
+	}
+
+	if final.Number <= after {
+		return nil, nil
+	}
+
+	if latest != nil && final.Number > latest.Number.Uint64() {
+		return nil, fmt.Errorf("%w: head %d, finalized %d", errFinalityPivotAhead, latest.Number, final.Number)
+	}
+
+	var header *types.Header
+
+	if final.Milestone {
+		header, err = d.verifyMilestoneHeaders(p, final)
+	} else {
+		header, err = d.verifyCheckpointHeaders(p, source, final)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.finalityLock.Lock()
+	if d.finalityAnchors == nil {
+		d.finalityAnchors = make(map[uint64]common.Hash)
+	}
+	d.finalityAnchors[final.Number] = header.Hash()
+	d.finalityLock.Unlock()
+
+	// Reject the peers and chains which don't include the finalized block
+	if d.ChainValidator != nil {
+		if final.Milestone {
+			d.ChainValidator.ProcessMilestone(final.Number, header.Hash())
+		} else {
+			d.ChainValidator.ProcessCheckpoint(final.Number, header.Hash())
+		}
+	}
+
+	p.log.Debug("Finalized pivot identified", "number", final.Number, "hash", header.Hash(), "milestone", final.Milestone)
+
+	return header, nil
+}
+
+// verifyMilestoneHeaders retrieves the headers backwards from the end block of
+// a milestone, and verifies they are linked to its hash.
+func (d *Downloader) verifyMilestoneHeaders(p *peerConnection, final *FinalizedBlock) (*types.Header, error) {
+	headers, hashes, err := d.fetchHeadersByHash(p, final.Hash, fsMinFullBlocks, 0, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(headers) == 0 || hashes[0] != final.Hash || headers[0].Number.Uint64() != final.Number {
+		return nil, fmt.Errorf("%w: milestone end block %d (%x) not served", errFinalityPivotMismatch, final.Number, final.Hash)
+	}
+
+	for i := 1; i < len(headers); i++ {
+		if headers[i-1].ParentHash != hashes[i] || headers[i-1].Number.Uint64() != headers[i].Number.Uint64()+1 {
+			return nil, fmt.Errorf("%w: broken header chain at %d", errFinalityPivotMismatch, headers[i].Number)
+		}
+	}
+
+	return headers[0], nil
+}
+
+// verifyCheckpointHeaders retrieves the headers of a checkpoint, and verifies
+// they are linked and match its root hash.
+func (d *Downloader) verifyCheckpointHeaders(p *peerConnection, source FinalitySource, final *FinalizedBlock) (*types.Header, error) {
+	if final.Start > final.Number {
+		return nil, fmt.Errorf("invalid checkpoint range %d-%d", final.Start, final.Number)
+	}
+
+	headers := make([]*types.Header, 0, final.Number-final.Start+1)
+
+	for from := final.Start; from <= final.Number; {
+		count := final.Number - from + 1
+		if count > uint64(MaxHeaderFetch) {
+			count = uint64(MaxHeaderFetch)
+		}
+
+		batch, _, err := d.fetchHeadersByNumber(p, from, int(count), 0, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if uint64(len(batch)) != count {
+			return nil, fmt.Errorf("%w: returned headers %d != requested %d", errBadPeer, len(batch), count)
+		}
+
+		headers = append(headers, batch...)
+		from += count
+	}
+
+	for i := 1; i < len(headers); i++ {
+		if headers[i].ParentHash != headers[i-1].Hash() || headers[i].Number.Uint64() != headers[i-1].Number.Uint64()+1 {
+			return nil, fmt.Errorf("%w: broken header chain at %d", errFinalityPivotMismatch, headers[i].Number)
+		}
+	}
+
+	root, err := source.CheckpointRoot(headers)
+	if err != nil {
+		return nil, err
+	}
+
+	if root != final.RootHash {
+		return nil, fmt.Errorf("%w: checkpoint %d-%d root hash %x != %x", errFinalityPivotMismatch, final.Start, final.Number, root, final.RootHash)
+	}
+
+	return headers[len(headers)-1], nil
+}
+
+// moveFinalityPivot moves the pivot to the latest finalized block if it's newer
+// than the current pivot. Failing to reach the finality source keeps the pivot.
+func (d *Downloader) moveFinalityPivot(p *peerConnection) error {
+	d.finalityLock.RLock()
+	source := d.finalitySource
+	d.finalityLock.RUnlock()
+
+	d.pivotLock.RLock()
+	pivot := d.pivotHeader
+	d.pivotLock.RUnlock()
+
+	if source == nil || pivot == nil {
+		return nil
+	}
+
+	header, err := d.fetchFinalityPivot(p, source, nil, pivot.Number.Uint64())
+	if errors.Is(err, errFinalityUnavailable) {
+		log.Debug("Keeping finalized pivot", "number", pivot.Number, "err", err)
+		return nil
+	}
+
+	if err != nil || header == nil {
+		return err
+	}
+
+	log.Info("Moving pivot to finalized block", "old", pivot.Number, "new", header.Number)
+
+	d.pivotLock.Lock()
+	d.pivotHeader = header
+	d.pivotLock.Unlock()
+
+	// Write out the pivot into the database so a rollback beyond it will
+	// reenable snap sync and update the state root that the state syncer
+	// will be downloading.
+	rawdb.WriteLastPivotNumber(d.stateDB, header.Number.Uint64())
+
+	return nil
+}
+
+// clampFinalityPivot returns the block to move a stale pivot to. In finality
+// sync, a candidate past the latest finalized block is replaced by that block,
+// or by nil if it isn't part of the results.
+func (d *Downloader) clampFinalityPivot(results []*fetchResult, candidate *types.Header) *types.Header {
+	d.finalityLock.RLock()
+	defer d.finalityLock.RUnlock()
+
+	if len(d.finalityAnchors) == 0 {
+		return candidate
+	}
+
+	var final uint64
+
+	for number := range d.finalityAnchors {
+		if number > final {
+			final = number
+		}
+	}
+
+	if candidate.Number.Uint64() <= final {
+		return candidate
+	}
+
+	for _, result := range results {
+		if result.Header.Number.Uint64() == final {
+			return result.Header
+		}
+	}
+
+	return nil
+}
+
+// checkFinalityAnchors verifies that the headers match the finalized blocks
+// of the current sync cycle.
+func (d *Downloader) checkFinalityAnchors(headers []*types.Header, hashes []common.Hash) error {
+	d.finalityLock.RLock()
+	defer d.finalityLock.RUnlock()
+
+	for i, header := range headers {
+		if hash, ok := d.finalityAnchors[header.Number.Uint64()]; ok && hash != hashes[i] {
+			return fmt.Errorf("%w: block %d hash %x, finalized %x", errFinalityPivotMismatch, header.Number, hashes[i], hash)
+		}
+	}
+
+	return nil
+}
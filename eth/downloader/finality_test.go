@@ -0,0 +1,138 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+)
+
+// finalityFake is a finality source returning a fixed finalized block
+type finalityFake struct {
+	final *FinalizedBlock
+}
+
+func (f *finalityFake) Finalized(ctx context.Context) (*FinalizedBlock, error) {
+	return f.final, nil
+}
+
+// CheckpointRoot hashes the hashes of the headers together
+func (f *finalityFake) CheckpointRoot(headers []*types.Header) (common.Hash, error) {
+	var data []byte
+	for _, header := range headers {
+		data = append(data, header.Hash().Bytes()...)
+	}
+
+	return crypto.Keccak256Hash(data), nil
+}
+
+// Tests that the finality sync anchors full and snap sync to the finalized
+// block, pivots snap sync to it, and rejects peers whose chain doesn't include it.
+func TestFinalitySync(t *testing.T) {
+	t.Parallel()
+
+	chain := testChainBase.shorten(blockCacheMaxItems - 15)
+	number := uint64(len(chain.blocks) - 80)
+	block := chain.blocks[number]
+
+	checkpoint := &finalityFake{}
+	checkpointRoot, _ := checkpoint.CheckpointRoot([]*types.Header{chain.blocks[number-1].Header(), block.Header()})
+
+	tests := []struct {
+		name  string
+		final *FinalizedBlock
+		err   error
+	}{
+		{"milestone", &FinalizedBlock{Number: number, Hash: block.Hash(), Milestone: true}, nil},
+		{"checkpoint", &FinalizedBlock{Number: number, Start: number - 1, RootHash: checkpointRoot}, nil},
+		{"milestone mismatch", &FinalizedBlock{Number: number, Hash: common.HexToHash("0x01"), Milestone: true}, errInvalidChain},
+		{"checkpoint mismatch", &FinalizedBlock{Number: number, Start: number - 1, RootHash: common.HexToHash("0x01")}, errInvalidChain},
+		{"peer behind", &FinalizedBlock{Number: uint64(len(chain.blocks)), Hash: common.HexToHash("0x01"), Milestone: true}, errFinalityPivotAhead},
+	}
+
+	for _, tt := range tests {
+		for _, mode := range []SyncMode{FullSync, SnapSync} {
+			tt, mode := tt, mode
+
+			t.Run(tt.name+"/"+mode.String(), func(t *testing.T) {
+				t.Parallel()
+
+				tester := newTester(t)
+				defer tester.terminate()
+
+				tester.downloader.SetFinalitySource(&finalityFake{final: tt.final})
+				tester.newPeer("peer", eth.ETH67, chain.blocks[1:])
+
+				err := tester.sync("peer", nil, mode)
+				if tt.err != nil {
+					if !errors.Is(err, tt.err) {
+						t.Fatalf("sync error mismatch: have %v, want %v", err, tt.err)
+					}
+
+					return
+				}
+
+				if err != nil {
+					t.Fatalf("failed to synchronise blocks: %v", err)
+				}
+
+				assertOwnChain(t, tester, len(chain.blocks))
+
+				if !tester.downloader.finalityEnabled() {
+					t.Fatalf("sync not anchored to the finalized block")
+				}
+
+				if mode != SnapSync {
+					return
+				}
+
+				if pivot := rawdb.ReadLastPivotNumber(tester.downloader.stateDB); pivot == nil || *pivot != number {
+					t.Fatalf("pivot mismatch: have %v, want %d", pivot, number)
+				}
+			})
+		}
+	}
+}
+
+// Tests that a stale pivot never moves past the latest finalized block.
+func TestClampFinalityPivot(t *testing.T) {
+	t.Parallel()
+
+	chain := testChainBase.shorten(200)
+
+	results := make([]*fetchResult, 0, 100)
+	for _, block := range chain.blocks[100:200] {
+		results = append(results, &fetchResult{Header: block.Header()})
+	}
+
+	candidate := chain.blocks[180].Header()
+
+	d := &Downloader{}
+	if pivot := d.clampFinalityPivot(results, candidate); pivot != candidate {
+		t.Fatalf("regular sync pivot mismatch: have %v, want %v", pivot.Number, candidate.Number)
+	}
+
+	d.finalityAnchors = map[uint64]common.Hash{120: chain.blocks[120].Hash(), 150: chain.blocks[150].Hash()}
+	if pivot := d.clampFinalityPivot(results, candidate); pivot == nil || pivot.Number.Uint64() != 150 {
+		t.Fatalf("clamped pivot mismatch: have %v, want 150", pivot)
+	}
+
+	if pivot := d.clampFinalityPivot(results, chain.blocks[140].Header()); pivot.Number.Uint64() != 140 {
+		t.Fatalf("finalized candidate mismatch: have %v, want 140", pivot.Number)
+	}
+
+	d.finalityAnchors = map[uint64]common.Hash{250: common.HexToHash("0x01")}
+	if pivot := d.clampFinalityPivot(results[:50], candidate); pivot == nil || pivot != candidate {
+		t.Fatalf("candidate below the finalized block mismatch: have %v", pivot)
+	}
+
+	d.finalityAnchors = map[uint64]common.Hash{170: chain.blocks[170].Hash()}
+	if pivot := d.clampFinalityPivot(results[:50], candidate); pivot != nil {
+		t.Fatalf("missing finalized block returned pivot %v", pivot.Number)
+	}
+}
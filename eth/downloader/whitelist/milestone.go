@@ -1,6 +1,10 @@
 package whitelist
 
 import (
+	"sort"
+	"sync"
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/flags"
 	"github.com/ethereum/go-ethereum/core/rawdb"
@@ -20,8 +24,17 @@ type milestone struct {
 	FutureMilestoneList  map[uint64]common.Hash // Future Milestone list
 	FutureMilestoneOrder []uint64               // Future Milestone Order
 	MaxCapacity          int                    //Capacity of future Milestone list
+
+	rejectedLock sync.Mutex  // Protects the last rejected reorg recorded in the rewind journal
+	rejectedTime time.Time   // Time of the last rejected reorg recorded in the rewind journal
+	rejectedTip  common.Hash // Last block of the last rejected chain recorded in the rewind journal
 }
 
+// rejectedReorgInterval is the minimum time between two rejected reorgs recorded
+// in the rewind journal, as peers may keep offering chains conflicting with the
+// locked milestone.
+const rejectedReorgInterval = time.Minute
+
 type milestoneService interface {
 	finalityService
 
@@ -31,6 +44,10 @@ type milestoneService interface {
 	UnlockMutex(doLock bool, milestoneId string, endBlockNum uint64, endBlockHash common.Hash)
 	UnlockSprint(endBlockNum uint64)
 	ProcessFutureMilestone(num uint64, hash common.Hash)
+	GetLockState() *rawdb.MilestoneLockState
+	ForceUnlock(reason string) *rawdb.MilestoneAuditEntry
+	PurgeFutureMilestones(reason string) *rawdb.MilestoneAuditEntry
+	GetMilestoneAudit(limit int) []*rawdb.MilestoneAuditEntry
 }
 
 var (
@@ -79,6 +96,8 @@ func (m *milestone) IsValidChain(currentHeader *types.Header, chain []*types.Hea
 	}
 
 	if m.Locked && !m.IsReorgAllowed(chain, m.LockedMilestoneNumber, m.LockedMilestoneHash) {
+		m.recordRejectedReorg(currentHeader, chain)
+
 		isValid = false
 		return isValid, nil
 	}
@@ -221,6 +240,50 @@ func (m *milestone) IsReorgAllowed(chain []*types.Header, lockedMilestoneNumber
 	return true
 }
 
+// recordRejectedReorg records a chain rejected because it conflicts with the
+// locked milestone in the rewind journal. Chains are validated for every peer,
+// so a chain is only recorded once, and at most one every rejectedReorgInterval.
+func (m *milestone) recordRejectedReorg(currentHeader *types.Header, chain []*types.Header) {
+	if m.db == nil {
+		return
+	}
+
+	tip := chain[len(chain)-1].Hash()
+
+	m.rejectedLock.Lock()
+	if tip == m.rejectedTip || time.Since(m.rejectedTime) < rejectedReorgInterval {
+		m.rejectedLock.Unlock()
+		return
+	}
+
+	m.rejectedTime, m.rejectedTip = time.Now(), tip
+	m.rejectedLock.Unlock()
+
+	entry := &rawdb.RewindEntry{
+		Time:         uint64(time.Now().UnixNano()),
+		Reason:       rawdb.RewindReorgRejected,
+		StartBlock:   chain[0].Number.Uint64(),
+		EndBlock:     chain[len(chain)-1].Number.Uint64(),
+		ExpectedHash: m.LockedMilestoneHash.Hex(),
+		Dropped:      make([]common.Hash, 0, len(chain)),
+	}
+
+	if currentHeader != nil {
+		entry.Head = currentHeader.Number.Uint64()
+		entry.RewindTo = entry.Head
+	}
+
+	for _, header := range chain {
+		if header.Number.Uint64() == m.LockedMilestoneNumber {
+			entry.LocalHash = header.Hash().Hex()
+		}
+
+		entry.Dropped = append(entry.Dropped, header.Hash())
+	}
+
+	rawdb.WriteRewindEntry(m.db, entry)
+}
+
 // This will return the list of milestoneIDs stored.
 func (m *milestone) GetMilestoneIDsList() []string {
 	m.finality.RLock()
@@ -240,6 +303,108 @@ func (m *milestone) purgeMilestoneIDsList() {
 	m.LockedMilestoneIDs = make(map[string]struct{})
 }
 
+// GetLockState returns the locked milestone, the milestone IDs voted on and
+// the future milestone queue.
+func (m *milestone) GetLockState() *rawdb.MilestoneLockState {
+	m.finality.RLock()
+	defer m.finality.RUnlock()
+
+	return m.lockState()
+}
+
+func (m *milestone) lockState() *rawdb.MilestoneLockState {
+	state := &rawdb.MilestoneLockState{
+		Locked:           m.Locked,
+		LockedNumber:     m.LockedMilestoneNumber,
+		LockedHash:       m.LockedMilestoneHash,
+		LockedIDs:        make([]string, 0, len(m.LockedMilestoneIDs)),
+		FutureMilestones: make([]rawdb.FutureMilestone, 0, len(m.FutureMilestoneOrder)),
+	}
+
+	for id := range m.LockedMilestoneIDs {
+		state.LockedIDs = append(state.LockedIDs, id)
+	}
+
+	sort.Strings(state.LockedIDs)
+
+	for _, number := range m.FutureMilestoneOrder {
+		state.FutureMilestones = append(state.FutureMilestones, rawdb.FutureMilestone{Number: number, Hash: m.FutureMilestoneList[number]})
+	}
+
+	return state
+}
+
+// ForceUnlock unlocks the locked milestone and drops the milestone IDs voted
+// on, to recover a validator stuck on a milestone which never got finalized.
+// The change is recorded in the audit log.
+func (m *milestone) ForceUnlock(reason string) *rawdb.MilestoneAuditEntry {
+	m.finality.Lock()
+	defer m.finality.Unlock()
+
+	entry := m.audit(rawdb.MilestoneAuditUnlock, reason)
+
+	m.Locked = false
+	m.purgeMilestoneIDsList()
+
+	err := rawdb.WriteLockField(m.db, m.Locked, m.LockedMilestoneNumber, m.LockedMilestoneHash, m.LockedMilestoneIDs)
+	if err != nil {
+		log.Error("Error in writing lock data of milestone to db", "err", err)
+	}
+
+	MilestoneIdsLengthMeter.Update(0)
+
+	log.Warn("Unlocked milestone manually", "number", entry.Before.LockedNumber, "hash", entry.Before.LockedHash, "ids", len(entry.Before.LockedIDs), "reason", reason)
+
+	return entry
+}
+
+// PurgeFutureMilestones drops the future milestone queue, recording the change
+// in the audit log.
+func (m *milestone) PurgeFutureMilestones(reason string) *rawdb.MilestoneAuditEntry {
+	m.finality.Lock()
+	defer m.finality.Unlock()
+
+	entry := m.audit(rawdb.MilestoneAuditPurgeFuture, reason)
+
+	m.FutureMilestoneList = make(map[uint64]common.Hash)
+	m.FutureMilestoneOrder = make([]uint64, 0)
+
+	err := rawdb.WriteFutureMilestoneList(m.db, m.FutureMilestoneOrder, m.FutureMilestoneList)
+	if err != nil {
+		log.Error("Error in writing future milestone data to db", "err", err)
+	}
+
+	log.Warn("Purged future milestones manually", "count", len(entry.Before.FutureMilestones), "reason", reason)
+
+	return entry
+}
+
+// audit records the current state in the audit log, before a manual change
+func (m *milestone) audit(action string, reason string) *rawdb.MilestoneAuditEntry {
+	entry := &rawdb.MilestoneAuditEntry{
+		Time:   uint64(time.Now().UnixNano()),
+		Action: action,
+		Reason: reason,
+		Before: m.lockState(),
+	}
+
+	if m.db != nil {
+		rawdb.WriteMilestoneAuditEntry(m.db, entry)
+	}
+
+	return entry
+}
+
+// GetMilestoneAudit returns up to limit entries of the audit log of the manual
+// changes, the most recent first.
+func (m *milestone) GetMilestoneAudit(limit int) []*rawdb.MilestoneAuditEntry {
+	if m.db == nil {
+		return nil
+	}
+
+	return rawdb.ReadMilestoneAudit(m.db, limit)
+}
+
 func (m *milestone) IsFutureMilestoneCompatible(chain []*types.Header) bool {
 	//Tip of the received chain
 	chainTipNumber := chain[len(chain)-1].Number.Uint64()
@@ -249,6 +249,84 @@ func TestMilestone(t *testing.T) {
 	require.Equal(t, milestone.FutureMilestoneOrder[capicity-1], uint64(16*capicity), "expected value is", uint64(16*capicity), "but got", milestone.FutureMilestoneOrder[capicity-1])
 }
 
+// TestMilestoneForceUnlock checks the manual unlock and purge of the future
+// milestones, and their audit log.
+func TestMilestoneForceUnlock(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+	s := NewMockService(db)
+
+	milestone := s.milestoneService.(*milestone)
+
+	milestone.ProcessFutureMilestone(20, common.Hash{0x02})
+	milestone.ProcessFutureMilestone(30, common.Hash{0x03})
+	milestone.LockMutex(11)
+	milestone.UnlockMutex(true, "milestoneID1", uint64(11), common.Hash{0x01})
+
+	state := s.GetLockState()
+	require.True(t, state.Locked, "expected the milestone to be locked")
+	require.Equal(t, uint64(11), state.LockedNumber)
+	require.Equal(t, []string{"milestoneID1"}, state.LockedIDs)
+	require.Equal(t, []rawdb.FutureMilestone{{Number: 20, Hash: common.Hash{0x02}}, {Number: 30, Hash: common.Hash{0x03}}}, state.FutureMilestones)
+
+	entry := s.ForceUnlock("stuck")
+	require.Equal(t, rawdb.MilestoneAuditUnlock, entry.Action)
+	require.True(t, entry.Before.Locked, "expected the audit entry to record the lock")
+	require.False(t, milestone.Locked, "expected the milestone to be unlocked")
+	require.Equal(t, 0, len(milestone.LockedMilestoneIDs), "expected the milestone IDs to be dropped")
+
+	locked, _, _, ids, err := rawdb.ReadLockField(db)
+	require.Nil(t, err, "error should be nil while reading the lock field")
+	require.False(t, locked, "expected the unlock to be persisted")
+	require.Equal(t, 0, len(ids), "expected the milestone IDs to be persisted")
+
+	entry = s.PurgeFutureMilestones("stale")
+	require.Equal(t, 2, len(entry.Before.FutureMilestones), "expected the audit entry to record the future milestones")
+	require.Equal(t, 0, len(milestone.FutureMilestoneOrder), "expected the future milestones to be dropped")
+
+	order, _, err := rawdb.ReadFutureMilestoneList(db)
+	require.Nil(t, err, "error should be nil while reading the future milestones")
+	require.Equal(t, 0, len(order), "expected the purge to be persisted")
+
+	audit := s.GetMilestoneAudit(0)
+	require.Equal(t, 2, len(audit), "expected two audit entries")
+	require.Equal(t, rawdb.MilestoneAuditPurgeFuture, audit[0].Action)
+	require.Equal(t, "stale", audit[0].Reason)
+	require.Equal(t, rawdb.MilestoneAuditUnlock, audit[1].Action)
+}
+
+// TestRecordRejectedReorg checks that rejected reorgs are recorded in the rewind
+// journal only once per chain, and at most once per interval.
+func TestRecordRejectedReorg(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+	s := NewMockService(db)
+
+	milestone := s.milestoneService.(*milestone)
+
+	chainA := createMockChain(1, 20)
+	chainB := createMockChain(1, 21)
+
+	milestone.recordRejectedReorg(nil, chainA)
+	milestone.recordRejectedReorg(nil, chainA)
+	milestone.recordRejectedReorg(nil, chainB)
+	require.Equal(t, 1, len(rawdb.ReadRewindHistory(db, 0)), "expected a single rejected reorg within the interval")
+
+	milestone.rejectedTime = time.Now().Add(-rejectedReorgInterval)
+
+	milestone.recordRejectedReorg(nil, chainA)
+	require.Equal(t, 1, len(rawdb.ReadRewindHistory(db, 0)), "expected the same chain to be recorded once")
+
+	milestone.recordRejectedReorg(nil, chainB)
+
+	entries := rawdb.ReadRewindHistory(db, 0)
+	require.Equal(t, 2, len(entries), "expected another chain to be recorded after the interval")
+	require.Equal(t, rawdb.RewindReorgRejected, entries[0].Reason)
+	require.Equal(t, uint64(21), entries[0].EndBlock)
+}
+
 // TestIsValidPeer checks the IsValidPeer function in isolation
 // for different cases by providing a mock fetchHeadersByNumber function
 func TestIsValidPeer(t *testing.T) {
@@ -30,7 +30,9 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall" //nolint:typecheck
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdallapp"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdallcache"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdallgrpc"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdallquorum"
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
@@ -84,6 +86,7 @@ var Defaults = Config{
 	RPCEVMTimeout:      5 * time.Second,
 	GPO:                FullNodeGPO,
 	RPCTxFeeCap:        1, // 1 ether
+	FinalityPolicy:     core.DefaultFinalityPolicyConfig,
 }
 
 //go:generate go run github.com/fjl/gencodec -type Config -formats toml -out gen_config.go
@@ -98,6 +101,10 @@ type Config struct {
 	NetworkId uint64 // Network ID to use for selecting peers to connect to
 	SyncMode  downloader.SyncMode
 
+	// Anchor the sync to the latest milestone or checkpoint finalized on heimdall
+	// instead of the chain advertised by peers, and pivot snap sync to it
+	FinalitySync bool
+
 	// This can be set to list of enrtree:// URLs which will be queried for
 	// for nodes to connect to.
 	EthDiscoveryURLs  []string
@@ -113,6 +120,10 @@ type Config struct {
 	// presence of these blocks for every new peer connection.
 	RequiredBlocks map[uint64]common.Hash `toml:"-"`
 
+	// FinalityPolicy limits the reorgs and rewinds of the chain relative to the
+	// whitelisted milestones and checkpoints
+	FinalityPolicy core.FinalityPolicyConfig
+
 	// Light client options
 	LightServ        int  `toml:",omitempty"` // Maximum percentage of time allowed for serving LES requests
 	LightIngress     int  `toml:",omitempty"` // Incoming bandwidth limit for light servers
@@ -178,12 +189,18 @@ type Config struct {
 	// URL to connect to Heimdall node
 	HeimdallURL string
 
+	// Additional Heimdall URLs to fail over to when HeimdallURL is unhealthy
+	HeimdallFailoverURLs []string
+
 	// No heimdall service
 	WithoutHeimdall bool
 
 	// Address to connect to Heimdall gRPC server
 	HeimdallgRPCAddress string
 
+	// Additional Heimdall gRPC addresses to fail over to when HeimdallgRPCAddress is unhealthy
+	HeimdallgRPCFailoverAddresses []string
+
 	// Run heimdall service as a child process
 	RunHeimdall bool
 
@@ -193,9 +210,26 @@ type Config struct {
 	// Use child heimdall process to fetch data, Only works when RunHeimdall is true
 	UseHeimdallApp bool
 
+	// Number of Heimdall endpoints which have to return identical spans, milestones,
+	// checkpoints and state-sync events for them to be accepted (0 disables quorum mode)
+	HeimdallQuorum int
+
+	// Persist Heimdall spans, state-sync events and checkpoints in the local database
+	HeimdallCache bool
+
+	// Serve Heimdall data from the local database only, without a Heimdall endpoint
+	HeimdallReplay bool
+
 	// Bor logs flag
 	BorLogs bool
 
+	// Number of blocks between two bor consensus snapshots stored on disk
+	BorSnapshotInterval uint64
+
+	// Number of blocks below the last finalized milestone for which bor consensus
+	// snapshots are kept (0 disables pruning)
+	BorSnapshotRetention uint64
+
 	// Parallel EVM (Block-STM) related config
 	ParallelEVM core.ParallelEVMConfig `toml:",omitempty"`
 
@@ -219,7 +253,9 @@ func CreateConsensusEngine(chainConfig *params.ChainConfig, ethConfig *Config, d
 		spanner := span.NewChainSpanner(blockchainAPI, contract.ValidatorSet(), chainConfig, common.HexToAddress(chainConfig.Bor.ValidatorContract))
 
 		if ethConfig.WithoutHeimdall {
-			return bor.New(chainConfig, db, blockchainAPI, spanner, nil, genesisContractsClient, ethConfig.DevFakeAuthor), nil
+			return newBor(chainConfig, ethConfig, db, blockchainAPI, spanner, nil, genesisContractsClient, ethConfig.DevFakeAuthor), nil
+		} else if ethConfig.HeimdallReplay {
+			return newBor(chainConfig, ethConfig, db, blockchainAPI, spanner, heimdallcache.NewReplayClient(db), genesisContractsClient, false), nil
 		} else {
 			if ethConfig.DevFakeAuthor {
 				log.Warn("Sanitizing DevFakeAuthor", "Use DevFakeAuthor with", "--bor.withoutheimdall")
@@ -229,12 +265,25 @@ func CreateConsensusEngine(chainConfig *params.ChainConfig, ethConfig *Config, d
 			if ethConfig.RunHeimdall && ethConfig.UseHeimdallApp {
 				heimdallClient = heimdallapp.NewHeimdallAppClient()
 			} else if ethConfig.HeimdallgRPCAddress != "" {
-				heimdallClient = heimdallgrpc.NewHeimdallGRPCClient(ethConfig.HeimdallgRPCAddress)
+				heimdallClient = heimdallgrpc.NewHeimdallGRPCClient(ethConfig.HeimdallgRPCAddress, ethConfig.HeimdallgRPCFailoverAddresses...)
 			} else {
-				heimdallClient = heimdall.NewHeimdallClient(ethConfig.HeimdallURL)
+				heimdallClient = heimdall.NewHeimdallClient(ethConfig.HeimdallURL, ethConfig.HeimdallFailoverURLs...)
+			}
+
+			if ethConfig.HeimdallQuorum > 0 && !(ethConfig.RunHeimdall && ethConfig.UseHeimdallApp) {
+				quorumClient, err := newHeimdallQuorumClient(ethConfig, heimdallClient)
+				if err != nil {
+					return nil, err
+				}
+
+				heimdallClient = quorumClient
 			}
 
-			return bor.New(chainConfig, db, blockchainAPI, spanner, heimdallClient, genesisContractsClient, false), nil
+			if ethConfig.HeimdallCache {
+				heimdallClient = heimdallcache.NewClient(heimdallClient, db)
+			}
+
+			return newBor(chainConfig, ethConfig, db, blockchainAPI, spanner, heimdallClient, genesisContractsClient, false), nil
 		}
 	}
 	if !chainConfig.TerminalTotalDifficultyPassed {
@@ -242,3 +291,33 @@ func CreateConsensusEngine(chainConfig *params.ChainConfig, ethConfig *Config, d
 	}
 	return beacon.New(ethash.NewFaker()), nil
 }
+
+// newBor creates the bor consensus engine with the snapshot policy of the config.
+func newBor(chainConfig *params.ChainConfig, ethConfig *Config, db ethdb.Database, blockchainAPI *ethapi.BlockChainAPI, spanner bor.Spanner, heimdallClient bor.IHeimdallClient, genesisContracts bor.GenesisContract, devFakeAuthor bool) *bor.Bor {
+	engine := bor.New(chainConfig, db, blockchainAPI, spanner, heimdallClient, genesisContracts, devFakeAuthor)
+	engine.SetSnapshotConfig(bor.SnapshotConfig{
+		Interval:  ethConfig.BorSnapshotInterval,
+		Retention: ethConfig.BorSnapshotRetention,
+	})
+
+	return engine
+}
+
+// newHeimdallQuorumClient creates a heimdall client which cross-checks responses
+// across all the configured endpoints, with one member client per endpoint. The
+// given failover client serves the requests which aren't cross-checked.
+func newHeimdallQuorumClient(ethConfig *Config, failoverClient bor.IHeimdallClient) (bor.IHeimdallClient, error) {
+	var members []heimdallquorum.Member
+
+	if ethConfig.HeimdallgRPCAddress != "" {
+		for _, address := range append([]string{ethConfig.HeimdallgRPCAddress}, ethConfig.HeimdallgRPCFailoverAddresses...) {
+			members = append(members, heimdallquorum.Member{Name: address, Client: heimdallgrpc.NewHeimdallGRPCClient(address)})
+		}
+	} else {
+		for _, url := range append([]string{ethConfig.HeimdallURL}, ethConfig.HeimdallFailoverURLs...) {
+			members = append(members, heimdallquorum.Member{Name: url, Client: heimdall.NewHeimdallClient(url)})
+		}
+	}
+
+	return heimdallquorum.NewClient(members, ethConfig.HeimdallQuorum, failoverClient)
+}
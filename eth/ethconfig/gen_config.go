@@ -21,20 +21,22 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Genesis                              *core.Genesis `toml:",omitempty"`
 		NetworkId                            uint64
 		SyncMode                             downloader.SyncMode
+		FinalitySync                         bool
 		EthDiscoveryURLs                     []string
 		SnapDiscoveryURLs                    []string
 		NoPruning                            bool
 		NoPrefetch                           bool
 		TxLookupLimit                        uint64                 `toml:",omitempty"`
 		RequiredBlocks                       map[uint64]common.Hash `toml:"-"`
-		LightServ                            int                    `toml:",omitempty"`
-		LightIngress                         int                    `toml:",omitempty"`
-		LightEgress                          int                    `toml:",omitempty"`
-		LightPeers                           int                    `toml:",omitempty"`
-		LightNoPrune                         bool                   `toml:",omitempty"`
-		LightNoSyncServe                     bool                   `toml:",omitempty"`
-		SkipBcVersionCheck                   bool                   `toml:"-"`
-		DatabaseHandles                      int                    `toml:"-"`
+		FinalityPolicy                       core.FinalityPolicyConfig
+		LightServ                            int  `toml:",omitempty"`
+		LightIngress                         int  `toml:",omitempty"`
+		LightEgress                          int  `toml:",omitempty"`
+		LightPeers                           int  `toml:",omitempty"`
+		LightNoPrune                         bool `toml:",omitempty"`
+		LightNoSyncServe                     bool `toml:",omitempty"`
+		SkipBcVersionCheck                   bool `toml:"-"`
+		DatabaseHandles                      int  `toml:"-"`
 		DatabaseCache                        int
 		DatabaseFreezer                      string
 		LevelDbCompactionTableSize           uint64
@@ -60,12 +62,19 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		RPCTxFeeCap                          float64
 		OverrideCancun                       *big.Int `toml:",omitempty"`
 		HeimdallURL                          string
+		HeimdallFailoverURLs                 []string
 		WithoutHeimdall                      bool
 		HeimdallgRPCAddress                  string
+		HeimdallgRPCFailoverAddresses        []string
 		RunHeimdall                          bool
 		RunHeimdallArgs                      string
 		UseHeimdallApp                       bool
+		HeimdallQuorum                       int
+		HeimdallCache                        bool
+		HeimdallReplay                       bool
 		BorLogs                              bool
+		BorSnapshotInterval                  uint64
+		BorSnapshotRetention                 uint64
 		ParallelEVM                          core.ParallelEVMConfig `toml:",omitempty"`
 		DevFakeAuthor                        bool                   `hcl:"devfakeauthor,optional" toml:"devfakeauthor,optional"`
 		OverrideVerkle                       *big.Int               `toml:",omitempty"`
@@ -74,12 +83,14 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Genesis = c.Genesis
 	enc.NetworkId = c.NetworkId
 	enc.SyncMode = c.SyncMode
+	enc.FinalitySync = c.FinalitySync
 	enc.EthDiscoveryURLs = c.EthDiscoveryURLs
 	enc.SnapDiscoveryURLs = c.SnapDiscoveryURLs
 	enc.NoPruning = c.NoPruning
 	enc.NoPrefetch = c.NoPrefetch
 	enc.TxLookupLimit = c.TxLookupLimit
 	enc.RequiredBlocks = c.RequiredBlocks
+	enc.FinalityPolicy = c.FinalityPolicy
 	enc.LightServ = c.LightServ
 	enc.LightIngress = c.LightIngress
 	enc.LightEgress = c.LightEgress
@@ -113,12 +124,19 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.RPCTxFeeCap = c.RPCTxFeeCap
 	enc.OverrideCancun = c.OverrideCancun
 	enc.HeimdallURL = c.HeimdallURL
+	enc.HeimdallFailoverURLs = c.HeimdallFailoverURLs
 	enc.WithoutHeimdall = c.WithoutHeimdall
 	enc.HeimdallgRPCAddress = c.HeimdallgRPCAddress
+	enc.HeimdallgRPCFailoverAddresses = c.HeimdallgRPCFailoverAddresses
 	enc.RunHeimdall = c.RunHeimdall
 	enc.RunHeimdallArgs = c.RunHeimdallArgs
 	enc.UseHeimdallApp = c.UseHeimdallApp
+	enc.HeimdallQuorum = c.HeimdallQuorum
+	enc.HeimdallCache = c.HeimdallCache
+	enc.HeimdallReplay = c.HeimdallReplay
 	enc.BorLogs = c.BorLogs
+	enc.BorSnapshotInterval = c.BorSnapshotInterval
+	enc.BorSnapshotRetention = c.BorSnapshotRetention
 	enc.ParallelEVM = c.ParallelEVM
 	enc.DevFakeAuthor = c.DevFakeAuthor
 	enc.OverrideVerkle = c.OverrideVerkle
@@ -131,20 +149,22 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Genesis                              *core.Genesis `toml:",omitempty"`
 		NetworkId                            *uint64
 		SyncMode                             *downloader.SyncMode
+		FinalitySync                         *bool
 		EthDiscoveryURLs                     []string
 		SnapDiscoveryURLs                    []string
 		NoPruning                            *bool
 		NoPrefetch                           *bool
 		TxLookupLimit                        *uint64                `toml:",omitempty"`
 		RequiredBlocks                       map[uint64]common.Hash `toml:"-"`
-		LightServ                            *int                   `toml:",omitempty"`
-		LightIngress                         *int                   `toml:",omitempty"`
-		LightEgress                          *int                   `toml:",omitempty"`
-		LightPeers                           *int                   `toml:",omitempty"`
-		LightNoPrune                         *bool                  `toml:",omitempty"`
-		LightNoSyncServe                     *bool                  `toml:",omitempty"`
-		SkipBcVersionCheck                   *bool                  `toml:"-"`
-		DatabaseHandles                      *int                   `toml:"-"`
+		FinalityPolicy                       *core.FinalityPolicyConfig
+		LightServ                            *int  `toml:",omitempty"`
+		LightIngress                         *int  `toml:",omitempty"`
+		LightEgress                          *int  `toml:",omitempty"`
+		LightPeers                           *int  `toml:",omitempty"`
+		LightNoPrune                         *bool `toml:",omitempty"`
+		LightNoSyncServe                     *bool `toml:",omitempty"`
+		SkipBcVersionCheck                   *bool `toml:"-"`
+		DatabaseHandles                      *int  `toml:"-"`
 		DatabaseCache                        *int
 		DatabaseFreezer                      *string
 		LevelDbCompactionTableSize           *uint64
@@ -170,12 +190,19 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		RPCTxFeeCap                          *float64
 		OverrideCancun                       *big.Int `toml:",omitempty"`
 		HeimdallURL                          *string
+		HeimdallFailoverURLs                 []string
 		WithoutHeimdall                      *bool
 		HeimdallgRPCAddress                  *string
+		HeimdallgRPCFailoverAddresses        []string
 		RunHeimdall                          *bool
 		RunHeimdallArgs                      *string
 		UseHeimdallApp                       *bool
+		HeimdallQuorum                       *int
+		HeimdallCache                        *bool
+		HeimdallReplay                       *bool
 		BorLogs                              *bool
+		BorSnapshotInterval                  *uint64
+		BorSnapshotRetention                 *uint64
 		ParallelEVM                          *core.ParallelEVMConfig `toml:",omitempty"`
 		DevFakeAuthor                        *bool                   `hcl:"devfakeauthor,optional" toml:"devfakeauthor,optional"`
 		OverrideVerkle                       *big.Int                `toml:",omitempty"`
@@ -193,6 +220,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.SyncMode != nil {
 		c.SyncMode = *dec.SyncMode
 	}
+	if dec.FinalitySync != nil {
+		c.FinalitySync = *dec.FinalitySync
+	}
 	if dec.EthDiscoveryURLs != nil {
 		c.EthDiscoveryURLs = dec.EthDiscoveryURLs
 	}
@@ -211,6 +241,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.RequiredBlocks != nil {
 		c.RequiredBlocks = dec.RequiredBlocks
 	}
+	if dec.FinalityPolicy != nil {
+		c.FinalityPolicy = *dec.FinalityPolicy
+	}
 	if dec.LightServ != nil {
 		c.LightServ = *dec.LightServ
 	}
@@ -310,12 +343,18 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.HeimdallURL != nil {
 		c.HeimdallURL = *dec.HeimdallURL
 	}
+	if dec.HeimdallFailoverURLs != nil {
+		c.HeimdallFailoverURLs = dec.HeimdallFailoverURLs
+	}
 	if dec.WithoutHeimdall != nil {
 		c.WithoutHeimdall = *dec.WithoutHeimdall
 	}
 	if dec.HeimdallgRPCAddress != nil {
 		c.HeimdallgRPCAddress = *dec.HeimdallgRPCAddress
 	}
+	if dec.HeimdallgRPCFailoverAddresses != nil {
+		c.HeimdallgRPCFailoverAddresses = dec.HeimdallgRPCFailoverAddresses
+	}
 	if dec.RunHeimdall != nil {
 		c.RunHeimdall = *dec.RunHeimdall
 	}
@@ -325,9 +364,24 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.UseHeimdallApp != nil {
 		c.UseHeimdallApp = *dec.UseHeimdallApp
 	}
+	if dec.HeimdallQuorum != nil {
+		c.HeimdallQuorum = *dec.HeimdallQuorum
+	}
+	if dec.HeimdallCache != nil {
+		c.HeimdallCache = *dec.HeimdallCache
+	}
+	if dec.HeimdallReplay != nil {
+		c.HeimdallReplay = *dec.HeimdallReplay
+	}
 	if dec.BorLogs != nil {
 		c.BorLogs = *dec.BorLogs
 	}
+	if dec.BorSnapshotInterval != nil {
+		c.BorSnapshotInterval = *dec.BorSnapshotInterval
+	}
+	if dec.BorSnapshotRetention != nil {
+		c.BorSnapshotRetention = *dec.BorSnapshotRetention
+	}
 	if dec.ParallelEVM != nil {
 		c.ParallelEVM = *dec.ParallelEVM
 	}
@@ -245,6 +245,20 @@ func (mr *MockBackendMockRecorder) SubscribeChainEvent(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeChainEvent", reflect.TypeOf((*MockBackend)(nil).SubscribeChainEvent), arg0)
 }
 
+// SubscribeFinalityEvent mocks base method.
+func (m *MockBackend) SubscribeFinalityEvent(arg0 chan<- core.FinalityEvent) event.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeFinalityEvent", arg0)
+	ret0, _ := ret[0].(event.Subscription)
+	return ret0
+}
+
+// SubscribeFinalityEvent indicates an expected call of SubscribeFinalityEvent.
+func (mr *MockBackendMockRecorder) SubscribeFinalityEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeFinalityEvent", reflect.TypeOf((*MockBackend)(nil).SubscribeFinalityEvent), arg0)
+}
+
 // SubscribeLogsEvent mocks base method.
 func (m *MockBackend) SubscribeLogsEvent(arg0 chan<- []*types.Log) event.Subscription {
 	m.ctrl.T.Helper()
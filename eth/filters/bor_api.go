@@ -6,6 +6,8 @@ import (
 
 	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -85,3 +87,108 @@ func (api *FilterAPI) NewDeposits(ctx context.Context, crit ethereum.StateSyncFi
 
 	return rpcSub, nil
 }
+
+// RPCFinalityEvent is the notification of a whitelisted checkpoint or milestone.
+type RPCFinalityEvent struct {
+	Type        string         `json:"type"` // checkpoint or milestone
+	MilestoneID string         `json:"milestoneId,omitempty"`
+	StartBlock  hexutil.Uint64 `json:"startBlock"`
+	EndBlock    hexutil.Uint64 `json:"endBlock"`
+	Hash        common.Hash    `json:"hash"` // Hash of the end block
+}
+
+// FinalityEvents send a notification each time a checkpoint or milestone is
+// whitelisted, finalizing the blocks up to its end block.
+func (api *FilterAPI) FinalityEvents(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		finality := make(chan core.FinalityEvent, finalityEvChanSize)
+		finalitySub := api.events.SubscribeFinality(finality)
+
+		for {
+			select {
+			case ev := <-finality:
+				notifier.Notify(rpcSub.ID, &RPCFinalityEvent{
+					Type:        ev.Type,
+					MilestoneID: ev.MilestoneID,
+					StartBlock:  hexutil.Uint64(ev.StartBlock),
+					EndBlock:    hexutil.Uint64(ev.EndBlock),
+					Hash:        ev.Hash,
+				})
+			case <-rpcSub.Err():
+				finalitySub.Unsubscribe()
+				return
+			case <-notifier.Closed():
+				finalitySub.Unsubscribe()
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// FinalizedHeads send a notification each time the finalized block, as resolved
+// by the "finalized" block tag, changes. It changes when a checkpoint or milestone
+// is whitelisted, or when the chain reaches one which was whitelisted ahead of it.
+func (api *FilterAPI) FinalizedHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	finalized := func() *types.Header {
+		header, err := api.sys.backend.HeaderByNumber(context.Background(), rpc.FinalizedBlockNumber)
+		if err != nil {
+			return nil
+		}
+
+		return header
+	}
+
+	var last common.Hash
+	if header := finalized(); header != nil {
+		last = header.Hash()
+	}
+
+	go func() {
+		finality := make(chan core.FinalityEvent, finalityEvChanSize)
+		finalitySub := api.events.SubscribeFinality(finality)
+
+		headers := make(chan *types.Header)
+		headersSub := api.events.SubscribeNewHeads(headers)
+
+		// Headers are delivered unbuffered, unsubscribe from them first so the
+		// event loop can't be stuck delivering one
+		defer func() {
+			headersSub.Unsubscribe()
+			finalitySub.Unsubscribe()
+		}()
+
+		for {
+			select {
+			case <-finality:
+			case <-headers:
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+
+			if header := finalized(); header != nil && header.Hash() != last {
+				last = header.Hash()
+				notifier.Notify(rpcSub.ID, header)
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
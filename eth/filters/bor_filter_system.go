@@ -30,3 +30,27 @@ func (es *EventSystem) SubscribeNewDeposits(data chan *types.StateSyncData) *Sub
 
 	return es.subscribe(sub)
 }
+
+func (es *EventSystem) handleFinalityEvent(filters filterIndex, ev core.FinalityEvent) {
+	for _, f := range filters[FinalitySubscription] {
+		f.finality <- ev
+	}
+}
+
+// SubscribeFinality creates a subscription that writes the checkpoints and milestones
+// which are whitelisted, finalizing the blocks up to their end block
+func (es *EventSystem) SubscribeFinality(finality chan core.FinalityEvent) *Subscription {
+	sub := &subscription{
+		id:        rpc.NewID(),
+		typ:       FinalitySubscription,
+		created:   time.Now(),
+		logs:      make(chan []*types.Log),
+		txs:       make(chan []*types.Transaction),
+		headers:   make(chan *types.Header),
+		finality:  finality,
+		installed: make(chan struct{}),
+		err:       make(chan error),
+	}
+
+	return es.subscribe(sub)
+}
@@ -0,0 +1,65 @@
+package filters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+func TestFinalitySubscription(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(t, db, Config{})
+		api          = NewFilterAPI(sys, false, true)
+		events       = []core.FinalityEvent{
+			{Type: core.FinalityMilestoneEvent, MilestoneID: "milestone-1", StartBlock: 0, EndBlock: 15, Hash: common.HexToHash("0x1")},
+			{Type: core.FinalityCheckpointEvent, StartBlock: 0, EndBlock: 255, Hash: common.HexToHash("0x2")},
+		}
+	)
+
+	chan0 := make(chan core.FinalityEvent)
+	sub0 := api.events.SubscribeFinality(chan0)
+	chan1 := make(chan core.FinalityEvent)
+	sub1 := api.events.SubscribeFinality(chan1)
+
+	done := make(chan struct{})
+
+	go func() { // simulate client
+		defer close(done)
+
+		i0, i1 := 0, 0
+		for i0 != len(events) || i1 != len(events) {
+			select {
+			case ev := <-chan0:
+				if ev != events[i0] {
+					t.Errorf("sub0 received invalid event on index %d, want %v, got %v", i0, events[i0], ev)
+				}
+
+				i0++
+			case ev := <-chan1:
+				if ev != events[i1] {
+					t.Errorf("sub1 received invalid event on index %d, want %v, got %v", i1, events[i1], ev)
+				}
+
+				i1++
+			case <-time.After(5 * time.Second):
+				t.Errorf("timeout waiting for finality events")
+				return
+			}
+		}
+
+		sub0.Unsubscribe()
+		sub1.Unsubscribe()
+	}()
+
+	for _, ev := range events {
+		backend.finalityFeed.Send(ev)
+	}
+
+	<-done
+}
@@ -78,6 +78,7 @@ type Backend interface {
 	SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription
 	SubscribePendingLogsEvent(ch chan<- []*types.Log) event.Subscription
 	SubscribeStateSyncEvent(ch chan<- core.StateSyncEvent) event.Subscription
+	SubscribeFinalityEvent(ch chan<- core.FinalityEvent) event.Subscription
 
 	BloomStatus() (uint64, uint64)
 	ServiceFilter(ctx context.Context, session *bloombits.MatcherSession)
@@ -180,6 +181,8 @@ const (
 	BlocksSubscription
 	// StateSyncSubscription to listen main chain state
 	StateSyncSubscription
+	// FinalitySubscription queries for whitelisted checkpoints and milestones
+	FinalitySubscription
 	// LastIndexSubscription keeps track of the last index
 	LastIndexSubscription
 )
@@ -196,6 +199,8 @@ const (
 	chainEvChanSize = 10
 	// stateEvChanSize is the size of channel listening to StateSyncEvent.
 	stateEvChanSize = 10
+	// finalityEvChanSize is the size of channel listening to FinalityEvent.
+	finalityEvChanSize = 10
 )
 
 type subscription struct {
@@ -210,6 +215,7 @@ type subscription struct {
 	err       chan error    // closed when the filter is uninstalled
 
 	stateSyncData chan *types.StateSyncData
+	finality      chan core.FinalityEvent
 }
 
 // EventSystem creates subscriptions, processes events and broadcasts them to the
@@ -239,6 +245,8 @@ type EventSystem struct {
 	// Bor related subscription and channels
 	stateSyncSub event.Subscription       // Subscription for new state event
 	stateSyncCh  chan core.StateSyncEvent // Channel to receive deposit state change event
+	finalitySub  event.Subscription       // Subscription for whitelisted checkpoints and milestones
+	finalityCh   chan core.FinalityEvent  // Channel to receive whitelisted checkpoints and milestones
 }
 
 // NewEventSystem creates a new manager that listens for event on the given mux,
@@ -260,6 +268,7 @@ func NewEventSystem(sys *FilterSystem, lightMode bool) *EventSystem {
 		pendingLogsCh: make(chan []*types.Log, logsChanSize),
 		chainCh:       make(chan core.ChainEvent, chainEvChanSize),
 		stateSyncCh:   make(chan core.StateSyncEvent, stateEvChanSize),
+		finalityCh:    make(chan core.FinalityEvent, finalityEvChanSize),
 	}
 
 	// Subscribe events
@@ -269,6 +278,7 @@ func NewEventSystem(sys *FilterSystem, lightMode bool) *EventSystem {
 	m.chainSub = m.backend.SubscribeChainEvent(m.chainCh)
 	m.pendingLogsSub = m.backend.SubscribePendingLogsEvent(m.pendingLogsCh)
 	m.stateSyncSub = m.backend.SubscribeStateSyncEvent(m.stateSyncCh)
+	m.finalitySub = m.backend.SubscribeFinalityEvent(m.finalityCh)
 
 	// Make sure none of the subscriptions are empty
 	if m.txsSub == nil || m.logsSub == nil || m.rmLogsSub == nil || m.chainSub == nil || m.pendingLogsSub == nil {
@@ -308,6 +318,7 @@ func (sub *Subscription) Unsubscribe() {
 			case <-sub.f.logs:
 			case <-sub.f.txs:
 			case <-sub.f.headers:
+			case <-sub.f.finality:
 			}
 		}
 
@@ -602,6 +613,7 @@ func (es *EventSystem) eventLoop() {
 		es.pendingLogsSub.Unsubscribe()
 		es.chainSub.Unsubscribe()
 		es.stateSyncSub.Unsubscribe()
+		es.finalitySub.Unsubscribe()
 	}()
 
 	index := make(filterIndex)
@@ -623,6 +635,8 @@ func (es *EventSystem) eventLoop() {
 			es.handleChainEvent(index, ev)
 		case ev := <-es.stateSyncCh:
 			es.handleStateSyncEvent(index, ev)
+		case ev := <-es.finalityCh:
+			es.handleFinalityEvent(index, ev)
 
 		case f := <-es.install:
 			if f.typ == MinedAndPendingLogsSubscription {
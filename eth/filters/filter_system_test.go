@@ -54,12 +54,17 @@ type testBackend struct {
 	pendingReceipts types.Receipts
 
 	stateSyncFeed event.Feed
+	finalityFeed  event.Feed
 }
 
 func (b *testBackend) SubscribeStateSyncEvent(ch chan<- core.StateSyncEvent) event.Subscription {
 	return b.stateSyncFeed.Subscribe(ch)
 }
 
+func (b *testBackend) SubscribeFinalityEvent(ch chan<- core.FinalityEvent) event.Subscription {
+	return b.finalityFeed.Subscribe(ch)
+}
+
 func (b *testBackend) ChainConfig() *params.ChainConfig {
 	return params.TestChainConfig
 }
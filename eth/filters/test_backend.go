@@ -26,6 +26,7 @@ type TestBackend struct {
 	chainFeed       event.Feed
 
 	stateSyncFeed event.Feed
+	finalityFeed  event.Feed
 }
 
 func (b *TestBackend) BloomStatus() (uint64, uint64) {
@@ -177,6 +178,10 @@ func (b *TestBackend) SubscribeStateSyncEvent(ch chan<- core.StateSyncEvent) eve
 	return b.stateSyncFeed.Subscribe(ch)
 }
 
+func (b *TestBackend) SubscribeFinalityEvent(ch chan<- core.FinalityEvent) event.Subscription {
+	return b.finalityFeed.Subscribe(ch)
+}
+
 func (b *TestBackend) ChainConfig() *params.ChainConfig { panic("not implemented") }
 
 func (b *TestBackend) CurrentHeader() *types.Header { panic("not implemented") }
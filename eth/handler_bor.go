@@ -7,6 +7,9 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/bor"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -24,19 +27,20 @@ var (
 
 // fetchWhitelistCheckpoint fetches the latest checkpoint from it's local heimdall
 // and verifies the data against bor data.
-func (h *ethHandler) fetchWhitelistCheckpoint(ctx context.Context, bor *bor.Bor, eth *Ethereum, verifier *borVerifier) (uint64, common.Hash, error) {
-	var (
-		blockNum  uint64
-		blockHash common.Hash
-	)
-
+func (h *ethHandler) fetchWhitelistCheckpoint(ctx context.Context, bor *bor.Bor, eth *Ethereum, verifier *borVerifier) (*core.FinalityEvent, error) {
 	// fetch the latest checkpoint from Heimdall
 	checkpoint, err := bor.HeimdallClient.FetchCheckpoint(ctx, -1)
 	if err != nil {
 		log.Debug("Failed to fetch latest checkpoint for whitelisting", "err", err)
-		return blockNum, blockHash, errCheckpoint
+		return nil, errCheckpoint
 	}
 
+	return h.verifyWhitelistCheckpoint(ctx, checkpoint, eth, verifier)
+}
+
+// verifyWhitelistCheckpoint verifies the checkpoint against bor data. It returns
+// the finality event of the checkpoint if it's verified.
+func (h *ethHandler) verifyWhitelistCheckpoint(ctx context.Context, checkpoint *checkpoint.Checkpoint, eth *Ethereum, verifier *borVerifier) (*core.FinalityEvent, error) {
 	log.Info("Got new checkpoint from heimdall", "start", checkpoint.StartBlock.Uint64(), "end", checkpoint.EndBlock.Uint64(), "rootHash", checkpoint.RootHash.String())
 
 	// Verify if the checkpoint fetched can be added to the local whitelist entry or not
@@ -45,50 +49,58 @@ func (h *ethHandler) fetchWhitelistCheckpoint(ctx context.Context, bor *bor.Bor,
 	hash, err := verifier.verify(ctx, eth, h, checkpoint.StartBlock.Uint64(), checkpoint.EndBlock.Uint64(), checkpoint.RootHash.String()[2:], true)
 	if err != nil {
 		log.Warn("Failed to whitelist checkpoint", "err", err)
-		return blockNum, blockHash, err
+		return nil, err
 	}
 
-	blockNum = checkpoint.EndBlock.Uint64()
-	blockHash = common.HexToHash(hash)
-
-	return blockNum, blockHash, nil
+	return &core.FinalityEvent{
+		Type:       core.FinalityCheckpointEvent,
+		StartBlock: checkpoint.StartBlock.Uint64(),
+		EndBlock:   checkpoint.EndBlock.Uint64(),
+		Hash:       common.HexToHash(hash),
+	}, nil
 }
 
 // fetchWhitelistMilestone fetches the latest milestone from it's local heimdall
 // and verifies the data against bor data.
-func (h *ethHandler) fetchWhitelistMilestone(ctx context.Context, bor *bor.Bor, eth *Ethereum, verifier *borVerifier) (uint64, common.Hash, error) {
-	var (
-		num  uint64
-		hash common.Hash
-	)
-
+func (h *ethHandler) fetchWhitelistMilestone(ctx context.Context, bor *bor.Bor, eth *Ethereum, verifier *borVerifier) (*core.FinalityEvent, error) {
 	// fetch latest milestone
 	milestone, err := bor.HeimdallClient.FetchMilestone(ctx)
 	if errors.Is(err, heimdall.ErrServiceUnavailable) {
 		log.Debug("Failed to fetch latest milestone for whitelisting", "err", err)
-		return num, hash, err
+		return nil, err
 	}
 
 	if err != nil {
 		log.Error("Failed to fetch latest milestone for whitelisting", "err", err)
-		return num, hash, errMilestone
+		return nil, errMilestone
 	}
 
-	num = milestone.EndBlock.Uint64()
-	hash = milestone.Hash
+	return h.verifyWhitelistMilestone(ctx, milestone, eth, verifier)
+}
+
+// verifyWhitelistMilestone verifies the milestone against bor data. It returns
+// the finality event of the milestone, along with the verification error if any.
+func (h *ethHandler) verifyWhitelistMilestone(ctx context.Context, milestone *milestone.Milestone, eth *Ethereum, verifier *borVerifier) (*core.FinalityEvent, error) {
+	event := &core.FinalityEvent{
+		Type:        core.FinalityMilestoneEvent,
+		MilestoneID: milestone.MilestoneID,
+		StartBlock:  milestone.StartBlock.Uint64(),
+		EndBlock:    milestone.EndBlock.Uint64(),
+		Hash:        milestone.Hash,
+	}
 
 	log.Info("Got new milestone from heimdall", "start", milestone.StartBlock.Uint64(), "end", milestone.EndBlock.Uint64(), "hash", milestone.Hash.String())
 
 	// Verify if the milestone fetched can be added to the local whitelist entry or not
 	// If verified, it returns the hash of the end block of the milestone. If not,
 	// it will return appropriate error.
-	_, err = verifier.verify(ctx, eth, h, milestone.StartBlock.Uint64(), milestone.EndBlock.Uint64(), milestone.Hash.String()[2:], false)
+	_, err := verifier.verify(ctx, eth, h, milestone.StartBlock.Uint64(), milestone.EndBlock.Uint64(), milestone.Hash.String()[2:], false)
 	if err != nil {
 		h.downloader.UnlockSprint(milestone.EndBlock.Uint64())
-		return num, hash, err
+		return event, err
 	}
 
-	return num, hash, nil
+	return event, nil
 }
 
 func (h *ethHandler) fetchNoAckMilestone(ctx context.Context, bor *bor.Bor) (string, error) {
@@ -14,6 +14,8 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/event"
 )
 
 type mockHeimdall struct {
@@ -102,18 +104,20 @@ func fetchCheckpointTest(t *testing.T, heimdall *mockHeimdall, bor *bor.Bor, han
 	// create a background context
 	ctx := context.Background()
 
-	_, _, err := handler.fetchWhitelistCheckpoint(ctx, bor, nil, verifier)
+	_, err := handler.fetchWhitelistCheckpoint(ctx, bor, nil, verifier)
 	require.Equal(t, err, errCheckpoint)
 
 	// create 4 mock checkpoints
 	checkpoints = createMockCheckpoints(4)
 
-	blockNum, blockHash, err := handler.fetchWhitelistCheckpoint(ctx, bor, nil, verifier)
+	finality, err := handler.fetchWhitelistCheckpoint(ctx, bor, nil, verifier)
 
 	// Check if we have expected result
 	require.Equal(t, err, nil)
-	require.Equal(t, checkpoints[len(checkpoints)-1].EndBlock.Uint64(), blockNum)
-	require.Equal(t, checkpoints[len(checkpoints)-1].RootHash, blockHash)
+	require.Equal(t, core.FinalityCheckpointEvent, finality.Type)
+	require.Equal(t, checkpoints[len(checkpoints)-1].StartBlock.Uint64(), finality.StartBlock)
+	require.Equal(t, checkpoints[len(checkpoints)-1].EndBlock.Uint64(), finality.EndBlock)
+	require.Equal(t, checkpoints[len(checkpoints)-1].RootHash, finality.Hash)
 }
 
 func fetchMilestoneTest(t *testing.T, heimdall *mockHeimdall, bor *bor.Bor, handler *ethHandler, verifier *borVerifier) {
@@ -132,18 +136,20 @@ func fetchMilestoneTest(t *testing.T, heimdall *mockHeimdall, bor *bor.Bor, hand
 	// create a background context
 	ctx := context.Background()
 
-	_, _, err := handler.fetchWhitelistMilestone(ctx, bor, nil, verifier)
+	_, err := handler.fetchWhitelistMilestone(ctx, bor, nil, verifier)
 	require.Equal(t, err, errMilestone)
 
 	// create 4 mock checkpoints
 	milestones = createMockMilestones(4)
 
-	num, hash, err := handler.fetchWhitelistMilestone(ctx, bor, nil, verifier)
+	finality, err := handler.fetchWhitelistMilestone(ctx, bor, nil, verifier)
 
 	// Check if we have expected result
 	require.Equal(t, err, nil)
-	require.Equal(t, milestones[len(milestones)-1].EndBlock.Uint64(), num)
-	require.Equal(t, milestones[len(milestones)-1].Hash, hash)
+	require.Equal(t, core.FinalityMilestoneEvent, finality.Type)
+	require.Equal(t, milestones[len(milestones)-1].StartBlock.Uint64(), finality.StartBlock)
+	require.Equal(t, milestones[len(milestones)-1].EndBlock.Uint64(), finality.EndBlock)
+	require.Equal(t, milestones[len(milestones)-1].Hash, finality.Hash)
 }
 
 func createMockCheckpoints(count int) []*checkpoint.Checkpoint {
@@ -187,3 +193,78 @@ func createMockMilestones(count int) []*milestone.Milestone {
 
 	return milestones
 }
+
+// TestHandleHeimdallSubscriptionRetry tests that items failing to be processed
+// are retried until they succeed, unless a newer item replaces them.
+func TestHandleHeimdallSubscriptionRetry(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ch      = make(chan *milestone.Milestone)
+		closeCh = make(chan struct{})
+		handled = make(chan uint64, 16)
+		done    = make(chan struct{})
+	)
+
+	sub := event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+
+	failures := map[uint64]int{1: 2, 2: 1 << 30}
+
+	go func() {
+		defer close(done)
+
+		handleHeimdallSubscription(sub, ch, closeCh, 10*time.Millisecond, "milestone", func(_ context.Context, m *milestone.Milestone) error {
+			end := m.EndBlock.Uint64()
+			handled <- end
+
+			if failures[end] > 0 {
+				failures[end]--
+				return errMilestone
+			}
+
+			return nil
+		})
+	}()
+
+	receive := func() uint64 {
+		select {
+		case end := <-handled:
+			return end
+		case <-time.After(time.Second):
+			t.Fatal("milestone not handled")
+			return 0
+		}
+	}
+
+	// The first milestone is retried until it succeeds
+	ch <- &milestone.Milestone{EndBlock: big.NewInt(1)}
+
+	for i := 0; i < 3; i++ {
+		require.Equal(t, uint64(1), receive())
+	}
+
+	// A failed milestone is replaced by the next one
+	ch <- &milestone.Milestone{EndBlock: big.NewInt(2)}
+	require.Equal(t, uint64(2), receive())
+
+	ch <- &milestone.Milestone{EndBlock: big.NewInt(3)}
+
+	end := receive()
+	for end == 2 {
+		end = receive()
+	}
+
+	require.Equal(t, uint64(3), end)
+
+	select {
+	case end := <-handled:
+		t.Fatalf("unexpected retry of milestone %d", end)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(closeCh)
+	<-done
+}
@@ -82,8 +82,8 @@ func (p *Peer) broadcastTransactions() {
 			for i := 0; i < len(queue) && size < maxTxPacketSize; i++ {
 				tx := p.txpool.Get(queue[i])
 
-				// Skip EIP-4337 bundled transactions
-				if tx != nil && tx.Tx.GetOptions() == nil {
+				// Skip EIP-4337 bundled and private transactions
+				if tx != nil && tx.Tx.GetOptions() == nil && !tx.Private {
 					txs = append(txs, tx.Tx)
 					size += common.StorageSize(tx.Tx.Size())
 				}
@@ -154,8 +154,8 @@ func (p *Peer) announceTransactions() {
 			)
 			for count = 0; count < len(queue) && size < maxTxPacketSize; count++ {
 				tx := p.txpool.Get(queue[count])
-				// Skip EIP-4337 bundled transactions
-				if tx != nil && tx.Tx.GetOptions() == nil {
+				// Skip EIP-4337 bundled and private transactions
+				if tx != nil && tx.Tx.GetOptions() == nil && !tx.Private {
 					pending = append(pending, queue[count])
 					pendingTypes = append(pendingTypes, tx.Tx.Type())
 					pendingSizes = append(pendingSizes, uint32(tx.Tx.Size()))
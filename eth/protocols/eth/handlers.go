@@ -554,9 +554,9 @@ func answerGetPooledTransactions(backend Backend, query GetPooledTransactionsPac
 		if bytes >= softResponseLimit {
 			break
 		}
-		// Retrieve the requested transaction, skipping if unknown to us
+		// Retrieve the requested transaction, skipping if unknown to us or private
 		tx := backend.TxPool().Get(hash)
-		if tx == nil {
+		if tx == nil || tx.Private {
 			continue
 		}
 		// If known, encode and queue for response packet
@@ -197,6 +197,9 @@ type TraceConfig struct {
 	Reexec  *uint64
 	Path    *string
 	IOFlag  *bool
+	// Parallel replays blocks with Block-STM to produce the intermediate
+	// states of their transactions, instead of executing them one by one
+	Parallel *bool
 	// Config specific to given tracer. Note struct logger
 	// config are historically embedded in main object.
 	TracerConfig    json.RawMessage
@@ -736,7 +739,8 @@ func (api *API) StandardTraceBadBlockToFile(ctx context.Context, hash common.Has
 // executes all the transactions contained within. The return value will be one item
 // per transaction, dependent on the requested tracer.
 // We always run parallel execution
-// One thread runs along and executes txs without tracing enabled to generate their prestate.
+// One thread runs along and executes txs without tracing enabled to generate their prestate,
+// or the block is replayed with Block-STM if config.Parallel is set.
 // Worker threads take the tasks and the prestate and trace them.
 func (api *API) traceBlock(ctx context.Context, block *types.Block, config *TraceConfig) ([]*txTraceResult, error) {
 	if config == nil {
@@ -774,6 +778,9 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 		ioflag = *config.IOFlag
 	}
 
+	// IO tracing dumps the accesses of the serial execution
+	parallel := !ioflag && config != nil && config.Parallel != nil && *config.Parallel
+
 	statedb, release, err := api.backend.StateAtBlock(ctx, parent, reexec, nil, true, false)
 	if err != nil {
 		return nil, err
@@ -859,8 +866,16 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 		london = api.backend.ChainConfig().IsLondon(block.Number())
 	}
 
+	// The parallel replay feeds all the transactions, skip the serial one
+	serialTxs := txs
+
+	if parallel {
+		failed = api.replayBlockParallel(ctx, block, txs, stateSyncPresent, statedb, config, jobs)
+		serialTxs = nil
+	}
+
 txloop:
-	for i, tx := range txs {
+	for i, tx := range serialTxs {
 		if ioflag {
 			// copy of statedb
 			statedb = statedb.Copy()
@@ -977,6 +992,35 @@ txloop:
 	}
 }
 
+// replayBlockParallel re-executes the transactions of a block with Block-STM on
+// top of the state of its parent, and sends every transaction to the tracers as
+// soon as its pre-state is known. The state-sync transaction, if present, is
+// traced on top of the state after all the others.
+func (api *API) replayBlockParallel(ctx context.Context, block *types.Block, txs []*types.Transaction, stateSyncPresent bool, statedb *state.StateDB, config *TraceConfig, jobs chan<- *txTraceTask) error {
+	regular := txs
+	if stateSyncPresent {
+		regular = txs[:len(txs)-1]
+	}
+
+	send := func(index int, prestate *state.StateDB) {
+		select {
+		case <-ctx.Done():
+		case jobs <- &txTraceTask{statedb: prestate, index: index}:
+		}
+	}
+
+	result, err := core.ReplayTransactionsParallel(api.backend.ChainConfig(), api.chainContext(ctx), statedb, block.Header(), regular, vm.Config{}, runtime.NumCPU(), send, ctx)
+	if err != nil {
+		return err
+	}
+
+	if stateSyncPresent && *config.BorTraceEnabled {
+		send(len(txs)-1, result.State)
+	}
+
+	return ctx.Err()
+}
+
 // standardTraceBlockToFile configures a new tracer which uses standard JSON output,
 // and traces either a full block or an individual transaction. The return value will
 // be one filename per transaction traced.
@@ -3,10 +3,13 @@ package tracers
 import (
 	"context"
 	"fmt"
+	"runtime"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/bor/statefull"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
@@ -75,7 +78,7 @@ func (api *API) traceBorBlock(ctx context.Context, block *types.Block, config *T
 
 	blockCtx := core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
 
-	traceTxn := func(indx int, tx *types.Transaction, borTx bool) *TxTraceResult {
+	traceTxn := func(indx int, tx *types.Transaction, borTx bool, statedb *state.StateDB) *TxTraceResult {
 		message, _ := core.TransactionToMessage(tx, signer, block.BaseFee())
 		txContext := core.NewEVMTxContext(message)
 
@@ -88,7 +91,10 @@ func (api *API) traceBorBlock(ctx context.Context, block *types.Block, config *T
 		// Not sure if we need to do this
 		statedb.SetTxContext(tx.Hash(), indx)
 
-		var execRes *core.ExecutionResult
+		var (
+			execRes *core.ExecutionResult
+			err     error
+		)
 
 		if borTx {
 			callmsg := prepareCallMessage(*message)
@@ -122,17 +128,72 @@ func (api *API) traceBorBlock(ctx context.Context, block *types.Block, config *T
 		return res
 	}
 
+	if config != nil && config.Parallel != nil && *config.Parallel {
+		res.Transactions, err = api.traceBorBlockParallel(ctx, block, txs, stateSyncPresent, statedb, traceTxn)
+		if err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	}
+
 	for indx, tx := range txs {
 		if stateSyncPresent && indx == len(txs)-1 {
-			res.Transactions = append(res.Transactions, traceTxn(indx, tx, true))
+			res.Transactions = append(res.Transactions, traceTxn(indx, tx, true, statedb))
 		} else {
-			res.Transactions = append(res.Transactions, traceTxn(indx, tx, false))
+			res.Transactions = append(res.Transactions, traceTxn(indx, tx, false, statedb))
 		}
 	}
 
 	return res, nil
 }
 
+// traceBorBlockParallel replays the transactions of a block with Block-STM on top
+// of the state of its parent, and traces every transaction on its own pre-state
+// as soon as it is known. The state-sync transaction, if present, is traced on
+// top of the state after all the others.
+func (api *API) traceBorBlockParallel(ctx context.Context, block *types.Block, txs []*types.Transaction, stateSyncPresent bool, statedb *state.StateDB, traceTxn func(int, *types.Transaction, bool, *state.StateDB) *TxTraceResult) ([]*TxTraceResult, error) {
+	var (
+		results = make([]*TxTraceResult, len(txs))
+		slots   = make(chan struct{}, runtime.NumCPU())
+		pend    sync.WaitGroup
+	)
+
+	trace := func(indx int, prestate *state.StateDB) {
+		slots <- struct{}{}
+
+		pend.Add(1)
+
+		go func() {
+			defer func() {
+				<-slots
+				pend.Done()
+			}()
+
+			results[indx] = traceTxn(indx, txs[indx], stateSyncPresent && indx == len(txs)-1, prestate)
+		}()
+	}
+
+	regular := txs
+	if stateSyncPresent {
+		regular = txs[:len(txs)-1]
+	}
+
+	result, err := core.ReplayTransactionsParallel(api.backend.ChainConfig(), api.chainContext(ctx), statedb, block.Header(), regular, vm.Config{}, runtime.NumCPU(), trace, ctx)
+	if err != nil {
+		pend.Wait()
+		return nil, err
+	}
+
+	if stateSyncPresent {
+		trace(len(txs)-1, result.State)
+	}
+
+	pend.Wait()
+
+	return results, nil
+}
+
 type TraceBlockRequest struct {
 	Number     int64
 	Hash       string
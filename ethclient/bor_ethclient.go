@@ -2,9 +2,11 @@ package ethclient
 
 import (
 	"context"
+	"math/big"
 
 	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -39,3 +41,90 @@ func (ec *Client) GetBorBlockReceipt(ctx context.Context, hash common.Hash) (*ty
 
 	return r, err
 }
+
+// GetStateSyncEvents returns the state-sync events committed in the canonical
+// blocks of the given range. A nil block number means the latest block.
+func (ec *Client) GetStateSyncEvents(ctx context.Context, fromBlock *big.Int, toBlock *big.Int) ([]*types.StateSyncRecord, error) {
+	var events []*types.StateSyncRecord
+	if err := ec.c.CallContext(ctx, &events, "bor_getStateSyncEvents", toBlockNumArg(fromBlock), toBlockNumArg(toBlock)); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GetStateSyncEventsByContract returns the state-sync events sent to the given
+// receiver contract in the canonical blocks of the given range.
+func (ec *Client) GetStateSyncEventsByContract(ctx context.Context, contract common.Address, fromBlock *big.Int, toBlock *big.Int) ([]*types.StateSyncRecord, error) {
+	var events []*types.StateSyncRecord
+	if err := ec.c.CallContext(ctx, &events, "bor_getStateSyncEventsByContract", contract, toBlockNumArg(fromBlock), toBlockNumArg(toBlock)); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GetStateSyncEventByID returns the state-sync event with the given state id.
+func (ec *Client) GetStateSyncEventByID(ctx context.Context, id uint64) (*types.StateSyncRecord, error) {
+	var event *types.StateSyncRecord
+
+	err := ec.c.CallContext(ctx, &event, "bor_getStateSyncEventByID", id)
+	if err == nil && event == nil {
+		return nil, ethereum.NotFound
+	}
+
+	return event, err
+}
+
+// SimulateStateSync commits the state-sync events pending in heimdall on top
+// of the latest block, without producing it.
+func (ec *Client) SimulateStateSync(ctx context.Context) (*bor.StateSyncSimulation, error) {
+	var simulation *bor.StateSyncSimulation
+	if err := ec.c.CallContext(ctx, &simulation, "bor_simulateStateSync"); err != nil {
+		return nil, err
+	}
+
+	return simulation, nil
+}
+
+// GetValidatorPerformance returns the block production record of every validator
+// over the given block range. A nil block number means the latest block.
+func (ec *Client) GetValidatorPerformance(ctx context.Context, fromBlock *big.Int, toBlock *big.Int) (*bor.PerformanceReport, error) {
+	var report *bor.PerformanceReport
+	if err := ec.c.CallContext(ctx, &report, "bor_getValidatorPerformance", toBlockNumArg(fromBlock), toBlockNumArg(toBlock)); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// SubscribeValidatorPerformance subscribes to the validator performance of every
+// sprint, sent once its last block is imported.
+func (ec *Client) SubscribeValidatorPerformance(ctx context.Context, ch chan<- *bor.PerformanceReport) (ethereum.Subscription, error) {
+	return ec.c.Subscribe(ctx, "bor", ch, "validatorPerformance")
+}
+
+// GetConfigAt returns the effective bor configuration at the given block, along
+// with the parameter transitions scheduled after it. A nil block number means
+// the latest block.
+func (ec *Client) GetConfigAt(ctx context.Context, number *big.Int) (*bor.ConfigAt, error) {
+	var config *bor.ConfigAt
+	if err := ec.c.CallContext(ctx, &config, "bor_getConfigAt", toBlockNumArg(number)); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// GetCheckpointProof returns the merkle branch proving the inclusion of the
+// header of a block in the root hash of the start to end block headers. The
+// proof can be checked against the checkpoint root hash with
+// bor.VerifyCheckpointProof.
+func (ec *Client) GetCheckpointProof(ctx context.Context, start uint64, end uint64, number uint64) (*bor.CheckpointProof, error) {
+	var proof *bor.CheckpointProof
+	if err := ec.c.CallContext(ctx, &proof, "bor_getCheckpointProof", start, end, number); err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
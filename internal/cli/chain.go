@@ -16,7 +16,11 @@ func (c *ChainCommand) MarkDown() string {
 	items := []string{
 		"# Chain",
 		"The ```chain``` command groups actions to interact with the blockchain in the client:",
+		"- [```chain config```](./chain_config.md): Resolve the bor configuration of a chain at a block.",
+		"- [```chain milestones```](./chain_milestones.md): Show the locked milestone and the future milestone queue.",
+		"- [```chain rewinds```](./chain_rewinds.md): List the rewinds of the chain and the rejected reorgs.",
 		"- [```chain sethead```](./chain_sethead.md): Set the current chain to a certain block.",
+		"- [```chain unlock```](./chain_unlock.md): Unlock the locked milestone.",
 		"- [```chain watch```](./chain_watch.md): Watch the chainHead, reorg and fork events in real-time.",
 	}
 
@@ -31,7 +35,23 @@ func (c *ChainCommand) Help() string {
 	
   Set the new head of the chain:
   
-    $ bor chain sethead <number>`
+    $ bor chain sethead <number>
+
+  Resolve the bor configuration of a chain at a block:
+
+    $ bor chain config --number <number>
+
+  List the rewinds of the chain and the rejected reorgs:
+
+    $ bor chain rewinds
+
+  Show the locked milestone and the future milestone queue:
+
+    $ bor chain milestones
+
+  Unlock a validator stuck on a milestone:
+
+    $ bor chain unlock --reason <reason>`
 }
 
 // Synopsis implements the cli.Command interface
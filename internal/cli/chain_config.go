@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server/chains"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ChainConfigCommand is the command to resolve the bor configuration of a chain
+type ChainConfigCommand struct {
+	*Meta2
+
+	chain  string
+	number uint64
+	diff   string
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *ChainConfigCommand) MarkDown() string {
+	items := []string{
+		"# Chain config",
+		"The ```chain config``` command resolves the effective bor configuration of a chain at a given block and lists the parameter transitions scheduled after it. With ```--diff```, it instead lists the blocks at which the bor schedules of two chains diverge.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *ChainConfigCommand) Help() string {
+	return `Usage: bor chain config [--chain <chain>] [--number <block>] [--diff <chain>]
+
+  This command resolves the effective bor configuration of a chain at a given block.
+
+  Compare the bor schedules of mainnet and a local genesis:
+
+    $ bor chain config --chain mainnet --diff ./genesis.json` + c.Flags().Help()
+}
+
+// Flags: chain, number, diff
+func (c *ChainConfigCommand) Flags() *flagset.Flagset {
+	flags := flagset.NewFlagSet("chain config")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "chain",
+		Usage:   "Name of the chain (mainnet, mumbai) or path to a genesis file",
+		Value:   &c.chain,
+		Default: "mainnet",
+	})
+
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "number",
+		Usage:   "Block number to resolve the configuration at",
+		Value:   &c.number,
+		Default: 0,
+	})
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "diff",
+		Usage:   "Name of the chain or path to a genesis file to compare the bor schedule with",
+		Value:   &c.diff,
+		Default: "",
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ChainConfigCommand) Synopsis() string {
+	return "Resolve the bor configuration of a chain at a block"
+}
+
+// Run implements the cli.Command interface
+func (c *ChainConfigCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	config, err := borChainConfig(c.chain)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.diff != "" {
+		other, err := borChainConfig(c.diff)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+
+		differences := params.DiffBorConfigs(config, other)
+		if len(differences) == 0 {
+			c.UI.Output("The bor schedules are identical")
+			return 0
+		}
+
+		out := []string{fmt.Sprintf("Block|Parameter|%s|%s", c.chain, c.diff)}
+		for _, d := range differences {
+			out = append(out, fmt.Sprintf("%d|%s|%s|%s", d.Block, d.Field, d.A, d.B))
+		}
+
+		c.UI.Output(formatList(out))
+
+		return 0
+	}
+
+	c.UI.Output(formatBorParams(config.ParamsAt(c.number)))
+
+	transitions := config.Transitions(c.number)
+	if len(transitions) == 0 {
+		c.UI.Output("\nNo upcoming transitions")
+		return 0
+	}
+
+	out := []string{"Block|Parameter|Value"}
+	for _, t := range transitions {
+		out = append(out, fmt.Sprintf("%d|%s|%s", t.Block, t.Field, t.Value))
+	}
+
+	c.UI.Output("\nUpcoming transitions:\n" + formatList(out))
+
+	return 0
+}
+
+// borChainConfig returns the bor config of a predefined chain or genesis file
+func borChainConfig(name string) (*params.BorConfig, error) {
+	chain, err := chains.GetChain(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if chain.Genesis == nil || chain.Genesis.Config == nil || chain.Genesis.Config.Bor == nil {
+		return nil, fmt.Errorf("chain %s has no bor config", name)
+	}
+
+	return chain.Genesis.Config.Bor, nil
+}
+
+func formatBorParams(p *params.BorParams) string {
+	out := []string{
+		fmt.Sprintf("Number|%d", p.Number),
+		fmt.Sprintf("Period|%d", p.Period),
+		fmt.Sprintf("Producer delay|%d", p.ProducerDelay),
+		fmt.Sprintf("Sprint|%d", p.Sprint),
+		fmt.Sprintf("Backup multiplier|%d", p.BackupMultiplier),
+		fmt.Sprintf("State sync confirmation delay|%d", p.StateSyncConfirmationDelay),
+		fmt.Sprintf("Burnt contract|%s", p.BurntContract),
+		fmt.Sprintf("Validator contract|%s", p.ValidatorContract),
+		fmt.Sprintf("State receiver contract|%s", p.StateReceiverContract),
+		fmt.Sprintf("Jaipur|%t", p.Jaipur),
+		fmt.Sprintf("Delhi|%t", p.Delhi),
+		fmt.Sprintf("Indore|%t", p.Indore),
+		fmt.Sprintf("Parallel universe|%t", p.ParallelUniverse),
+		fmt.Sprintf("Block alloc|%t", p.BlockAlloc),
+	}
+
+	if p.OverrideStateSyncRecords != nil {
+		out = append(out, fmt.Sprintf("Override state sync records|%d", *p.OverrideStateSyncRecords))
+	}
+
+	return formatKV(out)
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+)
+
+// ChainMilestonesCommand is the command to show the milestone lock state
+type ChainMilestonesCommand struct {
+	*Meta2
+
+	endpoint string
+	audit    int
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *ChainMilestonesCommand) MarkDown() string {
+	items := []string{
+		"# Chain milestones",
+		"The ```chain milestones``` command shows the milestone lock state of a running client, which decides whether reorgs are allowed: the locked milestone, the milestone IDs voted on and the future milestone queue. With ```--audit```, it also lists the most recent manual changes made with ```chain unlock```.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *ChainMilestonesCommand) Help() string {
+	return `Usage: bor chain milestones [--audit <count>]
+
+  This command shows the locked milestone and the future milestone queue` + c.Flags().Help()
+}
+
+// Flags: endpoint, audit
+func (c *ChainMilestonesCommand) Flags() *flagset.Flagset {
+	flags := flagset.NewFlagSet("chain milestones")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "endpoint",
+		Value: &c.endpoint,
+		Usage: "RPC endpoint of the running client (default = ipc endpoint of the default datadir)",
+	})
+
+	flags.IntFlag(&flagset.IntFlag{
+		Name:    "audit",
+		Value:   &c.audit,
+		Usage:   "Number of entries of the audit log of the manual changes to list",
+		Default: 0,
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ChainMilestonesCommand) Synopsis() string {
+	return "Show the locked milestone and the future milestone queue"
+}
+
+// Run implements the cli.Command interface
+func (c *ChainMilestonesCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	client, err := dialRPC(c.endpoint)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer client.Close()
+
+	var state *rawdb.MilestoneLockState
+	if err := client.CallContext(context.Background(), &state, "admin_getMilestoneLock"); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(formatMilestoneLockState(state))
+
+	if c.audit <= 0 {
+		return 0
+	}
+
+	var entries []*rawdb.MilestoneAuditEntry
+	if err := client.CallContext(context.Background(), &entries, "admin_getMilestoneAudit", c.audit); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	for _, entry := range entries {
+		c.UI.Output("")
+		c.UI.Output(formatMilestoneAuditEntry(entry))
+	}
+
+	return 0
+}
+
+func formatMilestoneLockState(state *rawdb.MilestoneLockState) string {
+	base := formatKV([]string{
+		fmt.Sprintf("Locked|%t", state.Locked),
+		fmt.Sprintf("Locked milestone|%d", state.LockedNumber),
+		fmt.Sprintf("Locked hash|%s", state.LockedHash.Hex()),
+		fmt.Sprintf("Milestone IDs|%s", strings.Join(state.LockedIDs, ", ")),
+		fmt.Sprintf("Future milestones|%d", len(state.FutureMilestones)),
+	})
+
+	for _, future := range state.FutureMilestones {
+		base += fmt.Sprintf("\n  %d %s", future.Number, future.Hash.Hex())
+	}
+
+	return base
+}
+
+func formatMilestoneAuditEntry(entry *rawdb.MilestoneAuditEntry) string {
+	base := formatKV([]string{
+		fmt.Sprintf("Time|%s", time.Unix(0, int64(entry.Time)).UTC().Format(time.RFC3339)),
+		fmt.Sprintf("Action|%s", entry.Action),
+		fmt.Sprintf("Reason|%s", entry.Reason),
+	})
+
+	if entry.Before != nil {
+		base += "\nBefore:\n" + formatMilestoneLockState(entry.Before)
+	}
+
+	return base
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+)
+
+// ChainRewindsCommand is the command to list the rewinds of the chain
+type ChainRewindsCommand struct {
+	*Meta2
+
+	endpoint string
+	limit    int
+	verbose  bool
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *ChainRewindsCommand) MarkDown() string {
+	items := []string{
+		"# Chain rewinds",
+		"The ```chain rewinds``` command lists the most recent entries of the rewind journal of a running client: the rewinds caused by a checkpoint root hash or milestone end block hash which doesn't match the local chain, and the reorgs rejected because they conflict with the locked milestone. Every entry shows the expected and local hashes and the rewind target. With ```--verbose```, it also lists the dropped blocks and the transactions returned to the pool.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *ChainRewindsCommand) Help() string {
+	return `Usage: bor chain rewinds [--limit <count>] [--verbose]
+
+  This command lists the rewinds of the chain and the rejected reorgs` + c.Flags().Help()
+}
+
+// Flags: endpoint, limit, verbose
+func (c *ChainRewindsCommand) Flags() *flagset.Flagset {
+	flags := flagset.NewFlagSet("chain rewinds")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "endpoint",
+		Value: &c.endpoint,
+		Usage: "RPC endpoint of the running client (default = ipc endpoint of the default datadir)",
+	})
+
+	flags.IntFlag(&flagset.IntFlag{
+		Name:    "limit",
+		Value:   &c.limit,
+		Usage:   "Maximum number of entries to list (0 = all)",
+		Default: 10,
+	})
+
+	flags.BoolFlag(&flagset.BoolFlag{
+		Name:    "verbose",
+		Value:   &c.verbose,
+		Usage:   "List the dropped blocks and the transactions returned to the pool",
+		Default: false,
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ChainRewindsCommand) Synopsis() string {
+	return "List the rewinds of the chain and the rejected reorgs"
+}
+
+// Run implements the cli.Command interface
+func (c *ChainRewindsCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	client, err := dialRPC(c.endpoint)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer client.Close()
+
+	var entries []*rawdb.RewindEntry
+	if err := client.CallContext(context.Background(), &entries, "bor_getRewindHistory", c.limit); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if len(entries) == 0 {
+		c.UI.Output("No rewinds recorded")
+		return 0
+	}
+
+	for i, entry := range entries {
+		if i > 0 {
+			c.UI.Output("")
+		}
+
+		c.UI.Output(formatRewindEntry(entry, c.verbose))
+	}
+
+	return 0
+}
+
+func formatRewindEntry(entry *rawdb.RewindEntry, verbose bool) string {
+	base := formatKV([]string{
+		fmt.Sprintf("Time|%s", time.Unix(0, int64(entry.Time)).UTC().Format(time.RFC3339)),
+		fmt.Sprintf("Reason|%s", entry.Reason),
+		fmt.Sprintf("Range|%d-%d", entry.StartBlock, entry.EndBlock),
+		fmt.Sprintf("Expected hash|%s", entry.ExpectedHash),
+		fmt.Sprintf("Local hash|%s", entry.LocalHash),
+		fmt.Sprintf("Head|%d", entry.Head),
+		fmt.Sprintf("Rewind to|%d", entry.RewindTo),
+		fmt.Sprintf("Dropped blocks|%d", len(entry.Dropped)),
+		fmt.Sprintf("Returned transactions|%d", len(entry.Transactions)),
+	})
+
+	if entry.Error != "" {
+		base += "\n" + formatKV([]string{fmt.Sprintf("Error|%s", entry.Error)})
+	}
+
+	if !verbose {
+		return base
+	}
+
+	for _, hash := range entry.Dropped {
+		base += "\n  block " + hash.Hex()
+	}
+
+	for _, hash := range entry.Transactions {
+		base += "\n  tx " + hash.Hex()
+	}
+
+	return base
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+)
+
+// ChainUnlockCommand is the command to unlock the locked milestone
+type ChainUnlockCommand struct {
+	*Meta2
+
+	endpoint string
+	reason   string
+	future   bool
+	yes      bool
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *ChainUnlockCommand) MarkDown() string {
+	items := []string{
+		"# Chain unlock",
+		"The ```chain unlock``` command unlocks the locked milestone of a running client and drops the milestone IDs voted on, to recover a validator stuck on a milestone which never got finalized, without deleting the chaindata. With ```--future```, it also purges the future milestone queue. Every change is recorded with its reason in an audit log, listed by ```chain milestones --audit```.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *ChainUnlockCommand) Help() string {
+	return `Usage: bor chain unlock --reason <reason> [--future] [--yes]
+
+  This command unlocks the locked milestone` + c.Flags().Help()
+}
+
+// Flags: endpoint, reason, future, yes
+func (c *ChainUnlockCommand) Flags() *flagset.Flagset {
+	flags := flagset.NewFlagSet("chain unlock")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "endpoint",
+		Value: &c.endpoint,
+		Usage: "RPC endpoint of the running client (default = ipc endpoint of the default datadir)",
+	})
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "reason",
+		Value: &c.reason,
+		Usage: "Reason of the unlock, recorded in the audit log",
+	})
+
+	flags.BoolFlag(&flagset.BoolFlag{
+		Name:    "future",
+		Value:   &c.future,
+		Usage:   "Purge the future milestone queue as well",
+		Default: false,
+	})
+
+	flags.BoolFlag(&flagset.BoolFlag{
+		Name:    "yes",
+		Value:   &c.yes,
+		Usage:   "Force unlock",
+		Default: false,
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ChainUnlockCommand) Synopsis() string {
+	return "Unlock the locked milestone"
+}
+
+// Run implements the cli.Command interface
+func (c *ChainUnlockCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.reason == "" {
+		c.UI.Error("No reason provided")
+		return 1
+	}
+
+	client, err := dialRPC(c.endpoint)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer client.Close()
+
+	if !c.yes {
+		response, err := c.UI.Ask("Are you sure you want to unlock the milestone? Reorgs beyond it will be allowed (y/n)")
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+
+		if response != "y" {
+			c.UI.Output("unlock aborted")
+			return 0
+		}
+	}
+
+	var entry *rawdb.MilestoneAuditEntry
+	if err := client.CallContext(context.Background(), &entry, "admin_unlockMilestone", c.reason); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(formatMilestoneAuditEntry(entry))
+
+	if c.future {
+		if err := client.CallContext(context.Background(), &entry, "admin_purgeFutureMilestones", c.reason); err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+
+		c.UI.Output("")
+		c.UI.Output(formatMilestoneAuditEntry(entry))
+	}
+
+	return 0
+}
@@ -103,21 +103,51 @@ func Commands() map[string]MarkDownCommandFactory {
 				Meta2: meta2,
 			}, nil
 		},
+		"debug profile-parallel": func() (MarkDownCommand, error) {
+			return &DebugProfileParallelCommand{
+				Meta2: meta2,
+			}, nil
+		},
+		"debug reexec-parallel": func() (MarkDownCommand, error) {
+			return &DebugReexecParallelCommand{
+				Meta: meta,
+			}, nil
+		},
 		"chain": func() (MarkDownCommand, error) {
 			return &ChainCommand{
 				UI: ui,
 			}, nil
 		},
+		"chain config": func() (MarkDownCommand, error) {
+			return &ChainConfigCommand{
+				Meta2: meta2,
+			}, nil
+		},
 		"chain watch": func() (MarkDownCommand, error) {
 			return &ChainWatchCommand{
 				Meta2: meta2,
 			}, nil
 		},
+		"chain milestones": func() (MarkDownCommand, error) {
+			return &ChainMilestonesCommand{
+				Meta2: meta2,
+			}, nil
+		},
+		"chain rewinds": func() (MarkDownCommand, error) {
+			return &ChainRewindsCommand{
+				Meta2: meta2,
+			}, nil
+		},
 		"chain sethead": func() (MarkDownCommand, error) {
 			return &ChainSetHeadCommand{
 				Meta2: meta2,
 			}, nil
 		},
+		"chain unlock": func() (MarkDownCommand, error) {
+			return &ChainUnlockCommand{
+				Meta2: meta2,
+			}, nil
+		},
 		"account": func() (MarkDownCommand, error) {
 			return &Account{
 				UI: ui,
@@ -200,6 +230,21 @@ func Commands() map[string]MarkDownCommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"snapshot inspect-bor": func() (MarkDownCommand, error) {
+			return &BorSnapshotInspectCommand{
+				borSnapshotMeta: borSnapshotMeta{Meta: meta},
+			}, nil
+		},
+		"snapshot rebuild-bor": func() (MarkDownCommand, error) {
+			return &BorSnapshotRebuildCommand{
+				borSnapshotMeta: borSnapshotMeta{Meta: meta},
+			}, nil
+		},
+		"snapshot prune-bor": func() (MarkDownCommand, error) {
+			return &BorSnapshotPruneCommand{
+				borSnapshotMeta: borSnapshotMeta{Meta: meta},
+			}, nil
+		},
 	}
 }
 
@@ -50,6 +50,8 @@ func (d *DebugCommand) MarkDown() string {
 		"The ```bor debug``` command takes a debug dump of the running client.",
 		"- [```bor debug pprof```](./debug_pprof.md): Dumps bor pprof traces.",
 		"- [```bor debug block <number>```](./debug_block.md): Dumps bor block traces.",
+		"- [```bor debug profile-parallel <number>```](./debug_profile-parallel.md): Profiles the parallel execution of a block.",
+		"- [```bor debug reexec-parallel```](./debug_reexec-parallel.md): Compares the serial and parallel execution of a range of blocks.",
 	}
 	items = append(items, examples...)
 
@@ -68,7 +70,15 @@ func (c *DebugCommand) Help() string {
 
 	Get the block traces:
 
-		$ bor debug block <number>`
+		$ bor debug block <number>
+
+	Profile the parallel execution of a block:
+
+		$ bor debug profile-parallel <number>
+
+	Compare the serial and parallel execution of a range of blocks:
+
+		$ bor debug reexec-parallel --datadir <datadir> --from <block> --to <block>`
 }
 
 // Synopsis implements the cli.Command interface
@@ -194,6 +204,14 @@ func (d *debugEnv) writeJSON(name string, msg protoreflect.ProtoMessage) error {
 	return nil
 }
 
+func (d *debugEnv) writeFile(name string, data []byte) error {
+	if err := os.WriteFile(filepath.Join(d.dst, name), data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %v", name, err)
+	}
+
+	return nil
+}
+
 func trapSignal(cancel func()) {
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh,
@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// DebugProfileParallelCommand is the command to profile the parallel execution of a block
+type DebugProfileParallelCommand struct {
+	*Meta2
+
+	endpoint string
+	output   string
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *DebugProfileParallelCommand) MarkDown() string {
+	items := []string{
+		"# Debug profile-parallel",
+		"The ```bor debug profile-parallel <number>``` command re-executes a block with Block STM and profiling enabled, and creates an archive containing the execution profile of every transaction (```profile.json```) and the dependency DAG of the block in Graphviz DOT format (```dag.dot```). The critical path is highlighted in the DAG.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *DebugProfileParallelCommand) Help() string {
+	return `Usage: bor debug profile-parallel <number>
+
+  This command profiles the parallel execution of a block` + c.Flags().Help()
+}
+
+// Flags: endpoint, output
+func (c *DebugProfileParallelCommand) Flags() *flagset.Flagset {
+	flags := flagset.NewFlagSet("debug profile-parallel")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "endpoint",
+		Value: &c.endpoint,
+		Usage: "RPC endpoint of the running client (default = ipc endpoint of the default datadir)",
+	})
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "output",
+		Value: &c.output,
+		Usage: "Output directory",
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DebugProfileParallelCommand) Synopsis() string {
+	return "Profile the parallel execution of a block"
+}
+
+// Run implements the cli.Command interface
+func (c *DebugProfileParallelCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("No block number provided")
+		return 1
+	}
+
+	number, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	client, err := dialRPC(c.endpoint)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer client.Close()
+
+	var profile *core.ParallelBlockProfile
+	if err := client.CallContext(context.Background(), &profile, "debug_profileParallelBlock", rpc.BlockNumber(number)); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	dEnv := &debugEnv{
+		output: c.output,
+		prefix: "bor-parallel-profile-",
+	}
+	if err := dEnv.init(); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if err := dEnv.writeFile("profile.json", data); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if err := dEnv.writeFile("dag.dot", []byte(profile.Dot)); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if err := dEnv.finish(); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(formatParallelProfile(profile))
+	c.UI.Output("")
+
+	if c.output != "" {
+		c.UI.Output(fmt.Sprintf("Created debug directory: %s", dEnv.dst))
+	} else {
+		c.UI.Output(fmt.Sprintf("Created parallel profile archive: %s", dEnv.tarName()))
+	}
+
+	return 0
+}
+
+func formatParallelProfile(profile *core.ParallelBlockProfile) string {
+	var incarnations, aborts int
+
+	for _, tx := range profile.Transactions {
+		incarnations += tx.Incarnations
+		aborts += tx.Aborts
+	}
+
+	path := make([]string, 0, len(profile.CriticalPath))
+	for _, i := range profile.CriticalPath {
+		path = append(path, strconv.Itoa(i))
+	}
+
+	out := []string{
+		fmt.Sprintf("Block|%d", profile.Number),
+		fmt.Sprintf("Hash|%s", profile.Hash),
+		fmt.Sprintf("Metadata|%t", profile.Metadata),
+		fmt.Sprintf("Transactions|%d", len(profile.Transactions)),
+		fmt.Sprintf("Incarnations|%d", incarnations),
+		fmt.Sprintf("Aborts|%d", aborts),
+		fmt.Sprintf("Serial time|%v", profile.SerialTime),
+		fmt.Sprintf("Critical path time|%v", profile.CriticalPathTime),
+		fmt.Sprintf("Critical path|%s", strings.Join(path, " -> ")),
+	}
+
+	if profile.CriticalPathTime > 0 {
+		out = append(out, fmt.Sprintf("Parallelizability|%.2fx", float64(profile.SerialTime)/float64(profile.CriticalPathTime)))
+	}
+
+	return formatKV(out)
+}
@@ -0,0 +1,269 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// DebugReexecParallelCommand is the command to compare the serial and parallel
+// execution of a range of blocks
+type DebugReexecParallelCommand struct {
+	*Meta
+
+	datadirAncient string
+	from           uint64
+	to             uint64
+	procs          int
+	output         string
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *DebugReexecParallelCommand) MarkDown() string {
+	items := []string{
+		"# Debug reexec-parallel",
+		"The ```bor debug reexec-parallel``` command opens the chain database read-only and processes every block of a range with both the serial and the parallel (Block STM) state processor, on top of the state of its parent. It compares their receipts, logs, gas used and state roots, and writes a reproduction of every diverging block to the output directory: the block (```block.rlp```), the parent state of every key it accesses (```prestate.json```) and the differences, including the diverging Block STM keys (```divergence.json```).",
+		"The parent state of every block must be available, so ranges beyond the recent blocks require an archive node. Span and state-sync commits at sprint starts need Heimdall and are skipped by both processors, so the state roots may differ from the canonical ones at these blocks.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *DebugReexecParallelCommand) Help() string {
+	return `Usage: bor debug reexec-parallel --datadir <datadir> --from <block> --to <block>
+
+  This command compares the serial and parallel execution of a range of blocks` + c.Flags().Help()
+}
+
+// Flags: datadir, keystore, datadir.ancient, from, to, procs, output
+func (c *DebugReexecParallelCommand) Flags() *flagset.Flagset {
+	flags := c.NewFlagSet("debug reexec-parallel")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "datadir.ancient",
+		Value:   &c.datadirAncient,
+		Usage:   "Path of the ancient data directory to store information",
+		Default: "",
+	})
+
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "from",
+		Value:   &c.from,
+		Usage:   "First block to re-execute",
+		Default: 1,
+	})
+
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "to",
+		Value:   &c.to,
+		Usage:   "Last block to re-execute (0 = head block)",
+		Default: 0,
+	})
+
+	flags.IntFlag(&flagset.IntFlag{
+		Name:    "procs",
+		Value:   &c.procs,
+		Usage:   "Number of speculative processes of the parallel state processor (0 = number of CPUs)",
+		Default: 0,
+	})
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "output",
+		Value:   &c.output,
+		Usage:   "Directory to write the reproductions of diverging blocks to",
+		Default: "reexec-parallel",
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DebugReexecParallelCommand) Synopsis() string {
+	return "Compare the serial and parallel execution of blocks"
+}
+
+// Run implements the cli.Command interface
+func (c *DebugReexecParallelCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.from == 0 {
+		c.UI.Error("genesis is not executable")
+		return 1
+	}
+
+	chaindb, config, err := openChainDatabase(c.dataDir, c.datadirAncient, true)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer chaindb.Close()
+
+	procs := c.procs
+	if procs <= 0 {
+		procs = runtime.NumCPU()
+	}
+
+	cacheConfig := *core.DefaultCacheConfig
+	cacheConfig.SnapshotLimit = 0
+	cacheConfig.TrieDirtyDisabled = true
+
+	engine := &reexecEngine{Bor: bor.New(config, chaindb, nil, nil, nil, nil, false)}
+
+	chain, err := core.NewParallelBlockChain(chaindb, &cacheConfig, nil, nil, engine, vm.Config{}, nil, nil, nil, procs)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer chain.Stop()
+
+	to := c.to
+	if head := chain.CurrentBlock().Number.Uint64(); to == 0 || to > head {
+		to = head
+	}
+
+	if c.from > to {
+		c.UI.Error(fmt.Sprintf("invalid range %d-%d", c.from, to))
+		return 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	trapSignal(cancel)
+
+	processor := core.NewParallelStateProcessor(chain.Config(), chain, engine)
+
+	var processed, diverged int
+
+	for number := c.from; number <= to; number++ {
+		if ctx.Err() != nil {
+			c.UI.Warn(fmt.Sprintf("Interrupted at block %d", number))
+			break
+		}
+
+		comparison, err := c.compare(ctx, chain, processor, number)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Block %d: %v", number, err))
+			return 1
+		}
+
+		processed++
+
+		if !comparison.Diverged() {
+			continue
+		}
+
+		diverged++
+
+		dir, err := c.writeReproduction(chain.GetBlockByNumber(number), comparison)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+
+		c.UI.Output(fmt.Sprintf("Block %d diverged (%d differences, %d keys): %s", number, len(comparison.Differences), len(comparison.Keys), dir))
+	}
+
+	c.UI.Output(formatKV([]string{
+		fmt.Sprintf("Range|%d-%d", c.from, to),
+		fmt.Sprintf("Processed|%d", processed),
+		fmt.Sprintf("Diverged|%d", diverged),
+	}))
+
+	if diverged > 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// compare processes a block with both state processors on top of its parent state
+func (c *DebugReexecParallelCommand) compare(ctx context.Context, chain *core.BlockChain, processor *core.ParallelStateProcessor, number uint64) (*core.ParallelComparison, error) {
+	block := chain.GetBlockByNumber(number)
+	if block == nil {
+		return nil, errors.New("block not found")
+	}
+
+	parent := chain.GetHeader(block.ParentHash(), number-1)
+	if parent == nil {
+		return nil, errors.New("parent not found")
+	}
+
+	statedb, err := chain.StateAt(parent.Root)
+	if err != nil {
+		return nil, fmt.Errorf("parent state unavailable: %w", err)
+	}
+
+	return processor.Compare(block, statedb, vm.Config{}, ctx)
+}
+
+// writeReproduction writes the block, its pre-state and the differences to the
+// output directory of the block
+func (c *DebugReexecParallelCommand) writeReproduction(block *types.Block, comparison *core.ParallelComparison) (string, error) {
+	dir := filepath.Join(c.output, fmt.Sprint(block.NumberU64()))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	blockRLP, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		return "", err
+	}
+
+	preState, err := json.MarshalIndent(comparison.PreState, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	divergence, err := json.MarshalIndent(comparison, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	files := map[string][]byte{
+		"block.rlp":       blockRLP,
+		"prestate.json":   preState,
+		"divergence.json": divergence,
+	}
+
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0600); err != nil {
+			return "", fmt.Errorf("failed to write %s: %v", name, err)
+		}
+	}
+
+	return dir, nil
+}
+
+// reexecEngine is the consensus engine used to re-execute blocks offline. It
+// recovers block authors like bor, but skips the span and state-sync commits
+// at sprint starts, which need Heimdall.
+type reexecEngine struct {
+	*bor.Bor
+}
+
+// Finalize implements consensus.Engine, only computing the state root
+func (e *reexecEngine) Finalize(chain consensus.ChainHeaderReader, header *types.Header, statedb *state.StateDB, _ []*types.Transaction, _ []*types.Header, _ []*types.Withdrawal) {
+	header.Root = statedb.IntermediateRoot(chain.Config().IsEIP158(header.Number))
+	header.UncleHash = types.CalcUncleHash(nil)
+}
@@ -24,6 +24,10 @@ import (
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/fdlimit"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdallsim"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
@@ -90,6 +94,13 @@ type Config struct {
 	// BorLogs enables bor log retrieval
 	BorLogs bool `hcl:"bor.logs,optional" toml:"bor.logs,optional"`
 
+	// BorSnapshotInterval is the number of blocks between two bor consensus snapshots stored on disk
+	BorSnapshotInterval uint64 `hcl:"bor.snapshot.interval,optional" toml:"bor.snapshot.interval,optional"`
+
+	// BorSnapshotRetention is the number of blocks below the last finalized milestone for which
+	// bor consensus snapshots are kept (0 disables pruning)
+	BorSnapshotRetention uint64 `hcl:"bor.snapshot.retention,optional" toml:"bor.snapshot.retention,optional"`
+
 	// Ethstats is the address of the ethstats server to send telemetry
 	Ethstats string `hcl:"ethstats,optional" toml:"ethstats,optional"`
 
@@ -134,6 +145,9 @@ type Config struct {
 	// ParallelEVM has the parallel evm related settings
 	ParallelEVM *ParallelEVMConfig `hcl:"parallelevm,block" toml:"parallelevm,block"`
 
+	// Finality has the reorg and rewind limits tied to the bor finality
+	Finality *FinalityConfig `hcl:"finality,block" toml:"finality,block"`
+
 	// Develop Fake Author mode to produce blocks without authorisation
 	DevFakeAuthor bool `hcl:"devfakeauthor,optional" toml:"devfakeauthor,optional"`
 
@@ -248,12 +262,18 @@ type HeimdallConfig struct {
 	// URL is the url of the heimdall server
 	URL string `hcl:"url,optional" toml:"url,optional"`
 
+	// FailoverURLs are the urls of additional heimdall servers to fail over to
+	FailoverURLs []string `hcl:"failover-urls,optional" toml:"failover-urls,optional"`
+
 	// Without is used to disable remote heimdall during testing
 	Without bool `hcl:"bor.without,optional" toml:"bor.without,optional"`
 
 	// GRPCAddress is the address of the heimdall grpc server
 	GRPCAddress string `hcl:"grpc-address,optional" toml:"grpc-address,optional"`
 
+	// FailoverGRPCAddresses are the addresses of additional heimdall grpc servers to fail over to
+	FailoverGRPCAddresses []string `hcl:"failover-grpc-addresses,optional" toml:"failover-grpc-addresses,optional"`
+
 	// RunHeimdall is used to run heimdall as a child process
 	RunHeimdall bool `hcl:"bor.runheimdall,optional" toml:"bor.runheimdall,optional"`
 
@@ -262,6 +282,28 @@ type HeimdallConfig struct {
 
 	// UseHeimdallApp is used to fetch data from heimdall app when running heimdall as a child process
 	UseHeimdallApp bool `hcl:"bor.useheimdallapp,optional" toml:"bor.useheimdallapp,optional"`
+
+	// Quorum is the number of heimdall endpoints which have to agree on spans, milestones,
+	// checkpoints and state-sync events (0 disables the quorum mode)
+	Quorum uint64 `hcl:"quorum,optional" toml:"quorum,optional"`
+
+	// Cache is used to persist heimdall spans, state-sync events and checkpoints in the local database
+	Cache bool `hcl:"bor.heimdallcache,optional" toml:"bor.heimdallcache,optional"`
+
+	// Replay is used to serve heimdall data from the local database only, without a heimdall endpoint
+	Replay bool `hcl:"bor.heimdallreplay,optional" toml:"bor.heimdallreplay,optional"`
+
+	// Simulator is the listen address of an embedded heimdall simulator, which is used instead of the heimdall url
+	Simulator string `hcl:"bor.heimdallsim,optional" toml:"bor.heimdallsim,optional"`
+
+	// SimulatorValidators are the validators (address[:power]) of the spans served by the heimdall simulator
+	SimulatorValidators []string `hcl:"bor.heimdallsim.validators,optional" toml:"bor.heimdallsim.validators,optional"`
+
+	// SimulatorProducers is the number of block producers selected per span by the heimdall simulator
+	SimulatorProducers uint64 `hcl:"bor.heimdallsim.producers,optional" toml:"bor.heimdallsim.producers,optional"`
+
+	// SimulatorSpanLength is the number of blocks in the spans served by the heimdall simulator
+	SimulatorSpanLength uint64 `hcl:"bor.heimdallsim.spanlength,optional" toml:"bor.heimdallsim.spanlength,optional"`
 }
 
 type TxPoolConfig struct {
@@ -323,6 +365,12 @@ type SealerConfig struct {
 	RecommitRaw string        `hcl:"recommit,optional" toml:"recommit,optional"`
 
 	CommitInterruptFlag bool `hcl:"commitinterrupt,optional" toml:"commitinterrupt,optional"`
+
+	// ParallelBlockProduction enables executing batches of transactions with Block-STM when building blocks
+	ParallelBlockProduction bool `hcl:"parallel,optional" toml:"parallel,optional"`
+
+	// ParallelBatchSize is the maximum number of transactions executed in a single Block-STM batch
+	ParallelBatchSize int `hcl:"parallelbatch,optional" toml:"parallelbatch,optional"`
 }
 
 type JsonRPCConfig struct {
@@ -591,6 +639,35 @@ type ParallelEVMConfig struct {
 	Enable bool `hcl:"enable,optional" toml:"enable,optional"`
 
 	SpeculativeProcesses int `hcl:"procs,optional" toml:"procs,optional"`
+
+	// Scheduler is the scheduling policy of Block STM (static or adaptive)
+	Scheduler string `hcl:"scheduler,optional" toml:"scheduler,optional"`
+
+	// MinProcesses is the minimum number of speculative processes of the adaptive scheduler
+	MinProcesses int `hcl:"minprocs,optional" toml:"minprocs,optional"`
+
+	// MaxProcesses is the maximum number of speculative processes of the adaptive scheduler
+	MaxProcesses int `hcl:"maxprocs,optional" toml:"maxprocs,optional"`
+
+	// SerialThreshold is the re-executions per transaction above which the adaptive scheduler executes serially
+	SerialThreshold float64 `hcl:"serialthreshold,optional" toml:"serialthreshold,optional"`
+
+	// VerifyTxDependency compares the transaction dependencies declared in block headers with the observed ones
+	VerifyTxDependency bool `hcl:"verifydeps,optional" toml:"verifydeps,optional"`
+
+	// EnforceTxDependency rejects blocks whose declared transaction dependencies are unsound
+	EnforceTxDependency bool `hcl:"enforcedeps,optional" toml:"enforcedeps,optional"`
+}
+
+type FinalityConfig struct {
+	// MaxRewind is the maximum number of blocks dropped by an automatic rewind on a checkpoint or milestone mismatch
+	MaxRewind uint64 `hcl:"maxrewind,optional" toml:"maxrewind,optional"`
+
+	// MaxReorgDepth is the maximum number of blocks dropped by a reorg
+	MaxReorgDepth uint64 `hcl:"maxreorgdepth,optional" toml:"maxreorgdepth,optional"`
+
+	// DeepReorg is the number of dropped blocks from which a reorg is reported as deep
+	DeepReorg uint64 `hcl:"deepreorg,optional" toml:"deepreorg,optional"`
 }
 
 func DefaultConfig() *Config {
@@ -634,14 +711,21 @@ func DefaultConfig() *Config {
 			},
 		},
 		Heimdall: &HeimdallConfig{
-			URL:         "http://localhost:1317",
-			Without:     false,
-			GRPCAddress: "",
+			URL:                   "http://localhost:1317",
+			FailoverURLs:          []string{},
+			Without:               false,
+			GRPCAddress:           "",
+			FailoverGRPCAddresses: []string{},
+			SimulatorValidators:   []string{},
+			SimulatorSpanLength:   heimdallsim.DefaultConfig.SpanLength,
 		},
 		SyncMode: "full",
 		GcMode:   "full",
 		Snapshot: true,
 		BorLogs:  false,
+
+		BorSnapshotInterval:  bor.DefaultSnapshotConfig.Interval,
+		BorSnapshotRetention: bor.DefaultSnapshotConfig.Retention,
 		TxPool: &TxPoolConfig{
 			Locals:       []string{},
 			NoLocals:     false,
@@ -663,6 +747,7 @@ func DefaultConfig() *Config {
 			ExtraData:           "",
 			Recommit:            125 * time.Second,
 			CommitInterruptFlag: true,
+			ParallelBatchSize:   64,
 		},
 		Gpo: &GpoConfig{
 			Blocks:           20,
@@ -786,6 +871,17 @@ func DefaultConfig() *Config {
 		ParallelEVM: &ParallelEVMConfig{
 			Enable:               true,
 			SpeculativeProcesses: 8,
+			Scheduler:            "static",
+			MinProcesses:         1,
+			MaxProcesses:         0,
+			SerialThreshold:      0,
+			VerifyTxDependency:   false,
+			EnforceTxDependency:  false,
+		},
+		Finality: &FinalityConfig{
+			MaxRewind:     core.DefaultFinalityPolicyConfig.MaxRewind,
+			MaxReorgDepth: core.DefaultFinalityPolicyConfig.MaxReorgDepth,
+			DeepReorg:     core.DefaultFinalityPolicyConfig.DeepReorg,
 		},
 	}
 }
@@ -902,6 +998,10 @@ func (c *Config) loadChain() error {
 	return nil
 }
 
+// snapSyncSupported is whether bor supports snap sync, which the finality sync
+// pivots to the latest finalized block.
+const snapSyncSupported = false // TODO(snap): Enable when we have snap sync working
+
 //nolint:gocognit
 func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*ethconfig.Config, error) {
 	dbHandles, err := MakeDatabaseHandles(c.Cache.FDLimit)
@@ -919,11 +1019,16 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 	}
 
 	n.HeimdallURL = c.Heimdall.URL
+	n.HeimdallFailoverURLs = c.Heimdall.FailoverURLs
 	n.WithoutHeimdall = c.Heimdall.Without
 	n.HeimdallgRPCAddress = c.Heimdall.GRPCAddress
+	n.HeimdallgRPCFailoverAddresses = c.Heimdall.FailoverGRPCAddresses
 	n.RunHeimdall = c.Heimdall.RunHeimdall
 	n.RunHeimdallArgs = c.Heimdall.RunHeimdallArgs
 	n.UseHeimdallApp = c.Heimdall.UseHeimdallApp
+	n.HeimdallQuorum = int(c.Heimdall.Quorum)
+	n.HeimdallCache = c.Heimdall.Cache
+	n.HeimdallReplay = c.Heimdall.Replay
 
 	// Developer Fake Author for producing blocks without authorisation on bor consensus
 	n.DevFakeAuthor = c.DevFakeAuthor
@@ -964,6 +1069,9 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		n.Miner.GasCeil = c.Sealer.GasCeil
 		n.Miner.ExtraData = []byte(c.Sealer.ExtraData)
 		n.Miner.CommitInterruptFlag = c.Sealer.CommitInterruptFlag
+		n.Miner.ParallelBlockProduction = c.Sealer.ParallelBlockProduction
+		n.Miner.ParallelBatchSize = c.Sealer.ParallelBatchSize
+		n.Miner.ParallelProcs = c.ParallelEVM.SpeculativeProcesses
 
 		if etherbase := c.Sealer.Etherbase; etherbase != "" {
 			if !common.IsHexAddress(etherbase) {
@@ -1135,7 +1243,7 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 
 	n.RPCTxFeeCap = c.JsonRPC.TxFeeCap
 
-	// sync mode. It can either be "fast", "full" or "snap". We disable
+	// sync mode. It can either be "fast", "full", "snap" or "finality". We disable
 	// for now the "light" mode.
 	switch c.SyncMode {
 	case "full":
@@ -1145,6 +1253,17 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		n.SyncMode = downloader.FullSync
 
 		log.Warn("Bor doesn't support Snap Sync yet, switching to Full Sync mode")
+	case "finality":
+		// Headers anchored to the latest milestone or checkpoint of heimdall,
+		// and the state snap synced at it once snap sync is supported
+		n.SyncMode = downloader.FullSync
+		if snapSyncSupported {
+			n.SyncMode = downloader.SnapSync
+		}
+
+		n.FinalitySync = true
+
+		log.Warn("Finality sync is experimental, the synced chain must include the latest finalized block")
 	default:
 		return nil, fmt.Errorf("sync mode '%s' not found", c.SyncMode)
 	}
@@ -1175,11 +1294,25 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		}
 	}
 
+	n.FinalityPolicy = core.FinalityPolicyConfig{
+		MaxRewind:     c.Finality.MaxRewind,
+		MaxReorgDepth: c.Finality.MaxReorgDepth,
+		DeepReorg:     c.Finality.DeepReorg,
+	}
+
 	n.BorLogs = c.BorLogs
+	n.BorSnapshotInterval = c.BorSnapshotInterval
+	n.BorSnapshotRetention = c.BorSnapshotRetention
 	n.DatabaseHandles = dbHandles
 
 	n.ParallelEVM.Enable = c.ParallelEVM.Enable
 	n.ParallelEVM.SpeculativeProcesses = c.ParallelEVM.SpeculativeProcesses
+	n.ParallelEVM.Scheduler = c.ParallelEVM.Scheduler
+	n.ParallelEVM.MinProcesses = c.ParallelEVM.MinProcesses
+	n.ParallelEVM.MaxProcesses = c.ParallelEVM.MaxProcesses
+	n.ParallelEVM.SerialThreshold = c.ParallelEVM.SerialThreshold
+	n.ParallelEVM.VerifyTxDependency = c.ParallelEVM.VerifyTxDependency
+	n.ParallelEVM.EnforceTxDependency = c.ParallelEVM.EnforceTxDependency
 	n.RPCReturnDataLimit = c.RPCReturnDataLimit
 
 	if c.Ancient != "" {
@@ -1189,6 +1322,45 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 	return &n, nil
 }
 
+// buildHeimdallSimulator creates the embedded heimdall simulator. Validators are
+// given as address[:power], defaulting to the miner etherbase.
+func (c *Config) buildHeimdallSimulator() (*heimdallsim.Simulator, error) {
+	addresses := c.Heimdall.SimulatorValidators
+	if len(addresses) == 0 && c.Sealer.Etherbase != "" {
+		addresses = []string{c.Sealer.Etherbase}
+	}
+
+	validators := make([]*valset.Validator, 0, len(addresses))
+
+	for _, entry := range addresses {
+		address, power, found := strings.Cut(entry, ":")
+
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid heimdall simulator validator address %q", address)
+		}
+
+		votingPower := int64(10000)
+
+		if found {
+			var err error
+
+			votingPower, err = strconv.ParseInt(power, 10, 64)
+			if err != nil || votingPower <= 0 {
+				return nil, fmt.Errorf("invalid heimdall simulator validator power %q", power)
+			}
+		}
+
+		validators = append(validators, valset.NewValidator(common.HexToAddress(address), votingPower))
+	}
+
+	return heimdallsim.New(heimdallsim.Config{
+		ChainID:    c.chain.Genesis.Config.ChainID.String(),
+		Validators: validators,
+		Producers:  int(c.Heimdall.SimulatorProducers),
+		SpanLength: c.Heimdall.SimulatorSpanLength,
+	})
+}
+
 var (
 	clientIdentifier = "bor"
 	gitCommit        = "" // Git SHA1 commit hash of the release (set via linker flags)
@@ -88,7 +88,7 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 	})
 	f.StringFlag(&flagset.StringFlag{
 		Name:    "syncmode",
-		Usage:   `Blockchain sync mode (only "full" sync supported)`,
+		Usage:   `Blockchain sync mode ("full", or the experimental "finality" sync anchored to the latest heimdall milestone or checkpoint)`,
 		Value:   &c.cliConfig.SyncMode,
 		Default: c.cliConfig.SyncMode,
 	})
@@ -116,6 +116,18 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.BorLogs,
 		Default: c.cliConfig.BorLogs,
 	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "bor.snapshot.interval",
+		Usage:   "Number of blocks between two bor consensus snapshots stored on disk (e.g. the sprint or span length)",
+		Value:   &c.cliConfig.BorSnapshotInterval,
+		Default: c.cliConfig.BorSnapshotInterval,
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "bor.snapshot.retention",
+		Usage:   "Number of blocks below the last finalized milestone for which bor consensus snapshots are kept (0 = keep all)",
+		Value:   &c.cliConfig.BorSnapshotRetention,
+		Default: c.cliConfig.BorSnapshotRetention,
+	})
 
 	// logging related flags (log-level and verbosity is present above, it will be removed soon)
 	f.StringFlag(&flagset.StringFlag{
@@ -154,6 +166,12 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.Heimdall.URL,
 		Default: c.cliConfig.Heimdall.URL,
 	})
+	f.SliceStringFlag(&flagset.SliceStringFlag{
+		Name:    "bor.heimdallfailover",
+		Usage:   "Comma separated list of additional Heimdall URLs to fail over to",
+		Value:   &c.cliConfig.Heimdall.FailoverURLs,
+		Default: c.cliConfig.Heimdall.FailoverURLs,
+	})
 	f.BoolFlag(&flagset.BoolFlag{
 		Name:    "bor.withoutheimdall",
 		Usage:   "Run without Heimdall service (for testing purpose)",
@@ -172,6 +190,12 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.Heimdall.GRPCAddress,
 		Default: c.cliConfig.Heimdall.GRPCAddress,
 	})
+	f.SliceStringFlag(&flagset.SliceStringFlag{
+		Name:    "bor.heimdallgRPCfailover",
+		Usage:   "Comma separated list of additional Heimdall gRPC addresses to fail over to",
+		Value:   &c.cliConfig.Heimdall.FailoverGRPCAddresses,
+		Default: c.cliConfig.Heimdall.FailoverGRPCAddresses,
+	})
 	f.BoolFlag(&flagset.BoolFlag{
 		Name:    "bor.runheimdall",
 		Usage:   "Run Heimdall service as a child process",
@@ -190,6 +214,48 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.Heimdall.UseHeimdallApp,
 		Default: c.cliConfig.Heimdall.UseHeimdallApp,
 	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "bor.heimdallquorum",
+		Usage:   "Number of Heimdall endpoints (bor.heimdall and bor.heimdallfailover, or their gRPC counterparts) which have to return identical spans, milestones, checkpoints and state-sync events (0 = disabled)",
+		Value:   &c.cliConfig.Heimdall.Quorum,
+		Default: c.cliConfig.Heimdall.Quorum,
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "bor.heimdallcache",
+		Usage:   "Persist Heimdall spans, state-sync events and checkpoints in the local database and serve them from there",
+		Value:   &c.cliConfig.Heimdall.Cache,
+		Default: c.cliConfig.Heimdall.Cache,
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "bor.heimdallreplay",
+		Usage:   "Serve Heimdall data from the local cache only, without a Heimdall endpoint (for re-executing historical blocks)",
+		Value:   &c.cliConfig.Heimdall.Replay,
+		Default: c.cliConfig.Heimdall.Replay,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "bor.heimdallsim",
+		Usage:   "Listen address of an embedded Heimdall simulator to use instead of bor.heimdall (for devnets, other nodes can use it as their bor.heimdall)",
+		Value:   &c.cliConfig.Heimdall.Simulator,
+		Default: c.cliConfig.Heimdall.Simulator,
+	})
+	f.SliceStringFlag(&flagset.SliceStringFlag{
+		Name:    "bor.heimdallsim.validators",
+		Usage:   "Comma separated list of validators (address[:power]) of the Heimdall simulator spans, defaults to the miner etherbase",
+		Value:   &c.cliConfig.Heimdall.SimulatorValidators,
+		Default: c.cliConfig.Heimdall.SimulatorValidators,
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "bor.heimdallsim.producers",
+		Usage:   "Number of block producers the Heimdall simulator selects per span (0 = all validators)",
+		Value:   &c.cliConfig.Heimdall.SimulatorProducers,
+		Default: c.cliConfig.Heimdall.SimulatorProducers,
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "bor.heimdallsim.spanlength",
+		Usage:   "Number of blocks in the spans served by the Heimdall simulator",
+		Value:   &c.cliConfig.Heimdall.SimulatorSpanLength,
+		Default: c.cliConfig.Heimdall.SimulatorSpanLength,
+	})
 
 	// txpool options
 	f.SliceStringFlag(&flagset.SliceStringFlag{
@@ -320,6 +386,20 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Default: c.cliConfig.Sealer.CommitInterruptFlag,
 		Group:   "Sealer",
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "miner.parallel",
+		Usage:   "Speculatively execute batches of transactions with Block STM when building blocks",
+		Value:   &c.cliConfig.Sealer.ParallelBlockProduction,
+		Default: c.cliConfig.Sealer.ParallelBlockProduction,
+		Group:   "Sealer",
+	})
+	f.IntFlag(&flagset.IntFlag{
+		Name:    "miner.parallelbatch",
+		Usage:   "Maximum number of transactions executed in a single Block STM batch when building blocks",
+		Value:   &c.cliConfig.Sealer.ParallelBatchSize,
+		Default: c.cliConfig.Sealer.ParallelBatchSize,
+		Group:   "Sealer",
+	})
 
 	// ethstats
 	f.StringFlag(&flagset.StringFlag{
@@ -966,6 +1046,42 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.ParallelEVM.SpeculativeProcesses,
 		Default: c.cliConfig.ParallelEVM.SpeculativeProcesses,
 	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "parallelevm.scheduler",
+		Usage:   "Scheduling policy of Block STM (static = always use parallelevm.procs, adaptive = adjust the processes to the recent conflict rate)",
+		Value:   &c.cliConfig.ParallelEVM.Scheduler,
+		Default: c.cliConfig.ParallelEVM.Scheduler,
+	})
+	f.IntFlag(&flagset.IntFlag{
+		Name:    "parallelevm.minprocs",
+		Usage:   "Minimum number of speculative processes of the adaptive scheduler",
+		Value:   &c.cliConfig.ParallelEVM.MinProcesses,
+		Default: c.cliConfig.ParallelEVM.MinProcesses,
+	})
+	f.IntFlag(&flagset.IntFlag{
+		Name:    "parallelevm.maxprocs",
+		Usage:   "Maximum number of speculative processes the adaptive scheduler may grow to (0 = number of CPUs)",
+		Value:   &c.cliConfig.ParallelEVM.MaxProcesses,
+		Default: c.cliConfig.ParallelEVM.MaxProcesses,
+	})
+	f.Float64Flag(&flagset.Float64Flag{
+		Name:    "parallelevm.serialthreshold",
+		Usage:   "Re-executions per transaction above which the adaptive scheduler executes blocks serially (0 = disabled)",
+		Value:   &c.cliConfig.ParallelEVM.SerialThreshold,
+		Default: c.cliConfig.ParallelEVM.SerialThreshold,
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "parallelevm.verifydeps",
+		Usage:   "Compare the transaction dependencies declared in block headers with the ones observed by Block STM",
+		Value:   &c.cliConfig.ParallelEVM.VerifyTxDependency,
+		Default: c.cliConfig.ParallelEVM.VerifyTxDependency,
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "parallelevm.enforcedeps",
+		Usage:   "Reject blocks past the parallel universe fork whose declared transaction dependencies miss observed ones (implies parallelevm.verifydeps)",
+		Value:   &c.cliConfig.ParallelEVM.EnforceTxDependency,
+		Default: c.cliConfig.ParallelEVM.EnforceTxDependency,
+	})
 	f.Uint64Flag(&flagset.Uint64Flag{
 		Name:    "dev.gaslimit",
 		Usage:   "Initial block gas limit",
@@ -973,6 +1089,26 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Default: c.cliConfig.Developer.GasLimit,
 	})
 
+	// finality
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "finality.maxrewind",
+		Usage:   "Maximum number of blocks dropped by an automatic rewind on a checkpoint or milestone mismatch (0 = unlimited)",
+		Value:   &c.cliConfig.Finality.MaxRewind,
+		Default: c.cliConfig.Finality.MaxRewind,
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "finality.maxreorgdepth",
+		Usage:   "Maximum number of blocks dropped by a reorg, reorgs below the whitelisted milestone are always rejected (0 = unlimited)",
+		Value:   &c.cliConfig.Finality.MaxReorgDepth,
+		Default: c.cliConfig.Finality.MaxReorgDepth,
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "finality.deepreorg",
+		Usage:   "Number of dropped blocks from which a reorg is reported as deep (0 = never)",
+		Value:   &c.cliConfig.Finality.DeepReorg,
+		Default: c.cliConfig.Finality.DeepReorg,
+	})
+
 	// pprof
 	f.BoolFlag(&flagset.BoolFlag{
 		Name:    "pprof",
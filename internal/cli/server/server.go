@@ -27,6 +27,7 @@ import (
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/consensus/beacon" //nolint:typecheck
 	"github.com/ethereum/go-ethereum/consensus/bor"    //nolint:typecheck
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdallsim"
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
@@ -56,6 +57,9 @@ type Server struct {
 
 	// tracerAPI to trace block executions
 	tracerAPI *tracers.API
+
+	// heimdallSim is the embedded heimdall simulator, if enabled
+	heimdallSim *heimdallsim.Simulator
 }
 
 type serverOption func(srv *Server, config *Config) error
@@ -124,7 +128,10 @@ func NewServer(config *Config, opts ...serverOption) (*Server, error) {
 	// start the logger
 	setupLogger(VerbosityIntToString(config.Verbosity), *config.Logging)
 
-	var err error
+	var (
+		err     error
+		started bool
+	)
 
 	for _, opt := range opts {
 		err = opt(srv, config)
@@ -138,6 +145,26 @@ func NewServer(config *Config, opts ...serverOption) (*Server, error) {
 		return nil, err
 	}
 
+	// start the embedded heimdall simulator, which replaces the remote heimdall
+	if config.Heimdall.Simulator != "" {
+		if srv.heimdallSim, err = config.buildHeimdallSimulator(); err != nil {
+			return nil, err
+		}
+
+		// stop the simulator again if the server fails to come up
+		defer func() {
+			if !started {
+				srv.heimdallSim.Close()
+			}
+		}()
+
+		if err = srv.heimdallSim.Start(config.Heimdall.Simulator); err != nil {
+			return nil, err
+		}
+
+		config.Heimdall.URL = srv.heimdallSim.URL()
+	}
+
 	// create the node/stack
 	nodeCfg, err := config.buildNode()
 	if err != nil {
@@ -251,6 +278,11 @@ func NewServer(config *Config, opts ...serverOption) (*Server, error) {
 	// set the auth status in backend
 	srv.backend.SetAuthorized(authorized)
 
+	// checkpoints and milestones of the heimdall simulator are built from the local chain
+	if srv.heimdallSim != nil {
+		srv.heimdallSim.SetChain(srv.backend.BlockChain())
+	}
+
 	filterSystem := utils.RegisterFilterAPI(stack, srv.backend.APIBackend, ethCfg)
 
 	// debug tracing is enabled by default
@@ -290,6 +322,8 @@ func NewServer(config *Config, opts ...serverOption) (*Server, error) {
 		return nil, err
 	}
 
+	started = true
+
 	return srv, nil
 }
 
@@ -302,6 +336,10 @@ func (s *Server) Stop() {
 		s.grpcServer.Stop()
 	}
 
+	if s.heimdallSim != nil {
+		s.heimdallSim.Close()
+	}
+
 	// shutdown the tracer
 	if s.tracer != nil {
 		if err := s.tracer.Shutdown(context.Background()); err != nil {
@@ -27,6 +27,9 @@ func (a *SnapshotCommand) MarkDown() string {
 		"# snapshot",
 		"The ```snapshot``` command groups snapshot related actions:",
 		"- [```snapshot prune-state```](./snapshot_prune-state.md): Prune state databases at the given datadir location.",
+		"- [```snapshot inspect-bor```](./snapshot_inspect-bor.md): Inspect the bor consensus snapshots.",
+		"- [```snapshot rebuild-bor```](./snapshot_rebuild-bor.md): Rebuild the bor consensus snapshots of the canonical chain.",
+		"- [```snapshot prune-bor```](./snapshot_prune-bor.md): Prune old bor consensus snapshots.",
 	}
 
 	return strings.Join(items, "\n\n")
@@ -40,7 +43,13 @@ func (c *SnapshotCommand) Help() string {
 
   Prune the state trie:
 
-    $ bor snapshot prune-state`
+    $ bor snapshot prune-state
+
+  Inspect, rebuild and prune the bor consensus snapshots:
+
+    $ bor snapshot inspect-bor
+    $ bor snapshot rebuild-bor
+    $ bor snapshot prune-bor`
 }
 
 // Synopsis implements the cli.Command interface
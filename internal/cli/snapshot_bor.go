@@ -0,0 +1,399 @@
+// Bor consensus snapshot related commands
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// borSnapshotMeta holds the flags shared by the bor snapshot commands
+type borSnapshotMeta struct {
+	*Meta
+
+	datadirAncient string
+}
+
+func (m *borSnapshotMeta) newFlagSet(name string) *flagset.Flagset {
+	flags := m.NewFlagSet(name)
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "datadir.ancient",
+		Value:   &m.datadirAncient,
+		Usage:   "Path of the ancient data directory to store information",
+		Default: "",
+	})
+
+	return flags
+}
+
+// openDatabase opens the chain database and reads the bor config of the chain
+func (m *borSnapshotMeta) openDatabase(readonly bool) (ethdb.Database, *params.BorConfig, error) {
+	chaindb, config, err := openChainDatabase(m.dataDir, m.datadirAncient, readonly)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return chaindb, config.Bor, nil
+}
+
+// openChainDatabase opens the chain database of a data directory and reads the
+// config of the bor chain it holds
+func openChainDatabase(dataDir string, datadirAncient string, readonly bool) (ethdb.Database, *params.ChainConfig, error) {
+	if dataDir == "" {
+		return nil, nil, errors.New("datadir is required")
+	}
+
+	stack, err := node.New(&node.Config{
+		DataDir: dataDir,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dbHandles, err := server.MakeDatabaseHandles(0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chaindb, err := stack.OpenDatabaseWithFreezer(chaindataPath, 0, dbHandles, datadirAncient, "", readonly, rawdb.ExtraDBConfig{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config := rawdb.ReadChainConfig(chaindb, rawdb.ReadCanonicalHash(chaindb, 0))
+	if config == nil || config.Bor == nil {
+		chaindb.Close()
+		return nil, nil, errors.New("database doesn't hold a bor chain")
+	}
+
+	return chaindb, config, nil
+}
+
+// BorSnapshotInspectCommand is the command to inspect the bor consensus snapshots
+type BorSnapshotInspectCommand struct {
+	borSnapshotMeta
+
+	number uint64
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *BorSnapshotInspectCommand) MarkDown() string {
+	items := []string{
+		"# Inspect bor snapshots",
+		"The ```bor snapshot inspect-bor``` command summarizes the bor consensus snapshots stored in the database, or shows the snapshots of a given block.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *BorSnapshotInspectCommand) Help() string {
+	return `Usage: bor snapshot inspect-bor --datadir <datadir> [--number <block>]
+
+  This command summarizes the bor consensus snapshots stored in the database` + c.Flags().Help()
+}
+
+// Synopsis implements the cli.Command interface
+func (c *BorSnapshotInspectCommand) Synopsis() string {
+	return "Inspect bor consensus snapshots"
+}
+
+// Flags: datadir, datadir.ancient, number
+func (c *BorSnapshotInspectCommand) Flags() *flagset.Flagset {
+	flags := c.newFlagSet("inspect-bor")
+
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "number",
+		Value:   &c.number,
+		Usage:   "Block number of the snapshots to show (0 = summary only)",
+		Default: 0,
+	})
+
+	return flags
+}
+
+// Run implements the cli.Command interface
+func (c *BorSnapshotInspectCommand) Run(args []string) int {
+	flags := c.Flags()
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	db, config, err := c.openDatabase(true)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	defer db.Close()
+
+	var (
+		binary, legacy, size int
+		lowest, highest      *bor.SnapshotInfo
+		details              []string
+	)
+
+	err = bor.IterateSnapshots(db, config, func(info bor.SnapshotInfo, snap *bor.Snapshot) bool {
+		if info.Legacy {
+			legacy++
+		} else {
+			binary++
+		}
+
+		size += info.Size
+
+		if lowest == nil || info.Number < lowest.Number {
+			lowest = &info
+		}
+
+		if highest == nil || info.Number > highest.Number {
+			highest = &info
+		}
+
+		if c.number != 0 && info.Number == c.number {
+			details = append(details, formatBorSnapshot(db, info, snap))
+		}
+
+		return true
+	})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	summary := []string{
+		fmt.Sprintf("Snapshots|%d", binary+legacy),
+		fmt.Sprintf("Legacy snapshots|%d", legacy),
+		fmt.Sprintf("Total size|%d", size),
+	}
+
+	if lowest != nil {
+		summary = append(summary,
+			fmt.Sprintf("Lowest block|%d", lowest.Number),
+			fmt.Sprintf("Highest block|%d", highest.Number),
+		)
+	}
+
+	c.UI.Output(formatKV(summary))
+
+	if c.number != 0 && len(details) == 0 {
+		c.UI.Output(fmt.Sprintf("\nNo snapshot stored for block %d", c.number))
+	}
+
+	for _, detail := range details {
+		c.UI.Output("\n" + detail)
+	}
+
+	return 0
+}
+
+func formatBorSnapshot(db ethdb.Reader, info bor.SnapshotInfo, snap *bor.Snapshot) string {
+	out := []string{
+		fmt.Sprintf("Number|%d", info.Number),
+		fmt.Sprintf("Hash|%s", info.Hash),
+		fmt.Sprintf("Canonical|%t", rawdb.ReadCanonicalHash(db, info.Number) == info.Hash),
+		fmt.Sprintf("Legacy|%t", info.Legacy),
+		fmt.Sprintf("Size|%d", info.Size),
+		fmt.Sprintf("Recents|%d", len(snap.Recents)),
+	}
+
+	if proposer := snap.ValidatorSet.Proposer; proposer != nil {
+		out = append(out, fmt.Sprintf("Proposer|%s", proposer.Address))
+	}
+
+	validators := []string{"Validator|Power|Priority"}
+	for _, validator := range snap.ValidatorSet.Validators {
+		validators = append(validators, fmt.Sprintf("%s|%d|%d", validator.Address, validator.VotingPower, validator.ProposerPriority))
+	}
+
+	return formatKV(out) + "\n\n" + formatList(validators)
+}
+
+// BorSnapshotRebuildCommand is the command to rebuild the bor consensus snapshots
+type BorSnapshotRebuildCommand struct {
+	borSnapshotMeta
+
+	from     uint64
+	interval uint64
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *BorSnapshotRebuildCommand) MarkDown() string {
+	items := []string{
+		"# Rebuild bor snapshots",
+		"The ```bor snapshot rebuild-bor``` command regenerates the bor consensus snapshots of the canonical chain in the current format, starting from the newest snapshot stored at or below the given block. The snapshots stored above it are replaced.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *BorSnapshotRebuildCommand) Help() string {
+	return `Usage: bor snapshot rebuild-bor --datadir <datadir> [--from <block>] [--interval <blocks>]
+
+  This command regenerates the bor consensus snapshots of the canonical chain` + c.Flags().Help()
+}
+
+// Synopsis implements the cli.Command interface
+func (c *BorSnapshotRebuildCommand) Synopsis() string {
+	return "Rebuild bor consensus snapshots"
+}
+
+// Flags: datadir, datadir.ancient, from, interval
+func (c *BorSnapshotRebuildCommand) Flags() *flagset.Flagset {
+	flags := c.newFlagSet("rebuild-bor")
+
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "from",
+		Value:   &c.from,
+		Usage:   "Block at or below which the snapshot to rebuild from is stored (0 = genesis)",
+		Default: 0,
+	})
+
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "interval",
+		Value:   &c.interval,
+		Usage:   "Number of blocks between two stored snapshots",
+		Default: bor.DefaultSnapshotConfig.Interval,
+	})
+
+	return flags
+}
+
+// Run implements the cli.Command interface
+func (c *BorSnapshotRebuildCommand) Run(args []string) int {
+	flags := c.Flags()
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	db, config, err := c.openDatabase(false)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	defer db.Close()
+
+	stored, err := bor.RebuildSnapshots(db, config, c.from, c.interval)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to rebuild snapshots after storing %d: %v", stored, err))
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Stored %d snapshots", stored))
+
+	return 0
+}
+
+// BorSnapshotPruneCommand is the command to prune the bor consensus snapshots
+type BorSnapshotPruneCommand struct {
+	borSnapshotMeta
+
+	below     uint64
+	retention uint64
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *BorSnapshotPruneCommand) MarkDown() string {
+	items := []string{
+		"# Prune bor snapshots",
+		"The ```bor snapshot prune-bor``` command deletes the bor consensus snapshots stored for blocks below the given block, or below the retention window of the last finalized milestone. The genesis snapshot and the newest snapshot below the block are kept.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *BorSnapshotPruneCommand) Help() string {
+	return `Usage: bor snapshot prune-bor --datadir <datadir> [--below <block> | --retention <blocks>]
+
+  This command deletes old bor consensus snapshots` + c.Flags().Help()
+}
+
+// Synopsis implements the cli.Command interface
+func (c *BorSnapshotPruneCommand) Synopsis() string {
+	return "Prune bor consensus snapshots"
+}
+
+// Flags: datadir, datadir.ancient, below, retention
+func (c *BorSnapshotPruneCommand) Flags() *flagset.Flagset {
+	flags := c.newFlagSet("prune-bor")
+
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "below",
+		Value:   &c.below,
+		Usage:   "Block below which snapshots are deleted (0 = use the retention)",
+		Default: 0,
+	})
+
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "retention",
+		Value:   &c.retention,
+		Usage:   "Number of blocks below the last finalized milestone for which snapshots are kept",
+		Default: 0,
+	})
+
+	return flags
+}
+
+// Run implements the cli.Command interface
+func (c *BorSnapshotPruneCommand) Run(args []string) int {
+	flags := c.Flags()
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	db, _, err := c.openDatabase(false)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	defer db.Close()
+
+	below := c.below
+	if below == 0 {
+		finalized, _, err := rawdb.ReadFinality[*rawdb.Milestone](db)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("No finalized milestone, use --below: %v", err))
+			return 1
+		}
+
+		if finalized <= c.retention {
+			c.UI.Output("Nothing to prune")
+			return 0
+		}
+
+		below = finalized - c.retention
+	}
+
+	deleted, err := bor.PruneSnapshots(db, below)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Deleted %d snapshots below block %d", deleted, below))
+
+	return 0
+}
@@ -24,6 +24,7 @@ import (
 	"math/big"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
@@ -1344,6 +1345,173 @@ func (s *BlockChainAPI) CallWithState(ctx context.Context, args TransactionArgs,
 	return result.Return(), result.Err
 }
 
+// maxCallBatchSize is the maximum number of calls of an eth_callBatch request.
+const maxCallBatchSize = 100
+
+// CallBatchResult is the outcome of a call of a batch.
+type CallBatchResult struct {
+	ReturnData hexutil.Bytes `json:"returnData"`      // Return data, or revert data if the call reverted
+	Error      string        `json:"error,omitempty"` // Error of the call, if it failed
+}
+
+// CallBatch executes a batch of independent calls on the state of the given
+// block, concurrently. Every call is executed on its own copy of the state, with
+// the overrides applied, so calls don't see each other's changes. Unlike a
+// JSON-RPC batch of eth_call requests, which is served one request at a time,
+// the calls run in parallel. A failing call doesn't fail the batch, its error is
+// returned along with its result.
+//
+// The whole batch shares a single RPC gas cap and EVM timeout, so it costs no
+// more than a single eth_call.
+func (s *BlockChainAPI) CallBatch(ctx context.Context, calls []TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *StateOverride, blockOverrides *BlockOverrides) ([]CallBatchResult, error) {
+	if len(calls) == 0 {
+		return nil, errors.New("empty call batch")
+	}
+
+	if len(calls) > maxCallBatchSize {
+		return nil, fmt.Errorf("call batch of %d calls exceeds limit %d", len(calls), maxCallBatchSize)
+	}
+
+	state, header, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+
+	if err := overrides.Apply(state); err != nil {
+		return nil, err
+	}
+
+	timeout := s.b.RPCEVMTimeout()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	gasCap := s.b.RPCGasCap()
+	if gasCap == 0 {
+		gasCap = math.MaxInt64 / 2
+	}
+
+	var (
+		results = make([]CallBatchResult, len(calls))
+		indexes = make(chan int, len(calls))
+		budget  = &callBatchBudget{gas: gasCap, pending: len(calls)}
+		pend    sync.WaitGroup
+
+		// The base state is copied by one worker at a time
+		copyLock sync.Mutex
+	)
+
+	for i := range calls {
+		indexes <- i
+	}
+
+	close(indexes)
+
+	threads := runtime.NumCPU()
+	if threads > len(calls) {
+		threads = len(calls)
+	}
+
+	for th := 0; th < threads; th++ {
+		pend.Add(1)
+
+		go func() {
+			defer pend.Done()
+
+			for i := range indexes {
+				copyLock.Lock()
+				statedb := state.Copy()
+				copyLock.Unlock()
+
+				results[i] = s.callInBatch(ctx, calls[i], header, statedb, blockOverrides, timeout, budget)
+			}
+		}()
+	}
+
+	pend.Wait()
+
+	return results, nil
+}
+
+// callBatchBudget is the gas shared by the calls of a batch.
+type callBatchBudget struct {
+	lock    sync.Mutex
+	gas     uint64 // Gas not reserved by any call
+	pending int    // Number of calls which haven't reserved gas yet
+}
+
+// reserve reserves the gas of a call: its gas limit if given, a fair share of
+// the remaining gas otherwise.
+func (b *callBatchBudget) reserve(args TransactionArgs) (uint64, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	gas := b.gas / uint64(b.pending)
+	b.pending--
+
+	if args.Gas != nil {
+		if uint64(*args.Gas) > b.gas {
+			return 0, fmt.Errorf("call gas %d exceeds remaining batch gas %d", uint64(*args.Gas), b.gas)
+		}
+
+		gas = uint64(*args.Gas)
+	}
+
+	if gas == 0 {
+		return 0, errors.New("call batch gas exhausted")
+	}
+
+	b.gas -= gas
+
+	return gas, nil
+}
+
+// refund returns the gas a call reserved but didn't use.
+func (b *callBatchBudget) refund(gas uint64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.gas += gas
+}
+
+// callInBatch executes a call of a batch on the given state, with the gas it
+// reserves from the budget of the batch.
+func (s *BlockChainAPI) callInBatch(ctx context.Context, args TransactionArgs, header *types.Header, state *state.StateDB, blockOverrides *BlockOverrides, timeout time.Duration, budget *callBatchBudget) CallBatchResult {
+	gas, err := budget.reserve(args)
+	if err != nil {
+		return CallBatchResult{Error: err.Error()}
+	}
+
+	result, err := doCallWithState(ctx, s.b, args, header, state, timeout, gas, blockOverrides)
+
+	if result != nil && result.UsedGas < gas {
+		budget.refund(gas - result.UsedGas)
+	} else if result == nil {
+		budget.refund(gas)
+	}
+
+	if err != nil {
+		return CallBatchResult{Error: err.Error()}
+	}
+
+	if int(s.b.RPCRpcReturnDataLimit()) > 0 && len(result.ReturnData) > int(s.b.RPCRpcReturnDataLimit()) {
+		return CallBatchResult{Error: fmt.Sprintf("call returned result of length %d exceeding limit %d", len(result.ReturnData), int(s.b.RPCRpcReturnDataLimit()))}
+	}
+
+	if len(result.Revert()) > 0 {
+		return CallBatchResult{ReturnData: result.Revert(), Error: newRevertError(result).Error()}
+	}
+
+	if result.Err != nil {
+		return CallBatchResult{ReturnData: result.Return(), Error: result.Err.Error()}
+	}
+
+	return CallBatchResult{ReturnData: result.Return()}
+}
+
 func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *StateOverride, gasCap uint64) (hexutil.Uint64, error) {
 	// Binary search the gas requirement, as it may be higher than the amount used
 	var (
@@ -2190,6 +2358,12 @@ func (s *TransactionAPI) sign(addr common.Address, tx *types.Transaction) (*type
 
 // SubmitTransaction is a helper function that submits tx to txPool and logs a message.
 func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (common.Hash, error) {
+	return submitTransaction(ctx, b, tx, b.SendTx)
+}
+
+// submitTransaction is the helper behind SubmitTransaction, submitting tx to
+// txPool with the given function.
+func submitTransaction(ctx context.Context, b Backend, tx *types.Transaction, send func(context.Context, *types.Transaction) error) (common.Hash, error) {
 	// If the transaction fee cap is already specified, ensure the
 	// fee of the given transaction is _reasonable_.
 	if err := checkTxFee(tx.GasPrice(), tx.Gas(), b.RPCTxFeeCap()); err != nil {
@@ -2201,7 +2375,7 @@ func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (c
 		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
 	}
 
-	if err := b.SendTx(ctx, tx); err != nil {
+	if err := send(ctx, tx); err != nil {
 		return common.Hash{}, err
 	}
 	// Print a log with full tx details for manual investigations and interventions
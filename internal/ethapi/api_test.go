@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -539,6 +540,12 @@ func (b testBackend) SubscribeChainSideEvent(ch chan<- core.ChainSideEvent) even
 func (b testBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
 	panic("implement me")
 }
+func (b testBackend) SendPrivateTx(ctx context.Context, signedTx *types.Transaction, expiry uint64, fallback bool) error {
+	panic("implement me")
+}
+func (b testBackend) SendBundle(ctx context.Context, bundle *types.Bundle) error {
+	panic("implement me")
+}
 func (b testBackend) GetTransaction(ctx context.Context, txHash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, error) {
 	tx, blockHash, blockNumber, index := rawdb.ReadTransaction(b.db, txHash)
 	return tx, blockHash, blockNumber, index, nil
@@ -623,6 +630,10 @@ func (b testBackend) SubscribeStateSyncEvent(ch chan<- core.StateSyncEvent) even
 	panic("implement me")
 }
 
+func (b testBackend) SubscribeFinalityEvent(ch chan<- core.FinalityEvent) event.Subscription {
+	panic("implement me")
+}
+
 func (b testBackend) GetBorBlockLogs(ctx context.Context, hash common.Hash) ([]*types.Log, error) {
 	receipt, err := b.GetBorBlockReceipt(ctx, hash)
 	if err != nil || receipt == nil {
@@ -918,6 +929,111 @@ func TestCall(t *testing.T) {
 	}
 }
 
+func TestCallBatch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(2)
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		number = rpc.LatestBlockNumber
+		code   = &hexutil.Bytes{
+			0x43,             // NUMBER
+			0x60, 0x00, 0x52, // MSTORE offset 0
+			0x60, 0x20, 0x60, 0x00, 0xf3,
+		}
+	)
+
+	api := NewBlockChainAPI(newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {}))
+
+	calls := []TransactionArgs{
+		// Both transfers succeed, calls don't see each other's changes
+		{From: &accounts[0].addr, To: &accounts[1].addr, Value: (*hexutil.Big)(big.NewInt(params.Ether * 3 / 4))},
+		{From: &accounts[0].addr, To: &accounts[1].addr, Value: (*hexutil.Big)(big.NewInt(params.Ether * 3 / 4))},
+		// Insufficient funds, fails without failing the batch
+		{From: &accounts[1].addr, To: &accounts[0].addr, Value: (*hexutil.Big)(big.NewInt(1000))},
+		{From: &accounts[0].addr, Input: code},
+	}
+
+	results, err := api.CallBatch(context.Background(), calls, rpc.BlockNumberOrHash{BlockNumber: &number}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to execute batch: %v", err)
+	}
+
+	if len(results) != len(calls) {
+		t.Fatalf("result count mismatch: have %d, want %d", len(results), len(calls))
+	}
+
+	for i := 0; i < 2; i++ {
+		if results[i].Error != "" {
+			t.Errorf("call %d: want no error, have %v", i, results[i].Error)
+		}
+	}
+
+	if !strings.Contains(results[2].Error, core.ErrInsufficientFunds.Error()) {
+		t.Errorf("call 2: error mismatch, want %v, have %q", core.ErrInsufficientFunds, results[2].Error)
+	}
+
+	if have, want := results[3].ReturnData.String(), "0x0000000000000000000000000000000000000000000000000000000000000001"; have != want {
+		t.Errorf("call 3: result mismatch, have %v, want %v", have, want)
+	}
+}
+
+func TestCallBatchLimits(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(1)
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		number = rpc.LatestBlockNumber
+		block  = rpc.BlockNumberOrHash{BlockNumber: &number}
+		loop   = &hexutil.Bytes{
+			0x5b,       // JUMPDEST
+			0x60, 0x00, // PUSH1 0
+			0x56, // JUMP
+		}
+	)
+
+	api := NewBlockChainAPI(newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {}))
+
+	if _, err := api.CallBatch(context.Background(), make([]TransactionArgs, maxCallBatchSize+1), block, nil, nil); err == nil {
+		t.Fatal("oversized batch accepted")
+	}
+
+	// Both calls burn all their gas, only one fits in the gas cap of the batch
+	gas := hexutil.Uint64(6000000)
+	calls := []TransactionArgs{
+		{From: &accounts[0].addr, Input: loop, Gas: &gas},
+		{From: &accounts[0].addr, Input: loop, Gas: &gas},
+	}
+
+	results, err := api.CallBatch(context.Background(), calls, block, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to execute batch: %v", err)
+	}
+
+	var exceeded int
+
+	for _, result := range results {
+		if strings.Contains(result.Error, "exceeds remaining batch gas") {
+			exceeded++
+		}
+	}
+
+	if exceeded != 1 {
+		t.Errorf("expected a single call over the batch gas, have %d: %+v", exceeded, results)
+	}
+}
+
 type Account struct {
 	key  *ecdsa.PrivateKey
 	addr common.Address
@@ -2,9 +2,13 @@ package ethapi
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 )
@@ -103,9 +107,128 @@ func (api *BorAPI) SendRawTransactionConditional(ctx context.Context, input hexu
 	// put options data in Tx, to use it later while block building
 	tx.PutOptions(&options)
 
-	return SubmitTransaction(ctx, api.b, tx)
+	// journal the options, which aren't part of the pool journal, to keep them
+	// across restarts and track the status of the transaction. The entry is
+	// written first, so the status tracker knows it once it enters the pool.
+	db := api.b.ChainDb()
+	known := rawdb.ReadConditionalTx(db, tx.Hash()) != nil
+
+	rawdb.WriteConditionalTx(db, &rawdb.ConditionalTxEntry{
+		Hash:    tx.Hash(),
+		Tx:      input,
+		Options: &options,
+		Time:    uint64(time.Now().Unix()),
+		Status:  rawdb.ConditionalTxPending,
+	})
+
+	hash, err := SubmitTransaction(ctx, api.b, tx)
+	if err != nil && !known {
+		rawdb.DeleteConditionalTx(db, tx.Hash())
+	}
+
+	return hash, err
 }
 
 func (api *BorAPI) GetVoteOnHash(ctx context.Context, starBlockNr uint64, endBlockNr uint64, hash string, milestoneId string) (bool, error) {
 	return api.b.GetVoteOnHash(ctx, starBlockNr, endBlockNr, hash, milestoneId)
 }
+
+// GetRewindHistory returns the most recent entries of the rewind journal: the
+// rewinds caused by mismatching checkpoints and milestones, and the reorgs
+// rejected by the locked milestone. All entries are returned if limit is nil.
+func (api *BorAPI) GetRewindHistory(limit *int) []*rawdb.RewindEntry {
+	var max int
+	if limit != nil {
+		max = *limit
+	}
+
+	entries := rawdb.ReadRewindHistory(api.b.ChainDb(), max)
+	if entries == nil {
+		entries = make([]*rawdb.RewindEntry, 0)
+	}
+
+	return entries
+}
+
+// maxStateSyncEvents is the maximum number of state-sync events returned by a
+// single range query.
+const maxStateSyncEvents = 1000
+
+var errTooManyStateSyncEvents = fmt.Errorf("query returned more than %d state-sync events, narrow the block range", maxStateSyncEvents)
+
+// GetStateSyncEvents returns the state-sync events committed in the canonical
+// blocks of the given range.
+func (api *BorAPI) GetStateSyncEvents(ctx context.Context, fromBlock rpc.BlockNumber, toBlock rpc.BlockNumber) ([]*types.StateSyncRecord, error) {
+	return api.stateSyncEvents(ctx, fromBlock, toBlock, nil)
+}
+
+// GetStateSyncEventsByContract returns the state-sync events sent to the given
+// receiver contract in the canonical blocks of the given range.
+func (api *BorAPI) GetStateSyncEventsByContract(ctx context.Context, contract common.Address, fromBlock rpc.BlockNumber, toBlock rpc.BlockNumber) ([]*types.StateSyncRecord, error) {
+	return api.stateSyncEvents(ctx, fromBlock, toBlock, &contract)
+}
+
+// GetStateSyncEventByID returns the state-sync event with the given state id,
+// or nil if it wasn't committed in the canonical chain.
+func (api *BorAPI) GetStateSyncEventByID(ctx context.Context, id uint64) (*types.StateSyncRecord, error) {
+	return rawdb.ReadBorStateSync(api.b.ChainDb(), id), nil
+}
+
+func (api *BorAPI) stateSyncEvents(ctx context.Context, fromBlock rpc.BlockNumber, toBlock rpc.BlockNumber, contract *common.Address) ([]*types.StateSyncRecord, error) {
+	from, err := api.resolveBlockNumber(ctx, fromBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := api.resolveBlockNumber(ctx, toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	if from > to {
+		return nil, errors.New("invalid block range")
+	}
+
+	result := make([]*types.StateSyncRecord, 0)
+
+	rawdb.IterateBorStateSyncs(api.b.ChainDb(), from, to, func(records []*types.StateSyncRecord) bool {
+		for _, record := range records {
+			if contract != nil && record.Contract != *contract {
+				continue
+			}
+
+			result = append(result, record)
+		}
+
+		return len(result) <= maxStateSyncEvents && ctx.Err() == nil
+	})
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(result) > maxStateSyncEvents {
+		return nil, errTooManyStateSyncEvents
+	}
+
+	return result, nil
+}
+
+// resolveBlockNumber converts block tags like latest to the number of the
+// matching header.
+func (api *BorAPI) resolveBlockNumber(ctx context.Context, number rpc.BlockNumber) (uint64, error) {
+	if number >= 0 {
+		return uint64(number.Int64()), nil
+	}
+
+	header, err := api.b.HeaderByNumber(ctx, number)
+	if err != nil {
+		return 0, err
+	}
+
+	if header == nil {
+		return 0, fmt.Errorf("block %s not found", number)
+	}
+
+	return header.Number.Uint64(), nil
+}
@@ -0,0 +1,107 @@
+package ethapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// defaultBundleBlocks is the number of blocks a bundle targets, if no max
+	// block number is given
+	defaultBundleBlocks = 25
+
+	// maxBundleBlocks is the maximum number of blocks a bundle can target
+	maxBundleBlocks = 100
+)
+
+// SendBundleArgs are the arguments of bor_sendBundle.
+type SendBundleArgs struct {
+	Txs               []hexutil.Bytes `json:"txs"`
+	MinBlockNumber    *hexutil.Uint64 `json:"minBlockNumber"`
+	MaxBlockNumber    *hexutil.Uint64 `json:"maxBlockNumber"`
+	MinTimestamp      *uint64         `json:"minTimestamp"`
+	MaxTimestamp      *uint64         `json:"maxTimestamp"`
+	RevertingTxHashes []common.Hash   `json:"revertingTxHashes"` // Transactions allowed to revert without failing the bundle
+}
+
+// SendBundle submits an ordered list of signed transactions to the local miner,
+// which includes them all-or-nothing at the top of a block within the given
+// block and timestamp range. It returns the hash of the bundle.
+func (api *BorAPI) SendBundle(ctx context.Context, args SendBundleArgs) (common.Hash, error) {
+	if len(args.Txs) == 0 {
+		return common.Hash{}, errors.New("empty bundle")
+	}
+
+	bundle := &types.Bundle{
+		Txs:               make(types.Transactions, 0, len(args.Txs)),
+		TimestampMin:      args.MinTimestamp,
+		TimestampMax:      args.MaxTimestamp,
+		RevertingTxHashes: args.RevertingTxHashes,
+	}
+
+	hashes := make(map[common.Hash]struct{}, len(args.Txs))
+
+	for i, input := range args.Txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(input); err != nil {
+			return common.Hash{}, fmt.Errorf("transaction %d: %w", i, err)
+		}
+
+		if err := checkTxFee(tx.GasPrice(), tx.Gas(), api.b.RPCTxFeeCap()); err != nil {
+			return common.Hash{}, fmt.Errorf("transaction %d: %w", i, err)
+		}
+
+		if !api.b.UnprotectedAllowed() && !tx.Protected() {
+			return common.Hash{}, fmt.Errorf("transaction %d: only replay-protected (EIP-155) transactions allowed over RPC", i)
+		}
+
+		bundle.Txs = append(bundle.Txs, tx)
+		hashes[tx.Hash()] = struct{}{}
+	}
+
+	for _, hash := range args.RevertingTxHashes {
+		if _, ok := hashes[hash]; !ok {
+			return common.Hash{}, fmt.Errorf("reverting transaction %x not in the bundle", hash)
+		}
+	}
+
+	head := api.b.CurrentHeader().Number.Uint64()
+
+	maxBlock := head + defaultBundleBlocks
+	if args.MaxBlockNumber != nil {
+		maxBlock = uint64(*args.MaxBlockNumber)
+	}
+
+	if maxBlock <= head {
+		return common.Hash{}, fmt.Errorf("max block number %d not after the current block %d", maxBlock, head)
+	}
+
+	if maxBlock > head+maxBundleBlocks {
+		return common.Hash{}, fmt.Errorf("max block number %d more than %d blocks after the current block %d", maxBlock, maxBundleBlocks, head)
+	}
+
+	bundle.BlockNumberMax = new(big.Int).SetUint64(maxBlock)
+
+	if args.MinBlockNumber != nil {
+		if uint64(*args.MinBlockNumber) > maxBlock {
+			return common.Hash{}, fmt.Errorf("min block number %d after the max block number %d", *args.MinBlockNumber, maxBlock)
+		}
+
+		bundle.BlockNumberMin = new(big.Int).SetUint64(uint64(*args.MinBlockNumber))
+	}
+
+	if err := api.b.SendBundle(ctx, bundle); err != nil {
+		return common.Hash{}, err
+	}
+
+	log.Info("Submitted bundle", "hash", bundle.Hash(), "txs", len(bundle.Txs), "maxBlock", maxBlock)
+
+	return bundle.Hash(), nil
+}
@@ -0,0 +1,49 @@
+package ethapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var errUnknownConditionalTx = errors.New("unknown conditional transaction")
+
+// ConditionalTransactionStatus is the status of a conditional transaction
+// submitted with bor_sendRawTransactionConditional.
+type ConditionalTransactionStatus struct {
+	Hash         common.Hash              `json:"hash"`
+	Status       string                   `json:"status"` // pending, included, expired, invalidated or dropped
+	BlockNumber  *uint64                  `json:"blockNumber,omitempty"`
+	BlockHash    *common.Hash             `json:"blockHash,omitempty"`
+	Reason       string                   `json:"reason,omitempty"`
+	KnownAccount *types.KnownAccountError `json:"knownAccount,omitempty"` // Mismatching known account and slot of an invalidated transaction
+}
+
+// GetConditionalTransactionStatus returns the status of a conditional
+// transaction: pending in the pool, included in a block, expired because its
+// block number or timestamp window passed, invalidated because a known account
+// doesn't match the state anymore, or dropped from the pool. The status is kept
+// up to date by the node on every new block.
+func (api *BorAPI) GetConditionalTransactionStatus(ctx context.Context, hash common.Hash) (*ConditionalTransactionStatus, error) {
+	entry := rawdb.ReadConditionalTx(api.b.ChainDb(), hash)
+	if entry == nil {
+		return nil, errUnknownConditionalTx
+	}
+
+	status := &ConditionalTransactionStatus{
+		Hash:         entry.Hash,
+		Status:       entry.Status,
+		Reason:       entry.Reason,
+		KnownAccount: entry.KnownAccount,
+	}
+
+	if entry.Status == rawdb.ConditionalTxIncluded {
+		status.BlockNumber = &entry.BlockNumber
+		status.BlockHash = &entry.BlockHash
+	}
+
+	return status, nil
+}
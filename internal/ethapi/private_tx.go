@@ -0,0 +1,47 @@
+package ethapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// defaultPrivateTxBlocks is the number of blocks a private transaction is kept
+// private for, if no max block number is given
+const defaultPrivateTxBlocks = 25
+
+// PrivateTransactionArgs are the arguments of eth_sendPrivateTransaction.
+type PrivateTransactionArgs struct {
+	Tx             hexutil.Bytes   `json:"tx"`
+	MaxBlockNumber *hexutil.Uint64 `json:"maxBlockNumber"` // Last block the transaction is kept private for
+	Fallback       bool            `json:"fallback"`       // Whether the transaction is broadcast, rather than dropped, after the max block
+}
+
+// SendPrivateTransaction adds the signed transaction to the transaction pool
+// without announcing it to the network, so that only the local miner includes
+// it. If it isn't included by the max block number, it's broadcast to the
+// network if fallback is set, otherwise dropped.
+func (s *TransactionAPI) SendPrivateTransaction(ctx context.Context, args PrivateTransactionArgs) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(args.Tx); err != nil {
+		return common.Hash{}, err
+	}
+
+	head := s.b.CurrentHeader().Number.Uint64()
+
+	expiry := head + defaultPrivateTxBlocks
+	if args.MaxBlockNumber != nil {
+		expiry = uint64(*args.MaxBlockNumber)
+	}
+
+	if expiry <= head {
+		return common.Hash{}, fmt.Errorf("max block number %d not after the current block %d", expiry, head)
+	}
+
+	return submitTransaction(ctx, s.b, tx, func(ctx context.Context, tx *types.Transaction) error {
+		return s.b.SendPrivateTx(ctx, tx, expiry, args.Fallback)
+	})
+}
@@ -335,6 +335,10 @@ func (b *backendMock) SubscribeChainSideEvent(ch chan<- core.ChainSideEvent) eve
 	return nil
 }
 func (b *backendMock) SendTx(ctx context.Context, signedTx *types.Transaction) error { return nil }
+func (b *backendMock) SendPrivateTx(ctx context.Context, signedTx *types.Transaction, expiry uint64, fallback bool) error {
+	return nil
+}
+func (b *backendMock) SendBundle(ctx context.Context, bundle *types.Bundle) error { return nil }
 func (b *backendMock) GetTransaction(ctx context.Context, txHash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, error) {
 	return nil, [32]byte{}, 0, 0, nil
 }
@@ -371,6 +375,10 @@ func (b *backendMock) SubscribeStateSyncEvent(ch chan<- core.StateSyncEvent) eve
 	return nil
 }
 
+func (b *backendMock) SubscribeFinalityEvent(ch chan<- core.FinalityEvent) event.Subscription {
+	return nil
+}
+
 func (b *backendMock) GetRootHash(ctx context.Context, starBlockNr uint64, endBlockNr uint64) (string, error) {
 	return "", nil
 }
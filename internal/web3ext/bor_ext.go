@@ -65,12 +65,55 @@ web3._extend({
 			call: 'bor_getVoteOnHash',
 			params: 4,
 		}),
+		new web3._extend.Method({
+			name: 'getStateSyncEvents',
+			call: 'bor_getStateSyncEvents',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getStateSyncEventsByContract',
+			call: 'bor_getStateSyncEventsByContract',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getStateSyncEventByID',
+			call: 'bor_getStateSyncEventByID',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'simulateStateSync',
+			call: 'bor_simulateStateSync',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'getCheckpointProof',
+			call: 'bor_getCheckpointProof',
+			params: 3,
+		}),
 		new web3._extend.Method({
 			name: 'sendRawTransactionConditional',
 			call: 'bor_sendRawTransactionConditional',
 			params: 2,
 			inputFormatter: [null]
 		}),
+		new web3._extend.Method({
+			name: 'getRewindHistory',
+			call: 'bor_getRewindHistory',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'getConditionalTransactionStatus',
+			call: 'bor_getConditionalTransactionStatus',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'sendBundle',
+			call: 'bor_sendBundle',
+			params: 1,
+		}),
 	]
 });
 `
@@ -229,6 +229,26 @@ web3._extend({
 			name: 'setHttpExecutionPoolSize',
 			call: 'admin_setHttpExecutionPoolSize',
 		}),
+		new web3._extend.Method({
+			name: 'getMilestoneLock',
+			call: 'admin_getMilestoneLock'
+		}),
+		new web3._extend.Method({
+			name: 'getMilestoneAudit',
+			call: 'admin_getMilestoneAudit',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'unlockMilestone',
+			call: 'admin_unlockMilestone',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'purgeFutureMilestones',
+			call: 'admin_purgeFutureMilestones',
+			params: 1
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
@@ -515,6 +535,18 @@ web3._extend({
 			params: 2,
 			inputFormatter:[web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter],
 		}),
+		new web3._extend.Method({
+			name: 'profileParallelBlock',
+			call: 'debug_profileParallelBlock',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter],
+		}),
+		new web3._extend.Method({
+			name: 'checkTxDependency',
+			call: 'debug_checkTxDependency',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter],
+		}),
 		new web3._extend.Method({
 			name: 'getWhitelistedCheckpoint',
 			call: 'debug_getWhitelistedCheckpoint',
@@ -615,6 +647,11 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputTransactionFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'sendPrivateTransaction',
+			call: 'eth_sendPrivateTransaction',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'fillTransaction',
 			call: 'eth_fillTransaction',
@@ -686,6 +723,12 @@ web3._extend({
 			params: 4,
 			inputFormatter: [web3._extend.formatters.inputCallFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter, null, null],
 		}),
+		new web3._extend.Method({
+			name: 'callBatch',
+			call: 'eth_callBatch',
+			params: 4,
+			inputFormatter: [null, web3._extend.formatters.inputDefaultBlockNumberFormatter, null, null],
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
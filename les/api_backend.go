@@ -199,6 +199,14 @@ func (b *LesApiBackend) SendTx(ctx context.Context, signedTx *types.Transaction)
 	return b.eth.txPool.Add(ctx, signedTx)
 }
 
+func (b *LesApiBackend) SendPrivateTx(ctx context.Context, signedTx *types.Transaction, expiry uint64, fallback bool) error {
+	return errors.New("private transactions are not supported by light clients")
+}
+
+func (b *LesApiBackend) SendBundle(ctx context.Context, bundle *types.Bundle) error {
+	return errors.New("bundles are not supported by light clients")
+}
+
 func (b *LesApiBackend) RemoveTx(txHash common.Hash) {
 	b.eth.txPool.RemoveTx(txHash)
 }
@@ -18,6 +18,15 @@ func (b *LesApiBackend) SubscribeStateSyncEvent(ch chan<- core.StateSyncEvent) e
 	return b.eth.blockchain.SubscribeStateSyncEvent(ch)
 }
 
+// SubscribeFinalityEvent subscribe whitelisted checkpoints and milestones, which
+// light clients don't track.
+func (b *LesApiBackend) SubscribeFinalityEvent(ch chan<- core.FinalityEvent) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+}
+
 // SubscribeChain2HeadEvent subscribe head/fork/reorg events.
 func (b *LesApiBackend) SubscribeChain2HeadEvent(ch chan<- core.Chain2HeadEvent) event.Subscription {
 	return b.eth.BlockChain().SubscribeChain2HeadEvent(ch)
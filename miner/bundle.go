@@ -0,0 +1,319 @@
+package miner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// maxBundles is the maximum number of bundles waiting for inclusion
+	maxBundles = 256
+
+	// maxBundleTxs is the maximum number of transactions of a bundle
+	maxBundleTxs = 16
+
+	// bundleSimulationTimeout is the time allowed to simulate the pending
+	// bundles of a block, the others are left for the next blocks
+	bundleSimulationTimeout = 200 * time.Millisecond
+
+	// bundleSimulationGasFactor bounds the gas of the bundles simulated for a
+	// block, as a multiple of its gas limit
+	bundleSimulationGasFactor = 2
+)
+
+var (
+	errBundleEmpty    = errors.New("empty bundle")
+	errBundleTooLarge = fmt.Errorf("bundle exceeds %d transactions", maxBundleTxs)
+	errBundleKnown    = errors.New("bundle already known")
+	errBundlePoolFull = errors.New("bundle pool full")
+	errBundleExpired  = errors.New("bundle range already passed")
+	errBundleReverted = errors.New("bundle transaction reverted")
+	errBundleGasLimit = errors.New("bundle exceeds block gas limit")
+	errBundleNonce    = errors.New("bundle nonces of a sender not consecutive")
+
+	bundleIncludedMeter = metrics.NewRegisteredMeter("worker/bundle/included", nil)
+	bundleFailedMeter   = metrics.NewRegisteredMeter("worker/bundle/failed", nil)
+)
+
+// bundlePool keeps the bundles waiting for inclusion until their range passes,
+// or their transactions are included or replaced.
+type bundlePool struct {
+	mu      sync.Mutex
+	bundles map[common.Hash]*types.Bundle
+}
+
+func newBundlePool() *bundlePool {
+	return &bundlePool{bundles: make(map[common.Hash]*types.Bundle)}
+}
+
+// add adds a bundle to the pool, expiring at the given head.
+func (p *bundlePool) add(bundle *types.Bundle, head *types.Header) error {
+	switch {
+	case len(bundle.Txs) == 0:
+		return errBundleEmpty
+	case len(bundle.Txs) > maxBundleTxs:
+		return errBundleTooLarge
+	case head != nil && bundle.Expired(head):
+		return errBundleExpired
+	}
+
+	hash := bundle.Hash()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.bundles[hash]; ok {
+		return errBundleKnown
+	}
+
+	if len(p.bundles) >= maxBundles {
+		return errBundlePoolFull
+	}
+
+	p.bundles[hash] = bundle
+
+	return nil
+}
+
+// remove removes a bundle from the pool.
+func (p *bundlePool) remove(hash common.Hash) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.bundles, hash)
+}
+
+// pending returns the bundles whose range includes the block of the header,
+// and drops the ones whose range passed.
+func (p *bundlePool) pending(header *types.Header) []*types.Bundle {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var bundles []*types.Bundle
+
+	for hash, bundle := range p.bundles {
+		if bundle.Expired(header) {
+			delete(p.bundles, hash)
+			continue
+		}
+
+		if bundle.ValidateRange(header) == nil {
+			bundles = append(bundles, bundle)
+		}
+	}
+
+	return bundles
+}
+
+// AddBundle adds a bundle of transactions, included all-or-nothing at the top
+// of the blocks built within its range.
+func (miner *Miner) AddBundle(bundle *types.Bundle) error {
+	head := miner.worker.chain.CurrentBlock()
+
+	if err := miner.worker.validateBundle(bundle, head); err != nil {
+		return err
+	}
+
+	return miner.worker.bundles.add(bundle, head)
+}
+
+// validateBundle checks the signatures of the bundle transactions, and that
+// their nonces and the balances of their senders are valid at the given head.
+// The nonces of a sender must follow each other within the bundle.
+func (w *worker) validateBundle(bundle *types.Bundle, head *types.Header) error {
+	statedb, err := w.chain.StateAt(head.Root)
+	if err != nil {
+		return err
+	}
+
+	var (
+		signer = types.MakeSigner(w.chainConfig, head.Number, head.Time)
+		nonces = make(map[common.Address]uint64)
+		costs  = make(map[common.Address]*big.Int)
+		gas    uint64
+	)
+
+	for _, tx := range bundle.Txs {
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			return fmt.Errorf("transaction %x: %w", tx.Hash(), err)
+		}
+
+		if next, ok := nonces[from]; ok {
+			if tx.Nonce() != next {
+				return fmt.Errorf("transaction %x: %w: have %d, want %d", tx.Hash(), errBundleNonce, tx.Nonce(), next)
+			}
+		} else if nonce := statedb.GetNonce(from); tx.Nonce() < nonce {
+			return fmt.Errorf("transaction %x: %w: address %v, tx: %d state: %d", tx.Hash(), core.ErrNonceTooLow, from, tx.Nonce(), nonce)
+		}
+
+		nonces[from] = tx.Nonce() + 1
+
+		cost, ok := costs[from]
+		if !ok {
+			cost = new(big.Int)
+			costs[from] = cost
+		}
+
+		if cost.Add(cost, tx.Cost()); statedb.GetBalance(from).Cmp(cost) < 0 {
+			return fmt.Errorf("transaction %x: %w: address %v", tx.Hash(), core.ErrInsufficientFunds, from)
+		}
+
+		gas += tx.Gas()
+	}
+
+	if gas > head.GasLimit {
+		return fmt.Errorf("%w: have %d, want at most %d", errBundleGasLimit, gas, head.GasLimit)
+	}
+
+	return nil
+}
+
+// transientBundleError returns whether a bundle failed for a reason depending
+// on the block it was applied to, rather than on the bundle itself.
+func transientBundleError(err error) bool {
+	return errors.Is(err, core.ErrGasLimitReached) ||
+		errors.Is(err, core.ErrNonceTooHigh) ||
+		errors.Is(err, core.ErrFeeCapTooLow) ||
+		errors.Is(err, vm.ErrInterrupt)
+}
+
+// bundleGas returns the gas limit of the bundle transactions.
+func bundleGas(bundle *types.Bundle) uint64 {
+	var gas uint64
+	for _, tx := range bundle.Txs {
+		gas += tx.Gas()
+	}
+
+	return gas
+}
+
+// commitBundles simulates the pending bundles against the environment, and
+// commits them at the top of the block ranked by their effective tip per gas.
+// A bundle is committed only if all its transactions succeed, or revert while
+// allowed to.
+func (w *worker) commitBundles(env *environment, interruptCtx context.Context) {
+	bundles := w.bundles.pending(env.header)
+	if len(bundles) == 0 {
+		return
+	}
+
+	if env.gasPool == nil {
+		env.gasPool = new(core.GasPool).AddGas(env.header.GasLimit)
+	}
+
+	type rankedBundle struct {
+		bundle *types.Bundle
+		tip    *big.Int
+	}
+
+	var (
+		ranked   = make([]rankedBundle, 0, len(bundles))
+		deadline = time.Now().Add(bundleSimulationTimeout)
+		gas      = bundleSimulationGasFactor * env.header.GasLimit
+	)
+
+	// Each simulation copies the environment, so the bundles simulated for a
+	// block are bounded in time and gas
+	for i, bundle := range bundles {
+		used := bundleGas(bundle)
+		if used > gas || time.Now().After(deadline) {
+			log.Debug("Bundle simulation budget exhausted", "number", env.header.Number, "skipped", len(bundles)-i)
+			break
+		}
+
+		gas -= used
+
+		sim, tip, err := w.applyBundle(env, bundle, interruptCtx)
+		if err != nil {
+			// The bundle can't succeed in any block, e.g. its transactions
+			// were included or replaced, or it reverted
+			if !transientBundleError(err) {
+				w.bundles.remove(bundle.Hash())
+			}
+
+			log.Debug("Skipping bundle", "hash", bundle.Hash(), "err", err)
+
+			continue
+		}
+
+		sim.discard()
+
+		ranked = append(ranked, rankedBundle{bundle: bundle, tip: tip})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].tip.Cmp(ranked[j].tip) > 0
+	})
+
+	for _, r := range ranked {
+		// Apply the bundle again, as the bundles committed before changed the state
+		next, _, err := w.applyBundle(env, r.bundle, interruptCtx)
+		if err != nil {
+			bundleFailedMeter.Mark(1)
+			log.Debug("Skipping conflicting bundle", "hash", r.bundle.Hash(), "err", err)
+
+			continue
+		}
+
+		env.state.StopPrefetcher()
+		env.state = next.state
+		env.gasPool = next.gasPool
+		env.header.GasUsed = next.header.GasUsed
+		env.txs = next.txs
+		env.receipts = next.receipts
+		env.tcount = next.tcount
+
+		bundleIncludedMeter.Mark(1)
+		log.Debug("Committed bundle", "hash", r.bundle.Hash(), "txs", len(r.bundle.Txs), "tip", r.tip)
+	}
+}
+
+// applyBundle applies the transactions of a bundle to a copy of the environment,
+// and returns it with the effective tip per gas of the bundle. The state can't
+// be reverted to a snapshot once a transaction is finalised, hence the copy.
+func (w *worker) applyBundle(env *environment, bundle *types.Bundle, interruptCtx context.Context) (*environment, *big.Int, error) {
+	var (
+		cpy     = env.copy()
+		gasUsed = cpy.header.GasUsed
+		tips    = new(big.Int)
+	)
+
+	for _, tx := range bundle.Txs {
+		cpy.state.SetTxContext(tx.Hash(), cpy.tcount)
+
+		if _, err := w.commitTransaction(cpy, tx, interruptCtx); err != nil {
+			cpy.discard()
+			return nil, nil, fmt.Errorf("transaction %x: %w", tx.Hash(), err)
+		}
+
+		receipt := cpy.receipts[len(cpy.receipts)-1]
+		if receipt.Status == types.ReceiptStatusFailed && !bundle.CanRevert(tx.Hash()) {
+			cpy.discard()
+			return nil, nil, fmt.Errorf("%w: %x", errBundleReverted, tx.Hash())
+		}
+
+		tip, err := tx.EffectiveGasTip(cpy.header.BaseFee)
+		if err != nil {
+			cpy.discard()
+			return nil, nil, fmt.Errorf("transaction %x: %w", tx.Hash(), err)
+		}
+
+		tips.Add(tips, tip.Mul(tip, new(big.Int).SetUint64(receipt.GasUsed)))
+		cpy.tcount++
+	}
+
+	return cpy, tips.Div(tips, new(big.Int).SetUint64(cpy.header.GasUsed-gasUsed)), nil
+}
@@ -0,0 +1,164 @@
+package miner
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// revertingCode is an init code reverting the contract creation
+const revertingCode = "0x60006000fd"
+
+// Tests that the bundles are kept until their range passes.
+func TestBundlePool(t *testing.T) {
+	t.Parallel()
+
+	pool := newBundlePool()
+	header := func(number int64) *types.Header {
+		return &types.Header{Number: big.NewInt(number)}
+	}
+
+	if err := pool.add(&types.Bundle{}, nil); !errors.Is(err, errBundleEmpty) {
+		t.Fatalf("empty bundle error mismatch: have %v, want %v", err, errBundleEmpty)
+	}
+
+	tx := types.NewTransaction(0, testUserAddress, big.NewInt(1), params.TxGas, big.NewInt(1), nil)
+	bundle := &types.Bundle{
+		Txs:            types.Transactions{tx},
+		BlockNumberMin: big.NewInt(3),
+		BlockNumberMax: big.NewInt(5),
+	}
+
+	if err := pool.add(bundle, header(6)); !errors.Is(err, errBundleExpired) {
+		t.Fatalf("expired bundle error mismatch: have %v, want %v", err, errBundleExpired)
+	}
+
+	if err := pool.add(bundle, header(1)); err != nil {
+		t.Fatalf("failed to add bundle: %v", err)
+	}
+
+	if err := pool.add(bundle, header(1)); !errors.Is(err, errBundleKnown) {
+		t.Fatalf("known bundle error mismatch: have %v, want %v", err, errBundleKnown)
+	}
+
+	if bundles := pool.pending(header(2)); len(bundles) != 0 {
+		t.Fatalf("bundle pending before its range: %d", len(bundles))
+	}
+
+	if bundles := pool.pending(header(5)); len(bundles) != 1 {
+		t.Fatalf("bundle not pending in its range")
+	}
+
+	if bundles := pool.pending(header(6)); len(bundles) != 0 || len(pool.bundles) != 0 {
+		t.Fatalf("bundle not dropped after its range")
+	}
+}
+
+// Tests that the bundles are committed at the top of the block, and only if
+// none of their transactions revert unless allowed to.
+func TestCommitBundles(t *testing.T) {
+	t.Parallel()
+
+	engine := ethash.NewFaker()
+	defer engine.Close()
+
+	w, b, _ := newTestWorker(t, ethashChainConfig, engine, rawdb.NewMemoryDatabase(), false, 0, 0)
+	defer w.close()
+
+	w.skipSealHook = func(task *task) bool {
+		return true
+	}
+
+	gasPrice := big.NewInt(10 * params.InitialBaseFee)
+	newBundle := func(value int64) *types.Bundle {
+		transfer, _ := types.SignTx(types.NewTransaction(0, testUserAddress, big.NewInt(value), params.TxGas, gasPrice, nil), types.HomesteadSigner{}, testBankKey)
+		create, _ := types.SignTx(types.NewContractCreation(1, big.NewInt(0), 100000, gasPrice, common.FromHex(revertingCode)), types.HomesteadSigner{}, testBankKey)
+
+		return &types.Bundle{Txs: types.Transactions{transfer, create}}
+	}
+
+	// The contract creation reverts, failing the bundle
+	reverted := newBundle(1)
+	if err := w.bundles.add(reverted, nil); err != nil {
+		t.Fatalf("failed to add bundle: %v", err)
+	}
+
+	block, _, err := w.getSealingBlock(b.chain.Genesis().Hash(), uint64(time.Now().Unix()), testBankAddress, common.Hash{}, nil, false)
+	if err != nil {
+		t.Fatalf("failed to build block: %v", err)
+	}
+
+	for _, tx := range reverted.Txs {
+		if block.Transaction(tx.Hash()) != nil {
+			t.Fatalf("reverted bundle transaction %x included", tx.Hash())
+		}
+	}
+
+	if len(w.bundles.bundles) != 0 {
+		t.Fatalf("reverted bundle not evicted")
+	}
+
+	// The contract creation is allowed to revert, including the bundle
+	allowed := newBundle(2)
+	allowed.RevertingTxHashes = []common.Hash{allowed.Txs[1].Hash()}
+
+	if err := w.bundles.add(allowed, nil); err != nil {
+		t.Fatalf("failed to add bundle: %v", err)
+	}
+
+	block, _, err = w.getSealingBlock(b.chain.Genesis().Hash(), uint64(time.Now().Unix()), testBankAddress, common.Hash{}, nil, false)
+	if err != nil {
+		t.Fatalf("failed to build block: %v", err)
+	}
+
+	txs := block.Transactions()
+	if len(txs) < 2 || txs[0].Hash() != allowed.Txs[0].Hash() || txs[1].Hash() != allowed.Txs[1].Hash() {
+		t.Fatalf("bundle not committed at the top of the block: have %d transactions", len(txs))
+	}
+}
+
+// Tests that the bundles are validated against the head state when submitted.
+func TestValidateBundle(t *testing.T) {
+	t.Parallel()
+
+	engine := ethash.NewFaker()
+	defer engine.Close()
+
+	w, b, _ := newTestWorker(t, ethashChainConfig, engine, rawdb.NewMemoryDatabase(), false, 0, 0)
+	defer w.close()
+
+	var (
+		head     = b.chain.CurrentBlock()
+		gasPrice = big.NewInt(10 * params.InitialBaseFee)
+	)
+
+	transfer := func(nonce uint64, value *big.Int) *types.Transaction {
+		tx, _ := types.SignTx(types.NewTransaction(nonce, testUserAddress, value, params.TxGas, gasPrice, nil), types.HomesteadSigner{}, testBankKey)
+		return tx
+	}
+
+	tests := []struct {
+		name string
+		txs  types.Transactions
+		err  error
+	}{
+		{"valid", types.Transactions{transfer(0, big.NewInt(1)), transfer(1, big.NewInt(1))}, nil},
+		{"unsigned", types.Transactions{types.NewTransaction(0, testUserAddress, big.NewInt(1), params.TxGas, gasPrice, nil)}, types.ErrInvalidSig},
+		{"nonce gap", types.Transactions{transfer(0, big.NewInt(1)), transfer(2, big.NewInt(1))}, errBundleNonce},
+		{"insufficient funds", types.Transactions{transfer(0, testBankFunds), transfer(1, big.NewInt(1))}, core.ErrInsufficientFunds},
+	}
+
+	for _, test := range tests {
+		if err := w.validateBundle(&types.Bundle{Txs: test.txs}, head); !errors.Is(err, test.err) {
+			t.Errorf("%s: error mismatch: have %v, want %v", test.name, err, test.err)
+		}
+	}
+}
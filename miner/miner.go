@@ -54,6 +54,10 @@ type Config struct {
 	Recommit            time.Duration  // The time interval for miner to re-create mining work.
 	CommitInterruptFlag bool           // Interrupt commit when time is up ( default = true)
 
+	ParallelBlockProduction bool // Speculatively execute batches of transactions with Block-STM when building blocks
+	ParallelBatchSize       int  // Maximum number of transactions executed in a single Block-STM batch
+	ParallelProcs           int  // Number of speculative processes used by Block-STM
+
 	NewPayloadTimeout time.Duration // The maximum time allowance for creating a new payload
 }
 
@@ -68,6 +72,9 @@ var DefaultConfig = Config{
 	// run 3 rounds.
 	Recommit:          2 * time.Second,
 	NewPayloadTimeout: 2 * time.Second,
+
+	ParallelBatchSize: 64,
+	ParallelProcs:     8,
 }
 
 // Miner creates blocks and searches for proof-of-work values.
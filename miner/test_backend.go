@@ -48,6 +48,7 @@ func newWorkerWithDelay(config *Config, chainConfig *params.ChainConfig, engine
 		coinbase:            config.Etherbase,
 		extra:               config.ExtraData,
 		pendingTasks:        make(map[common.Hash]*task),
+		bundles:             newBundlePool(),
 		txsCh:               make(chan core.NewTxsEvent, txChanSize),
 		chainHeadCh:         make(chan core.ChainHeadEvent, chainHeadChanSize),
 		newWorkCh:           make(chan *newWorkReq),
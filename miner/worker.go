@@ -95,6 +95,10 @@ var (
 	sealedBlocksCounter      = metrics.NewRegisteredCounter("worker/sealedBlocks", nil)
 	sealedEmptyBlocksCounter = metrics.NewRegisteredCounter("worker/sealedEmptyBlocks", nil)
 	txCommitInterruptCounter = metrics.NewRegisteredCounter("worker/txCommitInterrupt", nil)
+
+	// metrics of the transaction batches applied in parallel with Block-STM
+	parallelBatchTxsMeter      = metrics.NewRegisteredMeter("worker/parallelBatch/txs", nil)
+	parallelBatchFailedCounter = metrics.NewRegisteredCounter("worker/parallelBatch/failed", nil)
 )
 
 // environment is the worker's current environment and holds all
@@ -109,6 +113,8 @@ type environment struct {
 	header   *types.Header
 	txs      []*types.Transaction
 	receipts []*types.Receipt
+
+	public *environment // Environment before the private transactions, exposed as the pending block
 }
 
 // copy creates a deep copy of environment.
@@ -120,6 +126,7 @@ func (env *environment) copy() *environment {
 		coinbase: env.coinbase,
 		header:   types.CopyHeader(env.header),
 		receipts: copyReceipts(env.receipts),
+		public:   env.public,
 	}
 
 	if env.gasPool != nil {
@@ -229,6 +236,8 @@ type worker struct {
 	pendingMu    sync.RWMutex
 	pendingTasks map[common.Hash]*task
 
+	bundles *bundlePool // Bundles included all-or-nothing at the top of the blocks
+
 	snapshotMu       sync.RWMutex // The lock used to protect the snapshots below
 	snapshotBlock    *types.Block
 	snapshotReceipts types.Receipts
@@ -284,6 +293,7 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 		coinbase:            config.Etherbase,
 		extra:               config.ExtraData,
 		pendingTasks:        make(map[common.Hash]*task),
+		bundles:             newBundlePool(),
 		txsCh:               make(chan core.NewTxsEvent, txChanSize),
 		chainHeadCh:         make(chan core.ChainHeadEvent, chainHeadChanSize),
 		newWorkCh:           make(chan *newWorkReq),
@@ -636,13 +646,20 @@ func (w *worker) mainLoop(ctx context.Context) {
 						GasTipCap: tx.GasTipCap(),
 					})
 				}
-				txset := newTransactionsByPriceAndNonce(w.current.signer, txs, w.current.header.BaseFee)
-				tcount := w.current.tcount
-				w.commitTransactions(w.current, txset, nil, context.Background())
+				// The private transactions aren't announced, so the new ones
+				// go on top of the pending block without them
+				env := w.current
+				if env.public != nil {
+					env = env.public
+				}
+
+				txset := newTransactionsByPriceAndNonce(env.signer, txs, env.header.BaseFee)
+				tcount := env.tcount
+				w.commitTransactions(env, txset, nil, context.Background())
 
 				// Only update the snapshot if any new transactons were added
 				// to the pending block
-				if tcount != w.current.tcount {
+				if tcount != env.tcount {
 					w.updateSnapshot(w.current)
 				}
 			} else {
@@ -857,8 +874,13 @@ func (w *worker) makeEnv(parent *types.Header, header *types.Header, coinbase co
 	return env, nil
 }
 
-// updateSnapshot updates pending snapshot block, receipts and state.
+// updateSnapshot updates pending snapshot block, receipts and state. The private
+// transactions are left out, as they must not be exposed before their expiry.
 func (w *worker) updateSnapshot(env *environment) {
+	if env.public != nil {
+		env = env.public
+	}
+
 	w.snapshotMu.Lock()
 	defer w.snapshotMu.Unlock()
 
@@ -895,6 +917,75 @@ func (w *worker) commitTransaction(env *environment, tx *types.Transaction, inte
 	return receipt.Logs, nil
 }
 
+// nextTransactionBatch shifts out of txs the next transactions to speculatively
+// execute together, as long as their gas limits fit in the remaining block gas.
+// Transactions which need to be checked against the state of the block before
+// being applied, like conditional ones, end the batch.
+func (w *worker) nextTransactionBatch(env *environment, txs *transactionsByPriceAndNonce, dropped map[common.Address]struct{}) []*txpool.Transaction {
+	var (
+		batch []*txpool.Transaction
+		gas   = env.gasPool.Gas()
+	)
+
+	for len(batch) < w.config.ParallelBatchSize {
+		ltx := txs.Peek()
+		if ltx == nil {
+			break
+		}
+
+		tx := ltx.Resolve()
+		if tx == nil || tx.Tx.Gas() > gas || tx.Tx.GetOptions() != nil {
+			break
+		}
+
+		if tx.Tx.Protected() && !w.chainConfig.IsEIP155(env.header.Number) {
+			break
+		}
+
+		from, _ := types.Sender(env.signer, tx.Tx)
+		if _, ok := dropped[from]; ok {
+			break
+		}
+
+		batch = append(batch, tx)
+		gas -= tx.Tx.Gas()
+
+		txs.Shift()
+	}
+
+	return batch
+}
+
+// commitTransactionBatch applies a batch of transactions with Block-STM and, if
+// all of them succeed, commits them to the sealing block in the batch order.
+// The environment is left untouched otherwise.
+func (w *worker) commitTransactionBatch(env *environment, batch []*txpool.Transaction, interruptCtx context.Context) (*core.ParallelApplyResult, error) {
+	txs := make([]*types.Transaction, len(batch))
+	for i, tx := range batch {
+		txs[i] = tx.Tx
+	}
+
+	result, err := core.ApplyTransactionsParallel(w.chainConfig, w.chain, &env.coinbase, env.state, env.header, txs, env.tcount, env.header.GasUsed, *w.chain.GetVMConfig(), w.config.ParallelProcs, interruptCtx)
+	if err != nil {
+		parallelBatchFailedCounter.Inc(1)
+		return nil, err
+	}
+
+	if err := env.gasPool.SubGas(result.UsedGas - env.header.GasUsed); err != nil {
+		return nil, err
+	}
+
+	env.state.StopPrefetcher()
+	env.state = result.State
+	env.header.GasUsed = result.UsedGas
+	env.txs = append(env.txs, txs...)
+	env.receipts = append(env.receipts, result.Receipts...)
+
+	parallelBatchTxsMeter.Mark(int64(len(txs)))
+
+	return result, nil
+}
+
 func (w *worker) commitTransactions(env *environment, txs *transactionsByPriceAndNonce, interrupt *atomic.Int32, interruptCtx context.Context) error {
 	gasLimit := env.header.GasLimit
 	if env.gasPool == nil {
@@ -944,6 +1035,14 @@ func (w *worker) commitTransactions(env *environment, txs *transactionsByPriceAn
 		}(chDeps)
 	}
 
+	// Transactions of a batch which failed to apply in parallel, committed one by
+	// one, and the senders whose remaining transactions are skipped
+	var pending []*txpool.Transaction
+
+	dropped := make(map[common.Address]struct{})
+
+	parallel := w.config.ParallelBlockProduction && w.config.ParallelBatchSize > 1
+
 	initialGasLimit := env.gasPool.Gas()
 
 	initialTxs := txs.GetTxs()
@@ -991,43 +1090,119 @@ mainloop:
 			log.Trace("Not enough gas for further transactions", "have", env.gasPool, "want", params.TxGas)
 			break
 		}
+		// Speculatively execute the next batch of transactions, falling back to
+		// committing them one by one if any of them can't be applied
+		if parallel && len(pending) == 0 {
+			batch := w.nextTransactionBatch(env, txs, dropped)
+			if len(batch) > 1 {
+				result, err := w.commitTransactionBatch(env, batch, interruptCtx)
+				if err == nil {
+					for i, tx := range batch {
+						coalescedLogs = append(coalescedLogs, result.Receipts[i].Logs...)
+						env.tcount++
+
+						if EnableMVHashMap {
+							reads := result.TxIO.ReadSet(i)
+							readMap := make(map[blockstm.Key]blockstm.ReadDescriptor, len(reads))
+
+							for _, read := range reads {
+								readMap[read.Path] = read
+							}
+
+							depsMVReadList = append(depsMVReadList, reads)
+							depsMVFullWriteList = append(depsMVFullWriteList, result.TxIO.AllWriteSet(i))
+							mvReadMapList = append(mvReadMapList, readMap)
+
+							chDeps <- blockstm.TxDep{
+								Index:         env.tcount - 1,
+								ReadList:      depsMVReadList[count],
+								FullWriteList: depsMVFullWriteList,
+							}
+							count++
+						}
+
+						log.OnDebug(func(lg log.Logging) {
+							lg("Committed new tx in parallel batch", "tx hash", tx.Tx.Hash(), "nonce", tx.Tx.Nonce(), "gas", tx.Tx.Gas())
+						})
+					}
+
+					continue
+				}
+
+				log.Debug("Failed to apply transaction batch in parallel, committing sequentially", "txs", len(batch), "err", err)
+			}
+
+			pending = batch
+		}
+
 		// Retrieve the next transaction and abort if all done.
-		ltx := txs.Peek()
-		if ltx == nil {
-			breakCause = "all transactions has been included"
-			break
+		var (
+			tx          *txpool.Transaction
+			fromPending = len(pending) > 0
+		)
+
+		if fromPending {
+			tx, pending = pending[0], pending[1:]
+		} else {
+			ltx := txs.Peek()
+			if ltx == nil {
+				breakCause = "all transactions has been included"
+				break
+			}
+
+			if tx = ltx.Resolve(); tx == nil {
+				log.Warn("Ignoring evicted transaction")
+
+				txs.Pop()
+				continue
+			}
 		}
-		tx := ltx.Resolve()
-		if tx == nil {
-			log.Warn("Ignoring evicted transaction")
 
-			txs.Pop()
-			continue
+		// Transactions of a batch were already shifted out of txs, drop the
+		// sender by skipping its next transactions instead
+		shift := func() {
+			if !fromPending {
+				txs.Shift()
+			}
 		}
+
+		pop := func(from common.Address) {
+			if fromPending {
+				dropped[from] = struct{}{}
+			} else {
+				txs.Pop()
+			}
+		}
+
 		// Error may be ignored here. The error has already been checked
 		// during transaction acceptance is the transaction pool.
 		from, _ := types.Sender(env.signer, tx.Tx)
 
+		if _, ok := dropped[from]; ok {
+			pop(from)
+			continue
+		}
+
 		// not prioritising conditional transaction, yet.
 		//nolint:nestif
 		if options := tx.Tx.GetOptions(); options != nil {
 			if err := env.header.ValidateBlockNumberOptions4337(options.BlockNumberMin, options.BlockNumberMax); err != nil {
 				log.Trace("Dropping conditional transaction", "from", from, "hash", tx.Tx.Hash(), "reason", err)
-				txs.Pop()
+				pop(from)
 
 				continue
 			}
 
 			if err := env.header.ValidateTimestampOptions4337(options.TimestampMin, options.TimestampMax); err != nil {
 				log.Trace("Dropping conditional transaction", "from", from, "hash", tx.Tx.Hash(), "reason", err)
-				txs.Pop()
+				pop(from)
 
 				continue
 			}
 
 			if err := env.state.ValidateKnownAccounts(options.KnownAccounts); err != nil {
 				log.Trace("Dropping conditional transaction", "from", from, "hash", tx.Tx.Hash(), "reason", err)
-				txs.Pop()
+				pop(from)
 
 				continue
 			}
@@ -1038,7 +1213,7 @@ mainloop:
 		if tx.Tx.Protected() && !w.chainConfig.IsEIP155(env.header.Number) {
 			log.Trace("Ignoring reply protected transaction", "hash", tx.Tx.Hash(), "eip155", w.chainConfig.EIP155Block)
 
-			txs.Pop()
+			pop(from)
 			continue
 		}
 		// Start executing the transaction
@@ -1056,7 +1231,7 @@ mainloop:
 		case errors.Is(err, core.ErrNonceTooLow):
 			// New head notification data race between the transaction pool and miner, shift
 			log.Trace("Skipping transaction with low nonce", "sender", from, "nonce", tx.Tx.Nonce())
-			txs.Shift()
+			shift()
 
 		case errors.Is(err, nil):
 			// Everything ok, collect the logs and shift in the next transaction from the same account
@@ -1078,7 +1253,7 @@ mainloop:
 				count++
 			}
 
-			txs.Shift()
+			shift()
 
 			log.OnDebug(func(lg log.Logging) {
 				lg("Committed new tx", "tx hash", tx.Tx.Hash(), "from", from, "to", tx.Tx.To(), "nonce", tx.Tx.Nonce(), "gas", tx.Tx.Gas(), "gasPrice", tx.Tx.GasPrice(), "value", tx.Tx.Value(), "time spent", time.Since(start))
@@ -1088,7 +1263,7 @@ mainloop:
 			// Transaction is regarded as invalid, drop all consecutive transactions from
 			// the same sender because of `nonce-too-high` clause.
 			log.Debug("Transaction failed, account skipped", "hash", tx.Tx.Hash(), "err", err)
-			txs.Pop()
+			pop(from)
 		}
 
 		if EnableMVHashMap {
@@ -1340,6 +1515,8 @@ func (w *worker) fillTransactions(ctx context.Context, interrupt *atomic.Int32,
 	pending := w.eth.TxPool().Pending(true)
 	localTxs, remoteTxs := make(map[common.Address][]*txpool.LazyTransaction), pending
 
+	var privateTxs map[common.Address][]*txpool.LazyTransaction
+
 	var (
 		localTxsCount  int
 		remoteTxsCount int
@@ -1410,7 +1587,7 @@ func (w *worker) fillTransactions(ctx context.Context, interrupt *atomic.Int32,
 		prePendingTime := time.Now()
 
 		pending := w.eth.TxPool().Pending(true)
-		remoteTxs = pending
+		remoteTxs, privateTxs = splitPrivateTxs(pending)
 
 		postPendingTime := time.Now()
 
@@ -1439,6 +1616,11 @@ func (w *worker) fillTransactions(ctx context.Context, interrupt *atomic.Int32,
 		err             error
 	)
 
+	// Bundles are committed all-or-nothing at the top of the block
+	tracing.Exec(ctx, "", "worker.CommitBundles", func(ctx context.Context, span trace.Span) {
+		w.commitBundles(env, interruptCtx)
+	})
+
 	if len(localTxs) > 0 {
 		var txs *transactionsByPriceAndNonce
 
@@ -1495,6 +1677,27 @@ func (w *worker) fillTransactions(ctx context.Context, interrupt *atomic.Int32,
 		remoteEnvTCount = env.tcount
 	}
 
+	// Private transactions are committed last, so that the pending block can
+	// be taken without them
+	if len(privateTxs) > 0 {
+		env.public = env.copy()
+
+		var baseFee *uint256.Int
+		if env.header.BaseFee != nil {
+			baseFee = cmath.FromBig(env.header.BaseFee)
+		}
+
+		txs := newTransactionsByPriceAndNonce(env.signer, privateTxs, baseFee.ToBig())
+
+		tracing.Exec(ctx, "", "worker.PrivateCommitTransactions", func(ctx context.Context, span trace.Span) {
+			err = w.commitTransactions(env, txs, interrupt, interruptCtx)
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
 	tracing.SetAttributes(
 		span,
 		attribute.Int("len of final local txs ", localEnvTCount),
@@ -1504,6 +1707,34 @@ func (w *worker) fillTransactions(ctx context.Context, interrupt *atomic.Int32,
 	return nil
 }
 
+// splitPrivateTxs splits the private transactions out of the pending ones. The
+// transactions of an account following a private one are split out with it, to
+// keep the nonces contiguous.
+func splitPrivateTxs(pending map[common.Address][]*txpool.LazyTransaction) (map[common.Address][]*txpool.LazyTransaction, map[common.Address][]*txpool.LazyTransaction) {
+	private := make(map[common.Address][]*txpool.LazyTransaction)
+
+	for addr, txs := range pending {
+		for i, tx := range txs {
+			// Blob transactions are resolved lazily and are never private
+			if tx.Tx == nil || !tx.Tx.Private {
+				continue
+			}
+
+			private[addr] = txs[i:]
+
+			if i == 0 {
+				delete(pending, addr)
+			} else {
+				pending[addr] = txs[:i]
+			}
+
+			break
+		}
+	}
+
+	return pending, private
+}
+
 // generateWork generates a sealing block based on the given parameters.
 func (w *worker) generateWork(ctx context.Context, params *generateParams) (*types.Block, *big.Int, error) {
 	work, err := w.prepareWork(params)
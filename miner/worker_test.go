@@ -375,6 +375,60 @@ func TestGenerateAndImportBlock(t *testing.T) {
 	}
 }
 
+func TestGenerateAndImportBlockParallel(t *testing.T) {
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		config = *params.AllCliqueProtocolChanges
+	)
+	config.Clique = &params.CliqueConfig{Period: 1, Epoch: 30000}
+	engine := clique.New(config.Clique, db)
+
+	minerConfig := *testConfig
+	minerConfig.ParallelBlockProduction = true
+	minerConfig.ParallelBatchSize = 4
+	minerConfig.ParallelProcs = 4
+
+	b := newTestWorkerBackend(t, &config, engine, db)
+	b.txPool.Add(pendingTxs, true, false)
+
+	//nolint:staticcheck
+	w := newWorker(&minerConfig, &config, engine, b, new(event.TypeMux), nil, false)
+	w.setEtherbase(testBankAddress)
+	defer w.close()
+
+	// This test chain imports the mined blocks.
+	chain, _ := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, b.genesis, nil, engine, vm.Config{}, nil, nil, nil)
+	defer chain.Stop()
+
+	// Ignore empty commit here for less noise.
+	w.skipSealHook = func(task *task) bool {
+		return len(task.receipts) == 0
+	}
+
+	// Wait for mined blocks.
+	sub := w.mux.Subscribe(core.NewMinedBlockEvent{})
+	defer sub.Unsubscribe()
+
+	// Start mining!
+	w.start()
+
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			b.txPool.Add([]*txpool.Transaction{{Tx: b.newRandomTx(j == 0)}}, true, false)
+		}
+
+		select {
+		case ev := <-sub.Chan():
+			block := ev.Data.(core.NewMinedBlockEvent).Block
+			if _, err := chain.InsertChain([]*types.Block{block}); err != nil {
+				t.Fatalf("failed to insert new mined block %d: %v", block.NumberU64(), err)
+			}
+		case <-time.After(3 * time.Second): // Worker needs 1s to include new changes.
+			t.Fatalf("timeout")
+		}
+	}
+}
+
 func getFakeBorFromConfig(t *testing.T, chainConfig *params.ChainConfig) (consensus.Engine, *gomock.Controller) {
 	t.Helper()
 
@@ -1031,3 +1085,40 @@ func BenchmarkBorMiningBlockSTMMetadata(b *testing.B) {
 		}
 	}
 }
+
+// Tests that the private transactions, and the ones following them, are split
+// out of the pending transactions.
+func TestSplitPrivateTxs(t *testing.T) {
+	t.Parallel()
+
+	lazy := func(nonce uint64, private bool) *txpool.LazyTransaction {
+		tx := types.NewTransaction(nonce, common.Address{}, big.NewInt(0), params.TxGas, big.NewInt(1), nil)
+		return &txpool.LazyTransaction{Hash: tx.Hash(), Tx: &txpool.Transaction{Tx: tx, Private: private}}
+	}
+
+	var (
+		public  = common.Address{1}
+		mixed   = common.Address{2}
+		private = common.Address{3}
+		blob    = common.Address{4}
+	)
+
+	pending := map[common.Address][]*txpool.LazyTransaction{
+		public:  {lazy(0, false), lazy(1, false)},
+		mixed:   {lazy(0, false), lazy(1, true), lazy(2, false)},
+		private: {lazy(0, true)},
+		blob:    {{Hash: common.Hash{4}}},
+	}
+
+	publicTxs, privateTxs := splitPrivateTxs(pending)
+
+	assert.Equal(t, len(publicTxs), 3)
+	assert.Equal(t, len(publicTxs[public]), 2)
+	assert.Equal(t, len(publicTxs[mixed]), 1)
+	assert.Equal(t, len(publicTxs[blob]), 1)
+
+	assert.Equal(t, len(privateTxs), 2)
+	assert.Equal(t, len(privateTxs[mixed]), 2)
+	assert.Equal(t, privateTxs[mixed][0].Tx.Tx.Nonce(), uint64(1))
+	assert.Equal(t, len(privateTxs[private]), 1)
+}
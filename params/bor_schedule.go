@@ -0,0 +1,262 @@
+package params
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// BorParams are the effective bor consensus parameters at a block, resolved from
+// the block-keyed schedules of the BorConfig.
+type BorParams struct {
+	Number                     uint64 `json:"number"`
+	Period                     uint64 `json:"period"`
+	ProducerDelay              uint64 `json:"producerDelay"`
+	Sprint                     uint64 `json:"sprint"`
+	BackupMultiplier           uint64 `json:"backupMultiplier"`
+	StateSyncConfirmationDelay uint64 `json:"stateSyncConfirmationDelay"`
+	BurntContract              string `json:"burntContract"`
+	ValidatorContract          string `json:"validatorContract"`
+	StateReceiverContract      string `json:"stateReceiverContract"`
+	Jaipur                     bool   `json:"jaipur"`
+	Delhi                      bool   `json:"delhi"`
+	Indore                     bool   `json:"indore"`
+	ParallelUniverse           bool   `json:"parallelUniverse"`
+	BlockAlloc                 bool   `json:"blockAlloc"`                         // Whether accounts are allocated at this block
+	OverrideStateSyncRecords   *int   `json:"overrideStateSyncRecords,omitempty"` // Number of state-sync records committed at this block, if overridden
+}
+
+// BorTransition is a scheduled change of a bor consensus parameter.
+type BorTransition struct {
+	Block uint64 `json:"block"`
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
+// BorConfigDifference is a block at which a bor consensus parameter differs
+// between two configs.
+type BorConfigDifference struct {
+	Block uint64 `json:"block"`
+	Field string `json:"field"`
+	A     string `json:"a"`
+	B     string `json:"b"`
+}
+
+// borSchedule is the schedule of a single bor consensus parameter.
+type borSchedule struct {
+	field  string
+	exact  bool // The value only applies at the block it's keyed by
+	values map[uint64]string
+}
+
+// valueAt returns the value of the parameter at the given block, looked up like
+// the consensus engine does.
+func (s *borSchedule) valueAt(number uint64) string {
+	if s.exact || len(s.values) == 0 {
+		return s.values[number]
+	}
+
+	return lookupSchedule(s.values, number)
+}
+
+// ParamsAt resolves the effective bor consensus parameters at the given block.
+func (c *BorConfig) ParamsAt(number uint64) *BorParams {
+	n := new(big.Int).SetUint64(number)
+
+	params := &BorParams{
+		Number:                     number,
+		Period:                     scheduleAt(c.Period, number),
+		ProducerDelay:              scheduleAt(c.ProducerDelay, number),
+		Sprint:                     scheduleAt(c.Sprint, number),
+		BackupMultiplier:           scheduleAt(c.BackupMultiplier, number),
+		StateSyncConfirmationDelay: scheduleAt(c.StateSyncConfirmationDelay, number),
+		BurntContract:              scheduleAt(c.BurntContract, number),
+		ValidatorContract:          c.ValidatorContract,
+		StateReceiverContract:      c.StateReceiverContract,
+		Jaipur:                     c.IsJaipur(n),
+		Delhi:                      c.IsDelhi(n),
+		Indore:                     c.IsIndore(n),
+		ParallelUniverse:           c.IsParallelUniverse(n),
+	}
+
+	key := strconv.FormatUint(number, 10)
+
+	_, params.BlockAlloc = c.BlockAlloc[key]
+
+	if records, ok := c.OverrideStateSyncRecords[key]; ok {
+		params.OverrideStateSyncRecords = &records
+	}
+
+	return params
+}
+
+// scheduleAt resolves a block-keyed parameter like the Calculate* helpers, but
+// returns the zero value for an empty schedule instead of panicking. Dev mode
+// and local genesis files commonly leave some of these maps unset.
+func scheduleAt[T uint64 | string](field map[string]T, number uint64) T {
+	if len(field) == 0 {
+		var zero T
+		return zero
+	}
+
+	return borKeyValueConfigHelper(field, number)
+}
+
+// lookupSchedule returns the value of a non-empty schedule at the given block:
+// the value of the highest block at or below it. Below the first block, it's
+// the value of the last one.
+func lookupSchedule[T any](values map[uint64]T, number uint64) T {
+	keys := make([]uint64, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	for i := len(keys) - 1; i >= 0; i-- {
+		if keys[i] <= number {
+			return values[keys[i]]
+		}
+	}
+
+	return values[keys[len(keys)-1]]
+}
+
+// Transitions returns the parameter changes scheduled after the given block, in
+// block order.
+func (c *BorConfig) Transitions(after uint64) []BorTransition {
+	var transitions []BorTransition
+
+	for _, schedule := range c.schedules() {
+		for block, value := range schedule.values {
+			if block > after {
+				transitions = append(transitions, BorTransition{Block: block, Field: schedule.field, Value: value})
+			}
+		}
+	}
+
+	sort.Slice(transitions, func(i, j int) bool {
+		if transitions[i].Block != transitions[j].Block {
+			return transitions[i].Block < transitions[j].Block
+		}
+
+		return transitions[i].Field < transitions[j].Field
+	})
+
+	return transitions
+}
+
+// DiffBorConfigs compares the schedules of two bor configs, returning every
+// block at which a parameter of one of them changes to a value differing from
+// the other, in block order.
+func DiffBorConfigs(a, b *BorConfig) []BorConfigDifference {
+	var (
+		schedulesA  = a.schedules()
+		schedulesB  = b.schedules()
+		differences []BorConfigDifference
+	)
+
+	for i := range schedulesA {
+		blocks := map[uint64]struct{}{0: {}}
+
+		for block := range schedulesA[i].values {
+			blocks[block] = struct{}{}
+		}
+
+		for block := range schedulesB[i].values {
+			blocks[block] = struct{}{}
+		}
+
+		for block := range blocks {
+			valueA, valueB := schedulesA[i].valueAt(block), schedulesB[i].valueAt(block)
+			if valueA != valueB {
+				differences = append(differences, BorConfigDifference{Block: block, Field: schedulesA[i].field, A: valueA, B: valueB})
+			}
+		}
+	}
+
+	sort.Slice(differences, func(i, j int) bool {
+		if differences[i].Block != differences[j].Block {
+			return differences[i].Block < differences[j].Block
+		}
+
+		return differences[i].Field < differences[j].Field
+	})
+
+	return differences
+}
+
+// schedules returns the schedules of all the bor consensus parameters, always in
+// the same order.
+func (c *BorConfig) schedules() []borSchedule {
+	return []borSchedule{
+		{field: "period", values: scheduleValues(c.Period)},
+		{field: "producerDelay", values: scheduleValues(c.ProducerDelay)},
+		{field: "sprint", values: scheduleValues(c.Sprint)},
+		{field: "backupMultiplier", values: scheduleValues(c.BackupMultiplier)},
+		{field: "stateSyncConfirmationDelay", values: scheduleValues(c.StateSyncConfirmationDelay)},
+		{field: "burntContract", values: scheduleValues(c.BurntContract)},
+		{field: "validatorContract", values: map[uint64]string{0: c.ValidatorContract}},
+		{field: "stateReceiverContract", values: map[uint64]string{0: c.StateReceiverContract}},
+		forkSchedule("jaipur", c.JaipurBlock),
+		forkSchedule("delhi", c.DelhiBlock),
+		forkSchedule("indore", c.IndoreBlock),
+		forkSchedule("parallelUniverse", c.ParallelUniverseBlock),
+		{field: "blockAlloc", exact: true, values: allocScheduleValues(c.BlockAlloc)},
+		{field: "overrideStateSyncRecords", exact: true, values: scheduleValues(c.OverrideStateSyncRecords)},
+	}
+}
+
+func scheduleValues[T uint64 | string | int](field map[string]T) map[uint64]string {
+	values := make(map[uint64]string, len(field))
+
+	for key, value := range field {
+		block, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		values[block] = fmt.Sprint(value)
+	}
+
+	return values
+}
+
+// allocScheduleValues summarizes the allocations by their number of accounts
+// and a digest of their content.
+func allocScheduleValues(field map[string]interface{}) map[uint64]string {
+	values := make(map[uint64]string, len(field))
+
+	for key, alloc := range field {
+		block, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		accounts := 0
+		if m, ok := alloc.(map[string]interface{}); ok {
+			accounts = len(m)
+		}
+
+		blob, _ := json.Marshal(alloc)
+		digest := sha256.Sum256(blob)
+
+		values[block] = fmt.Sprintf("%d accounts (%x)", accounts, digest[:4])
+	}
+
+	return values
+}
+
+func forkSchedule(field string, block *big.Int) borSchedule {
+	schedule := borSchedule{field: field, values: map[uint64]string{0: "false"}}
+
+	// Mirror IsParallelUniverse, which treats a zero block as disabled
+	if block != nil && !(field == "parallelUniverse" && block.Sign() == 0) {
+		schedule.values[block.Uint64()] = "true"
+	}
+
+	return schedule
+}
@@ -0,0 +1,104 @@
+package params
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func testBorScheduleConfig() *BorConfig {
+	return &BorConfig{
+		Period:                     map[string]uint64{"0": 2, "100": 4},
+		ProducerDelay:              map[string]uint64{"0": 6},
+		Sprint:                     map[string]uint64{"0": 64, "200": 16},
+		BackupMultiplier:           map[string]uint64{"0": 2},
+		StateSyncConfirmationDelay: map[string]uint64{"0": 128},
+		BurntContract:              map[string]string{"0": "0x000000000000000000000000000000000000dead"},
+		ValidatorContract:          "0x0000000000000000000000000000000000001000",
+		StateReceiverContract:      "0x0000000000000000000000000000000000001001",
+		JaipurBlock:                big.NewInt(50),
+		DelhiBlock:                 big.NewInt(200),
+		OverrideStateSyncRecords:   map[string]int{"150": 3},
+	}
+}
+
+func TestBorParamsAt(t *testing.T) {
+	t.Parallel()
+
+	config := testBorScheduleConfig()
+
+	params := config.ParamsAt(99)
+	assert.Equal(t, params.Period, uint64(2))
+	assert.Equal(t, params.Sprint, uint64(64))
+	assert.Equal(t, params.Jaipur, true)
+	assert.Equal(t, params.Delhi, false)
+	assert.Assert(t, params.OverrideStateSyncRecords == nil)
+
+	params = config.ParamsAt(150)
+	assert.Equal(t, params.Period, uint64(4))
+	assert.Equal(t, *params.OverrideStateSyncRecords, 3)
+
+	params = config.ParamsAt(200)
+	assert.Equal(t, params.Sprint, uint64(16))
+	assert.Equal(t, params.Delhi, true)
+}
+
+func TestBorParamsAtEmptySchedules(t *testing.T) {
+	t.Parallel()
+
+	config := testBorScheduleConfig()
+	config.StateSyncConfirmationDelay = map[string]uint64{}
+	config.BurntContract = nil
+
+	params := config.ParamsAt(150)
+	assert.Equal(t, params.Period, uint64(4))
+	assert.Equal(t, params.StateSyncConfirmationDelay, uint64(0))
+	assert.Equal(t, params.BurntContract, "")
+}
+
+func TestBorTransitions(t *testing.T) {
+	t.Parallel()
+
+	transitions := testBorScheduleConfig().Transitions(100)
+
+	assert.DeepEqual(t, transitions, []BorTransition{
+		{Block: 150, Field: "overrideStateSyncRecords", Value: "3"},
+		{Block: 200, Field: "delhi", Value: "true"},
+		{Block: 200, Field: "sprint", Value: "16"},
+	})
+}
+
+func TestDiffBorConfigs(t *testing.T) {
+	t.Parallel()
+
+	a, b := testBorScheduleConfig(), testBorScheduleConfig()
+	assert.Equal(t, len(DiffBorConfigs(a, b)), 0)
+
+	b.Period = map[string]uint64{"0": 2, "120": 4}
+	b.DelhiBlock = nil
+
+	assert.DeepEqual(t, DiffBorConfigs(a, b), []BorConfigDifference{
+		{Block: 100, Field: "period", A: "4", B: "2"},
+		{Block: 200, Field: "delhi", A: "true", B: "false"},
+	})
+}
+
+// Tests that the schedules compared by DiffBorConfigs resolve like ParamsAt,
+// including below their first block.
+func TestBorSchedulesMatchParamsAt(t *testing.T) {
+	t.Parallel()
+
+	a, b := testBorScheduleConfig(), testBorScheduleConfig()
+	a.Period = map[string]uint64{"10": 2, "100": 4}
+
+	for _, number := range []uint64{0, 9, 10, 99, 100, 1000} {
+		assert.Equal(t, a.schedules()[0].valueAt(number), fmt.Sprint(a.ParamsAt(number).Period))
+	}
+
+	// Below its first block, the schedule of a resolves to its last value
+	assert.DeepEqual(t, DiffBorConfigs(a, b), []BorConfigDifference{
+		{Block: 0, Field: "period", A: "4", B: "2"},
+	})
+}
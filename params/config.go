@@ -19,7 +19,6 @@ package params
 import (
 	"fmt"
 	"math/big"
-	"sort"
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -625,7 +624,6 @@ func (c *BorConfig) IsSprintStart(number uint64) bool {
 }
 
 func borKeyValueConfigHelper[T uint64 | string](field map[string]T, number uint64) T {
-	keys := make([]uint64, 0, len(field))
 	fieldUint := make(map[uint64]T)
 
 	for k, v := range field {
@@ -634,20 +632,10 @@ func borKeyValueConfigHelper[T uint64 | string](field map[string]T, number uint6
 			panic(err)
 		}
 
-		keys = append(keys, keyUint)
-
 		fieldUint[keyUint] = v
 	}
 
-	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
-
-	for i := 0; i < len(keys)-1; i++ {
-		if number >= keys[i] && number < keys[i+1] {
-			return fieldUint[keys[i]]
-		}
-	}
-
-	return fieldUint[keys[len(keys)-1]]
+	return lookupSchedule(fieldUint, number)
 }
 
 func (c *BorConfig) CalculateBurntContract(number uint64) string {